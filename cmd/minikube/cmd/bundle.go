@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bundle"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/util"
+)
+
+var (
+	bundleKubernetesVersion string
+	bundleDriver            string
+	bundleContainerRuntime  string
+	bundleCNI               string
+	bundleAddons            []string
+	bundleOutput            string
+)
+
+// bundleCmd represents the bundle command
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export and install the artifacts needed to start a cluster offline",
+	Long:  "Export the artifacts needed to start a cluster into a single archive, and install it on an air-gapped host to run 'minikube start --offline'.",
+}
+
+var createBundleCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a bundle of the artifacts needed to start a cluster",
+	Long: `Download the ISO or base image, the preload, the Kubernetes binaries, and the CNI and addon images needed to start a cluster, and write them into a single archive.
+The archive is created for the architecture of this host.`,
+	Example: `
+$ minikube bundle create --kubernetes-version v1.33.0 --driver docker --container-runtime containerd
+$ minikube bundle create --driver kvm2 --addons ingress,metrics-server -o airgap.tar.gz
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		if !driver.Supported(bundleDriver) {
+			exit.Message(reason.Usage, "The driver '{{.driver}}' is not supported on {{.os}}/{{.arch}}", out.V{"driver": bundleDriver, "os": runtime.GOOS, "arch": runtime.GOARCH})
+		}
+		if err := validateRuntime(bundleContainerRuntime); err != nil {
+			exit.Message(reason.Usage, "{{.err}}", out.V{"err": err})
+		}
+		switch bundleContainerRuntime {
+		case constants.DefaultContainerRuntime:
+			bundleContainerRuntime = defaultRuntime()
+		case "cri-o":
+			bundleContainerRuntime = constants.CRIO
+		}
+		k8sVersion := bundleKubernetesVersion
+		if _, err := util.ParseKubernetesVersion(k8sVersion); err != nil {
+			exit.Message(reason.Usage, "Invalid Kubernetes version: {{.version}}", out.V{"version": k8sVersion})
+		}
+		if !strings.HasPrefix(k8sVersion, "v") {
+			k8sVersion = "v" + k8sVersion
+		}
+		for _, a := range bundleAddons {
+			if _, ok := assets.Addons[a]; !ok {
+				exit.Message(reason.AddonUnsupported, "The addon '{{.name}}' is not a valid minikube addon", out.V{"name": a})
+			}
+		}
+
+		spec := bundle.Spec{
+			KubernetesVersion: k8sVersion,
+			Driver:            bundleDriver,
+			ContainerRuntime:  bundleContainerRuntime,
+			CNI:               bundleCNI,
+			Addons:            bundleAddons,
+		}
+		dst := bundleOutput
+		if dst == "" {
+			dst = fmt.Sprintf("minikube-bundle-%s-%s-%s.tar.gz", k8sVersion, bundleContainerRuntime, runtime.GOARCH)
+		}
+		m, err := bundle.Create(spec, dst)
+		if err != nil {
+			exit.Error(reason.InetBundleCreate, "Failed to create bundle", err)
+		}
+		out.Styled(style.Check, "Wrote {{.count}} artifacts to {{.path}}", out.V{"count": len(m.Files), "path": dst})
+	},
+}
+
+var installBundleCmd = &cobra.Command{
+	Use:   "install FILE",
+	Short: "Install a bundle into the local caches",
+	Long:  "Install the artifacts of a bundle created with 'minikube bundle create' into the local caches, so that 'minikube start --offline' does not need any network access.",
+	Example: `
+$ minikube bundle install minikube-bundle-v1.33.0-containerd-amd64.tar.gz
+`,
+	Run: func(_ *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Please provide the path of a bundle to install")
+		}
+		out.Step(style.Caching, "Installing bundle {{.path}} ...", out.V{"path": args[0]})
+		m, err := bundle.Install(args[0])
+		if err != nil {
+			exit.Error(reason.HostBundleInstall, "Failed to install bundle", err)
+		}
+		out.Styled(style.Check, "Installed {{.count}} artifacts for Kubernetes {{.version}} with {{.driver}} and {{.runtime}}", out.V{"count": len(m.Files), "version": m.KubernetesVersion, "driver": m.Driver, "runtime": m.ContainerRuntime})
+		startArgs := []string{"minikube", "start", "--offline", "--kubernetes-version=" + m.KubernetesVersion, "--driver=" + m.Driver, "--container-runtime=" + m.ContainerRuntime}
+		if m.CNI != "" {
+			startArgs = append(startArgs, "--cni="+m.CNI)
+		}
+		if len(m.Addons) > 0 {
+			startArgs = append(startArgs, "--addons="+strings.Join(m.Addons, ","))
+		}
+		out.Styled(style.Tip, "To start a cluster without network access, run: \"{{.command}}\"", out.V{"command": strings.Join(startArgs, " ")})
+	},
+}
+
+func init() {
+	createBundleCmd.Flags().StringVar(&bundleKubernetesVersion, "kubernetes-version", constants.DefaultKubernetesVersion, "The Kubernetes version to bundle")
+	createBundleCmd.Flags().StringVar(&bundleDriver, "driver", driver.Docker, "The driver the bundle is used with")
+	createBundleCmd.Flags().StringVar(&bundleContainerRuntime, "container-runtime", constants.DefaultContainerRuntime, fmt.Sprintf("The container runtime the bundle is used with. Valid options: %s (default: auto)", strings.Join(cruntime.ValidRuntimes(), ", ")))
+	createBundleCmd.Flags().StringVar(&bundleCNI, "cni", "", "The CNI the bundle is used with, as in 'minikube start --cni' (default: auto)")
+	createBundleCmd.Flags().StringSliceVar(&bundleAddons, "addons", nil, "Additional addons to bundle the images of, besides the default ones")
+	createBundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "The path of the archive (default: minikube-bundle-<version>-<runtime>-<arch>.tar.gz)")
+	bundleCmd.AddCommand(createBundleCmd)
+	bundleCmd.AddCommand(installBundleCmd)
+}
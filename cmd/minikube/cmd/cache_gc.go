@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	cmdConfig "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/hostcache"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	cacheDUFormat  string
+	cacheGCMaxSize string
+	cacheGCDryRun  bool
+)
+
+// CacheUsage is the disk usage of a category of cached artifacts
+type CacheUsage struct {
+	Category  hostcache.Category `json:"category"`
+	Artifacts int                `json:"artifacts"`
+	Size      int64              `json:"size"`
+	InUse     int64              `json:"inUse"`
+}
+
+// duCacheCmd represents the cache du command
+var duCacheCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show the disk usage of the cache",
+	Long:  "Show how much disk space each category of artifacts cached on the host uses, and how much of it is used by existing profiles.",
+	Run: func(_ *cobra.Command, _ []string) {
+		artifacts := loadCachedArtifacts()
+
+		byCategory := map[hostcache.Category]*CacheUsage{}
+		total := CacheUsage{Category: "total"}
+		for _, a := range artifacts {
+			u, ok := byCategory[a.Category]
+			if !ok {
+				u = &CacheUsage{Category: a.Category}
+				byCategory[a.Category] = u
+			}
+			for _, cu := range []*CacheUsage{u, &total} {
+				cu.Artifacts++
+				cu.Size += a.Size
+				if !a.Evictable() {
+					cu.InUse += a.Size
+				}
+			}
+		}
+		usage := []CacheUsage{}
+		for _, u := range byCategory {
+			usage = append(usage, *u)
+		}
+		sort.Slice(usage, func(i, j int) bool { return usage[i].Size > usage[j].Size })
+
+		switch cacheDUFormat {
+		case "table":
+			data := [][]string{}
+			for _, u := range append(usage, total) {
+				data = append(data, []string{string(u.Category), fmt.Sprintf("%d", u.Artifacts), units.HumanSize(float64(u.Size)), units.HumanSize(float64(u.InUse))})
+			}
+			renderTable([]string{"Category", "Artifacts", "Size", "In Use"}, data)
+		case "json":
+			printJSON(usage)
+		case "yaml":
+			printYAML(usage)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'table', 'json', 'yaml'", out.V{"format": cacheDUFormat})
+		}
+	},
+}
+
+// gcCacheCmd represents the cache gc command
+var gcCacheCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Evict cached artifacts not used by any profile",
+	Long: `Evict the least recently used artifacts cached on the host until the cache fits in --max-size, or all of the ones not used by a profile if --max-size is not given.
+Artifacts used by an existing profile and downloads in progress are never evicted, and the registry cache is pruned with 'minikube cache registry prune' instead.`,
+	Example: `
+$ minikube cache gc --max-size=20GB
+$ minikube cache gc --dry-run
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		var maxSize int64
+		if cacheGCMaxSize != "" {
+			var err error
+			maxSize, err = units.FromHumanSize(cacheGCMaxSize)
+			if err != nil {
+				exit.Message(reason.Usage, "invalid --max-size {{.size}}: {{.error}}", out.V{"size": cacheGCMaxSize, "error": err})
+			}
+		}
+
+		evict := hostcache.Plan(loadCachedArtifacts(), maxSize)
+		var freed int64
+		removed := 0
+		for _, a := range evict {
+			if cacheGCDryRun {
+				out.Styled(style.Deleted, "Would evict {{.path}} ({{.size}})", out.V{"path": cachePath(a.Path), "size": units.HumanSize(float64(a.Size))})
+			} else {
+				if err := hostcache.Remove(a); err != nil {
+					if errors.Is(err, hostcache.ErrDownloading) {
+						out.WarningT("Skipping {{.path}}, another minikube instance is downloading it", out.V{"path": cachePath(a.Path)})
+						continue
+					}
+					exit.Error(reason.HostCacheGC, "Failed to evict cached artifact", err)
+				}
+				out.Styled(style.Deleted, "Evicted {{.path}} ({{.size}})", out.V{"path": cachePath(a.Path), "size": units.HumanSize(float64(a.Size))})
+			}
+			removed++
+			freed += a.Size
+		}
+		if cacheGCDryRun {
+			out.Styled(style.Check, "Would evict {{.count}} artifacts, reclaiming {{.size}}", out.V{"count": removed, "size": units.HumanSize(float64(freed))})
+			return
+		}
+		out.Styled(style.Check, "Evicted {{.count}} artifacts, reclaimed {{.size}}", out.V{"count": removed, "size": units.HumanSize(float64(freed))})
+	},
+}
+
+func init() {
+	duCacheCmd.Flags().StringVar(&cacheDUFormat, "format", "table", "Format to output the disk usage in. Options: table, json, yaml")
+	gcCacheCmd.Flags().StringVar(&cacheGCMaxSize, "max-size", "", "Size to shrink the cache to, not counting the registry cache (e.g. 20GB)")
+	gcCacheCmd.Flags().BoolVar(&cacheGCDryRun, "dry-run", false, "Only show the artifacts that would be evicted")
+	cacheCmd.AddCommand(duCacheCmd)
+	cacheCmd.AddCommand(gcCacheCmd)
+}
+
+// loadCachedArtifacts returns the artifacts cached on the host, with the profiles using them
+func loadCachedArtifacts() []*hostcache.Artifact {
+	images, err := cmdConfig.ListConfigMap(cacheImageConfigKey)
+	if err != nil {
+		exit.Error(reason.InternalListConfig, "Failed to get image map", err)
+	}
+	artifacts, err := hostcache.Load(images)
+	if err != nil {
+		exit.Error(reason.HostCacheGC, "Failed to list cached artifacts", err)
+	}
+	return artifacts
+}
+
+// cachePath returns the path of a cached artifact relative to the cache directory
+func cachePath(p string) string {
+	if rel, err := filepath.Rel(hostcache.Dir(), p); err == nil {
+		return rel
+	}
+	return p
+}
+
+// listCachedArtifacts prints a table of every artifact cached on the host
+func listCachedArtifacts() {
+	data := [][]string{}
+	for _, a := range loadCachedArtifacts() {
+		usedBy := strings.Join(a.UsedBy, ", ")
+		if a.Incomplete {
+			usedBy = strings.TrimSpace(usedBy + " (incomplete download)")
+		}
+		data = append(data, []string{string(a.Category), cachePath(a.Path), units.HumanSize(float64(a.Size)), units.HumanDuration(time.Since(a.LastUsed)) + " ago", usedBy})
+	}
+	renderTable([]string{"Category", "Path", "Size", "Last Used", "Used By"}, data)
+}
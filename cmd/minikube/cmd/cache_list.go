@@ -28,7 +28,10 @@ import (
 
 const defaultCacheListFormat = "{{.CacheImage}}\n"
 
-var cacheListFormat string
+var (
+	cacheListFormat string
+	cacheListAll    bool
+)
 
 // CacheListTemplate represents the cache list template
 type CacheListTemplate struct {
@@ -37,10 +40,15 @@ type CacheListTemplate struct {
 
 // listCacheCmd represents the cache list command
 var listCacheCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all available images from the local cache.",
-	Long:  "List all available images from the local cache.",
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List all available images from the local cache.",
+	Long:    "List all available images from the local cache, or with --all every artifact cached on the host, with its size, when it was last used and the profiles using it.",
 	Run: func(_ *cobra.Command, _ []string) {
+		if cacheListAll {
+			listCachedArtifacts()
+			return
+		}
 		images, err := cmdConfig.ListConfigMap(cacheImageConfigKey)
 		if err != nil {
 			exit.Error(reason.InternalListConfig, "Failed to get image map", err)
@@ -55,6 +63,7 @@ func init() {
 	listCacheCmd.Flags().StringVar(&cacheListFormat, "format", defaultCacheListFormat,
 		`Go template format string for the cache list output.  The format for Go templates can be found here: https://pkg.go.dev/text/template
 For the list of accessible variables for the template, see the struct values here: https://pkg.go.dev/k8s.io/minikube/cmd/minikube/cmd#CacheListTemplate`)
+	listCacheCmd.Flags().BoolVar(&cacheListAll, allFlag, false, "List every artifact cached on the host: ISOs, base images, preloads, binaries and images")
 	cacheCmd.AddCommand(listCacheCmd)
 }
 
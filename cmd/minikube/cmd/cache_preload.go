@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/cmd/minikube/cmd/flags"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var preloadNode string
+
+// preloadCacheCmd represents the cache preload command
+var preloadCacheCmd = &cobra.Command{
+	Use:   "preload",
+	Short: "Manage preload tarballs",
+	Long:  "Manage the tarballs of preloaded images and Kubernetes binaries that speed up starting a cluster.",
+}
+
+var createPreloadCacheCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a preload tarball from a running node",
+	Long: `Capture the image store and Kubernetes binaries of a running node into a preload tarball.
+New clusters with the same Kubernetes version, container runtime and image repository use it instead of the official preload, including clusters with a custom image repository.
+The kubelet and the container runtime of the node are stopped while the image store is captured, so that it is consistent.`,
+	Example: `
+$ minikube cache preload create
+$ minikube cache preload create -p custom --node custom-m02
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		co := mustload.Running(ClusterFlagValue(), flags.CommandOptions())
+		runner := co.CP.Runner
+		if preloadNode != "" {
+			runner = remoteCommandRunner(&co, preloadNode)
+		}
+
+		out.Step(style.Caching, "Creating preload for Kubernetes {{.version}} on {{.runtime}} ...", out.V{"version": co.Config.KubernetesConfig.KubernetesVersion, "runtime": co.Config.KubernetesConfig.ContainerRuntime})
+		path, err := machine.CreatePreload(runner, *co.Config)
+		if err != nil {
+			exit.Error(reason.GuestPreloadCreate, "Failed to create preload", err)
+		}
+		out.Styled(style.Check, "Saved preload to {{.path}}", out.V{"path": path})
+	},
+}
+
+func init() {
+	createPreloadCacheCmd.Flags().StringVarP(&preloadNode, "node", "n", "", "The node to capture. Defaults to the primary control plane.")
+	preloadCacheCmd.AddCommand(createPreloadCacheCmd)
+	cacheCmd.AddCommand(preloadCacheCmd)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/registrycache"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	registryCacheFormat  string
+	registryCacheMaxSize string
+	registryCachePort    int
+	registryCacheListen  []string
+)
+
+// registryCacheCmd represents the cache registry command
+var registryCacheCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage the registry cache shared by all profiles",
+	Long:  "Manage the pull-through cache of Docker Hub on the host, which profiles started with --registry-cache use as a registry mirror.",
+}
+
+var registryCacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of the registry cache",
+	Long:  "Show whether the registry cache is running, and how much of its size cap it uses.",
+	Run: func(_ *cobra.Command, _ []string) {
+		st, err := registrycache.GetStatus()
+		if err != nil {
+			exit.Error(reason.HostRegistryCache, "Failed to get registry cache status", err)
+		}
+
+		switch registryCacheFormat {
+		case "table":
+			frontend, state := "-", "Not started"
+			if st.Container != "" {
+				frontend, state = registrycache.ContainerName, st.Container
+			}
+			if st.Process != "" {
+				frontend, state = "process", st.Process
+				if st.Port != 0 {
+					frontend = fmt.Sprintf("process (port %d)", st.Port)
+				}
+			}
+			renderTable([]string{"Frontend", "State", "Blobs", "Size", "Max Size", "Directory"},
+				[][]string{{frontend, state, fmt.Sprintf("%d", st.Blobs), units.HumanSize(float64(st.Size)), units.HumanSize(float64(st.MaxSize)), st.Dir}})
+		case "json":
+			printJSON(st)
+		case "yaml":
+			printYAML(st)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'table', 'json', 'yaml'", out.V{"format": registryCacheFormat})
+		}
+	},
+}
+
+var registryCachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Shrink the registry cache to its size cap",
+	Long:  "Remove the least recently used layers from the registry cache until it fits in its size cap, which is the registry-cache-max-size config unless --max-size is given.",
+	Example: `
+$ minikube cache registry prune
+$ minikube cache registry prune --max-size=5GB
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		var maxSize int64
+		var err error
+		if registryCacheMaxSize != "" {
+			maxSize, err = units.FromHumanSize(registryCacheMaxSize)
+			if err != nil {
+				exit.Message(reason.Usage, "invalid --max-size {{.size}}: {{.error}}", out.V{"size": registryCacheMaxSize, "error": err})
+			}
+		} else if maxSize, err = registrycache.MaxSize(); err != nil {
+			exit.Error(reason.Usage, "Invalid registry cache size cap", err)
+		}
+
+		removed, freed, err := registrycache.Prune(maxSize)
+		if err != nil {
+			exit.Error(reason.HostRegistryCache, "Failed to prune the registry cache", err)
+		}
+		out.Styled(style.Deleted, "Removed {{.count}} blobs, reclaimed {{.size}}", out.V{"count": removed, "size": units.HumanSize(float64(freed))})
+	},
+}
+
+var registryCacheServeCmd = &cobra.Command{
+	Use:    "serve",
+	Short:  "Serve the registry cache",
+	Long:   "Run the registry cache process used by the drivers without a container runtime on the host.",
+	Hidden: true,
+	Run: func(_ *cobra.Command, _ []string) {
+		if err := registrycache.Serve(registryCacheListen, registryCachePort); err != nil {
+			exit.Error(reason.HostRegistryCache, "Failed to serve the registry cache", err)
+		}
+	},
+}
+
+func init() {
+	registryCacheStatusCmd.Flags().StringVar(&registryCacheFormat, "format", "table", "Format to output the status in. Options: table, json, yaml")
+	registryCachePruneCmd.Flags().StringVar(&registryCacheMaxSize, "max-size", "", "Size to shrink the registry cache to, instead of the registry-cache-max-size config (e.g. 5GB)")
+	registryCacheServeCmd.Flags().IntVar(&registryCachePort, "port", registrycache.ProcessPort, "Port to listen on")
+	registryCacheServeCmd.Flags().StringSliceVar(&registryCacheListen, "listen-address", []string{"127.0.0.1"}, "Addresses to listen on")
+	registryCacheCmd.AddCommand(registryCacheStatusCmd)
+	registryCacheCmd.AddCommand(registryCachePruneCmd)
+	registryCacheCmd.AddCommand(registryCacheServeCmd)
+	cacheCmd.AddCommand(registryCacheCmd)
+}
@@ -0,0 +1,519 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/client-go/util/homedir"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/cmd/minikube/cmd/flags"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/issuer"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/trust"
+	"k8s.io/minikube/pkg/minikube/users"
+)
+
+// certExpiringSoon is how long before their expiration certificates are reported as expiring
+const certExpiringSoon = 30 * 24 * time.Hour
+
+var (
+	certsOutput        string
+	certsRotateCA      bool
+	certsRotateForce   bool
+	certsIssuerOnce    bool
+	certsTrustStoreDir string
+	certsTrustNSS      bool
+)
+
+// certsCmd represents the certs command
+var certsCmd = &cobra.Command{
+	Use:   "certs",
+	Short: "Manage the certificates of a cluster and the minikube CA",
+	Long:  "Check and rotate the certificates minikube and kubeadm generate for a cluster, on the host and on its nodes, issue certificates for its Ingress objects, and trust the minikube CA on the host.",
+}
+
+// certsCheckCmd represents the certs check command
+var certsCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "List the certificates of a cluster with their expiration",
+	Long:  "List the certificates of the host used by a cluster, and the certificates and kubeconfigs of its running nodes, with their expiration.",
+	Run: func(_ *cobra.Command, _ []string) {
+		api, cc := mustload.Partial(ClusterFlagValue(), flags.CommandOptions())
+		defer api.Close()
+
+		expiries, err := bootstrapper.HostCertExpiries(cc.Name)
+		if err != nil {
+			exit.Error(reason.HostCerts, "Unable to read the certificates", err)
+		}
+		for _, n := range cc.Nodes {
+			name := config.MachineName(*cc, n)
+			r, err := nodeRunner(api, name)
+			if err != nil {
+				out.WarningT("Skipping the certificates of {{.node}}: {{.error}}", out.V{"node": name, "error": err})
+				continue
+			}
+			found, err := bootstrapper.NodeCertExpiries(r)
+			if err != nil {
+				exit.Error(reason.GuestCert, "Unable to read the certificates of "+name, err)
+			}
+			for _, e := range found {
+				e.Location = name
+				expiries = append(expiries, e)
+			}
+		}
+
+		switch strings.ToLower(certsOutput) {
+		case "table":
+			data := [][]string{}
+			for _, e := range expiries {
+				data = append(data, []string{e.Location, e.Path, e.Expires.Local().Format(time.RFC3339), residualTime(e.Expires)})
+			}
+			renderTable([]string{"Location", "Certificate", "Expires", "Residual Time"}, data)
+		case "json":
+			printJSON(expiries)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'table', 'json'", out.V{"format": certsOutput})
+		}
+		for _, e := range expiries {
+			if time.Until(e.Expires) < certExpiringSoon {
+				out.WarningT(`Some certificates expire within 30 days, run "minikube certs rotate" to renew them`)
+				break
+			}
+		}
+	},
+}
+
+// residualTime returns the time left before an expiration, in the style of kubeadm certs check-expiration
+func residualTime(expires time.Time) string {
+	left := time.Until(expires)
+	switch {
+	case left <= 0:
+		return "expired"
+	case left < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(left.Hours()))
+	case left < 365*24*time.Hour:
+		return fmt.Sprintf("%dd", int(left.Hours()/24))
+	default:
+		return fmt.Sprintf("%dy", int(left.Hours()/24/365))
+	}
+}
+
+// nodeRunner returns the command runner of a running node
+func nodeRunner(api libmachine.API, name string) (command.Runner, error) {
+	st, err := machine.Status(api, name)
+	if err != nil {
+		return nil, err
+	}
+	if st != state.Running.String() {
+		return nil, fmt.Errorf("the node is %s", strings.ToLower(st))
+	}
+	host, err := machine.LoadHost(api, name)
+	if err != nil {
+		return nil, err
+	}
+	return machine.CommandRunner(host)
+}
+
+// certsRotateCmd represents the certs rotate command
+var certsRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Regenerate the certificates of a running cluster",
+	Long: `Regenerate the certificates minikube and kubeadm generated for a running cluster, copy them to its nodes, restart the control plane and update the kubeconfig.
+With --ca, also regenerate the CAs of minikube, which are shared by every profile: the other profiles need a rotation too, and the pods of the cluster a restart. As the other clusters stop being trusted until then, --ca is refused while other profiles exist, unless --force is given.
+The CA supplied with --ca-cert is not regenerated, but installed again from its file, which may have been replaced.`,
+	Example: `
+$ minikube certs rotate
+$ minikube certs rotate --ca
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		co := mustload.Running(ClusterFlagValue(), flags.CommandOptions())
+		cc := co.Config
+
+		others := []string{}
+		if certsRotateCA {
+			others = otherProfiles(cc.Name)
+			if len(others) > 0 && !certsRotateForce {
+				exit.Message(reason.Usage, `The CAs of minikube are shared by the {{.profiles}} profiles, which stop being trusted until they are rotated too: delete them, or use --force to rotate the CAs anyway`, out.V{"profiles": strings.Join(others, ", ")})
+			}
+		}
+
+		type node struct {
+			config.Node
+			name      string
+			runner    command.Runner
+			caChanged bool
+		}
+		nodes := []node{}
+		var primary command.Runner
+		for _, n := range cc.Nodes {
+			name := config.MachineName(*cc, n)
+			r, err := nodeRunner(co.API, name)
+			if err != nil {
+				exit.Message(reason.GuestStatus, `Unable to rotate the certificates of {{.node}}, start the cluster first: {{.error}}`, out.V{"node": name, "error": err})
+			}
+			nodes = append(nodes, node{Node: n, name: name, runner: r})
+			if config.IsPrimaryControlPlane(*cc, n) {
+				primary = r
+			}
+		}
+
+		if certsRotateCA {
+			out.Step(style.Provisioning, "Generating new minikube CAs ...")
+			if err := bootstrapper.RotateSharedCACerts(cc.KubernetesConfig); err != nil {
+				exit.Error(reason.HostCerts, "Unable to generate the CAs", err)
+			}
+		} else if cc.KubernetesConfig.CACert != "" {
+			// the file of the CA supplied with --ca-cert may have been replaced since
+			if _, err := bootstrapper.InstallCustomCA(cc.KubernetesConfig); err != nil {
+				exit.Error(reason.HostCerts, "Unable to install the CA", err)
+			}
+		}
+		for i := range nodes {
+			changed, err := bootstrapper.NodeCAChanged(cc.Name, nodes[i].runner)
+			if err != nil {
+				exit.Error(reason.GuestCert, "Unable to read the CA of "+nodes[i].name, err)
+			}
+			nodes[i].caChanged = changed
+		}
+		if err := bootstrapper.RemoveProfileCerts(cc.Name); err != nil {
+			exit.Error(reason.HostCerts, "Unable to remove the certificates", err)
+		}
+
+		out.Step(style.Provisioning, "Generating certificates and copying them to the nodes ...")
+		for _, n := range nodes {
+			if err := bootstrapper.SetupCerts(*cc, n.Node, primary, n.runner); err != nil {
+				exit.Error(reason.GuestCert, "Unable to set up the certificates of "+n.name, err)
+			}
+		}
+
+		kubeadmCfg, err := bootstrapper.KubeadmConfig(primary)
+		if err != nil {
+			exit.Error(reason.GuestCert, "Unable to read the kubeadm config", err)
+		}
+		for _, n := range nodes {
+			if !n.ControlPlane {
+				continue
+			}
+			out.Step(style.Restarting, "Renewing the kubeadm certificates of {{.node}} and restarting its control plane ...", out.V{"node": n.name})
+			if err := bootstrapper.RenewKubeadmCerts(n.runner, *cc, kubeadmCfg); err != nil {
+				exit.Error(reason.GuestCert, "Unable to renew the kubeadm certificates of "+n.name, err)
+			}
+			cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: n.runner})
+			if err != nil {
+				exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
+			}
+			if err := bootstrapper.RestartControlPlane(n.runner, cr, *cc, n.Node); err != nil {
+				exit.Error(reason.GuestCert, "Unable to restart the control plane of "+n.name, err)
+			}
+		}
+
+		caChanged := false
+		for _, n := range nodes {
+			if !n.caChanged {
+				continue
+			}
+			caChanged = true
+			out.Step(style.Restarting, "Renewing the kubelet certificate of {{.node}} ...", out.V{"node": n.name})
+			if err := bootstrapper.RenewKubeletCert(n.runner, *cc, n.Node); err != nil {
+				exit.Error(reason.GuestCert, "Unable to renew the kubelet certificate of "+n.name, err)
+			}
+		}
+		if caChanged {
+			if err := bootstrapper.RestartSystemWorkloads(primary, *cc); err != nil {
+				out.WarningT("Unable to restart the kube-system workloads: {{.error}}", out.V{"error": err})
+			}
+			out.WarningT("The cluster CA changed: restart the pods talking to the apiserver so that they trust the new CA")
+		}
+
+		if cc.EmbedCerts {
+			if err := updateEmbeddedCerts(cc); err != nil {
+				exit.Error(reason.HostKubeconfigUpdate, "Failed to update kubeconfig", err)
+			}
+		}
+
+		if certsRotateCA {
+			if us, err := users.List(cc.Name); err == nil && len(us) > 0 {
+				out.WarningT(`The certificates of the users of the cluster were signed by the previous CA, run "minikube user add" again for each of them`)
+			}
+			for _, p := range others {
+				out.WarningT(`The "{{.profile}}" profile shares the CA, run "minikube certs rotate -p {{.profile}}" once it runs`, out.V{"profile": p})
+			}
+		}
+		out.Styled(style.Success, "Rotated the certificates of the cluster")
+	},
+}
+
+// otherProfiles returns the names of the profiles other than name, which share the CAs of minikube
+func otherProfiles(name string) []string {
+	profiles, err := config.ListValidProfiles()
+	if err != nil {
+		klog.Warningf("unable to list the profiles: %v", err)
+	}
+	others := []string{}
+	for _, p := range profiles {
+		if p.Name != name {
+			others = append(others, p.Name)
+		}
+	}
+	return others
+}
+
+// updateEmbeddedCerts embeds the renewed client certificate and CA into the kubeconfig context of a cluster
+func updateEmbeddedCerts(cc *config.ClusterConfig) error {
+	host, port, err := kubeconfig.Endpoint(cc.Name, kubeconfig.PathFromEnv())
+	if err != nil {
+		return err
+	}
+	kcs := &kubeconfig.Settings{
+		ClusterName:          cc.Name,
+		Namespace:            cc.KubernetesConfig.Namespace,
+		ClusterServerAddress: "https://" + net.JoinHostPort(host, strconv.Itoa(port)),
+		ClientCertificate:    localpath.ClientCert(cc.Name),
+		ClientKey:            localpath.ClientKey(cc.Name),
+		CertificateAuthority: localpath.ProfileCACert(cc.Name),
+		KeepContext:          true,
+		EmbedCerts:           true,
+	}
+	kcs.SetPath(kubeconfig.PathFromEnv())
+	klog.Infof("embedding the renewed certificates of %s in the kubeconfig", cc.Name)
+	return kubeconfig.Update(kcs)
+}
+
+// certsIssuerCmd represents the certs issuer command
+var certsIssuerCmd = &cobra.Command{
+	Use:   "issuer",
+	Short: "Issue the TLS secrets of the Ingress objects of a cluster, signed by the CA of the cluster",
+	Long: `Watch the Ingress objects of a cluster, and create the secret named by each of their TLS entries, with a certificate for the hosts of the entry signed by the CA of the cluster.
+Only localhost, the *.test hosts and the hosts of the domains of the issuer-domains config are issued, the other hosts are skipped. Set the domains with "minikube config set issuer-domains example.internal,corp.internal".
+Secrets are renewed when their hosts change, before they expire and when the CA changes. Secrets not issued by minikube are never modified.
+The issuer is an on-demand tool running in the foreground until it is interrupted, nothing runs it in the background. "minikube start" syncs the secrets once, as --once does: the Ingress objects created later get no secret, and the secrets are not renewed, until the issuer runs again. Run "minikube certs trust" so that the host trusts the certificates.`,
+	Example: `
+$ minikube certs issuer
+$ minikube certs issuer --once
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		co := mustload.Running(ClusterFlagValue(), flags.CommandOptions())
+		iss, err := newIssuer(co.Config.Name)
+		if err != nil {
+			exit.Error(reason.InternalKubernetesClient, "kubernetes client", err)
+		}
+
+		if certsIssuerOnce {
+			issued, err := iss.SyncAll(context.Background())
+			for _, i := range issued {
+				printIssued(i)
+			}
+			if err != nil {
+				exit.Error(reason.HostCerts, "Unable to issue the TLS secrets", err)
+			}
+			if len(issued) == 0 {
+				out.Styled(style.Empty, "No TLS secret to issue")
+			}
+			return
+		}
+
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+		out.Styled(style.Notice, "Issuing the TLS secrets of the Ingress objects of {{.cluster}}, press Ctrl-C to stop", out.V{"cluster": co.Config.Name})
+		err = iss.Run(ctx, func(ing *networkingv1.Ingress, issued []issuer.Issued, err error) {
+			for _, i := range issued {
+				printIssued(i)
+			}
+			if err != nil {
+				out.WarningT("Unable to issue the TLS secrets of the {{.namespace}}/{{.ingress}} Ingress: {{.error}}", out.V{"namespace": ing.Namespace, "ingress": ing.Name, "error": err})
+			}
+		})
+		if err != nil {
+			exit.Error(reason.HostCerts, "Unable to watch the Ingress objects", err)
+		}
+	},
+}
+
+// newIssuer returns the issuer of the TLS secrets of the Ingress objects of a cluster, for the domains of the
+// issuer-domains config
+func newIssuer(profile string) (*issuer.Issuer, error) {
+	domains, err := issuer.ParseDomains(viper.GetString(config.IssuerDomains))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s config", config.IssuerDomains)
+	}
+	client, err := kapi.Client(profile)
+	if err != nil {
+		return nil, err
+	}
+	return issuer.New(profile, client, domains), nil
+}
+
+// syncIngressSecrets issues the TLS secrets of the Ingress objects of a cluster once, as "minikube certs issuer --once"
+func syncIngressSecrets(profile string) {
+	iss, err := newIssuer(profile)
+	if err != nil {
+		out.WarningT("Unable to issue the TLS secrets of the Ingress objects: {{.error}}", out.V{"error": err})
+		return
+	}
+	issued, err := iss.SyncAll(context.Background())
+	for _, i := range issued {
+		printIssued(i)
+	}
+	if err != nil {
+		out.WarningT("Unable to issue the TLS secrets of the Ingress objects: {{.error}}", out.V{"error": err})
+	}
+}
+
+// printIssued prints a secret issued for an Ingress
+func printIssued(i issuer.Issued) {
+	v := out.V{"namespace": i.Namespace, "secret": i.Secret, "ingress": i.Ingress, "hosts": strings.Join(i.Hosts, ", ")}
+	if i.Renewed {
+		out.Styled(style.Success, "Renewed {{.namespace}}/{{.secret}} of the {{.ingress}} Ingress for {{.hosts}}", v)
+		return
+	}
+	out.Styled(style.Success, "Issued {{.namespace}}/{{.secret}} of the {{.ingress}} Ingress for {{.hosts}}", v)
+}
+
+// certsTrustCmd represents the certs trust command
+var certsTrustCmd = &cobra.Command{
+	Use:   "trust",
+	Short: "Trust the CA of a cluster on the host",
+	Long: `Install the CA of a cluster into the trust store of the host, and into the NSS databases of Firefox and Chromium, so that they trust the certificates of the cluster and of its Ingress objects.
+The CA is the one the cluster was started with, with --ca-cert, or else the minikube CA shared by the other profiles.
+The CA is not constrained to any domain, and its private key is stored unencrypted, in ~/.minikube/ca.key for the minikube CA: anyone who can read the key can then impersonate any website to this host. Run "minikube certs untrust" when the clusters are no longer used.
+Installing into the trust store requires sudo. Only Linux hosts are supported.`,
+	Run: func(_ *cobra.Command, _ []string) {
+		store := trustStore()
+		profile := ClusterFlagValue()
+		caCert, name := trustedCA(profile)
+		if _, err := os.Stat(caCert); err != nil {
+			exit.Message(reason.Usage, "The minikube CA does not exist yet, start a cluster first")
+		}
+
+		out.WarningT("The {{.name}} CA is trusted for every domain, and its key is {{.key}}: anyone who can read the key can impersonate any website to this host", out.V{"name": name, "key": localpath.ProfileCAKey(profile)})
+		out.Step(style.Permissions, "Installing the {{.name}} CA into {{.dir}}, which requires sudo ...", out.V{"name": name, "dir": store.Dir})
+		if err := store.Install(name, caCert); err != nil {
+			exit.Error(reason.HostCerts, "Unable to trust the CA", err)
+		}
+		if certsTrustNSS && !trust.HasCertutil() {
+			out.WarningT("certutil was not found: install the NSS tools, such as libnss3-tools or nss-tools, to trust the CA in Firefox and Chromium")
+		} else if certsTrustNSS {
+			for _, db := range trust.NSSDatabases(homedir.HomeDir()) {
+				if err := trust.AddToNSS(db, name, caCert); err != nil {
+					out.WarningT("Unable to trust the {{.name}} CA in {{.db}}: {{.error}}", out.V{"name": name, "db": db, "error": err})
+					continue
+				}
+				out.Styled(style.Check, "Trusted the {{.name}} CA in {{.db}}", out.V{"name": name, "db": db})
+			}
+		}
+		out.Styled(style.Success, "The host trusts the {{.name}} CA, restart your browsers to apply the change", out.V{"name": name})
+	},
+}
+
+// certsUntrustCmd represents the certs untrust command
+var certsUntrustCmd = &cobra.Command{
+	Use:   "untrust",
+	Short: "Stop trusting the CA of a cluster on the host",
+	Long:  "Remove the CA of a cluster, trusted by \"minikube certs trust\", from the trust store of the host, and from the NSS databases of Firefox and Chromium. Removing it from the trust store requires sudo.",
+	Run: func(_ *cobra.Command, _ []string) {
+		store := trustStore()
+		_, name := trustedCA(ClusterFlagValue())
+		removed, err := store.Remove(name)
+		if err != nil {
+			exit.Error(reason.HostCerts, "Unable to stop trusting the CA", err)
+		}
+		if removed {
+			out.Styled(style.Deleted, "Removed the {{.name}} CA from {{.dir}}", out.V{"name": name, "dir": store.Dir})
+		}
+		if certsTrustNSS && trust.HasCertutil() {
+			for _, db := range trust.NSSDatabases(homedir.HomeDir()) {
+				found, err := trust.RemoveFromNSS(db, name)
+				if err != nil {
+					out.WarningT("Unable to remove the {{.name}} CA from {{.db}}: {{.error}}", out.V{"name": name, "db": db, "error": err})
+					continue
+				}
+				if found {
+					out.Styled(style.Deleted, "Removed the {{.name}} CA from {{.db}}", out.V{"name": name, "db": db})
+					removed = true
+				}
+			}
+		}
+		if !removed {
+			out.Styled(style.Empty, "The host does not trust the {{.name}} CA", out.V{"name": name})
+		}
+	},
+}
+
+// trustedCA returns the CA the host trusts for the clusters of a profile, and its name in the trust stores: the CA
+// supplied with --ca-cert, or the minikube CA shared by the other profiles
+func trustedCA(profile string) (string, string) {
+	if ca := localpath.ProfileCACert(profile); ca != localpath.CACert() {
+		return ca, trust.Name(profile)
+	}
+	return localpath.CACert(), trust.Name("")
+}
+
+// trustStore returns the trust store of the host, exiting on the hosts that are not supported
+func trustStore() trust.SystemStore {
+	if runtime.GOOS != "linux" {
+		exit.Message(reason.Unimplemented, "Trusting the minikube CA is only supported on Linux hosts")
+	}
+	store, err := trust.FindSystemStore(certsTrustStoreDir)
+	if err != nil {
+		exit.Message(reason.Usage, "Unable to find the trust store of the host: {{.error}}", out.V{"error": err})
+	}
+	return store
+}
+
+func init() {
+	certsCheckCmd.Flags().StringVarP(&certsOutput, "output", "o", "table", "The output format, one of 'table', 'json'")
+	certsRotateCmd.Flags().BoolVar(&certsRotateCA, "ca", false, "Also regenerate the CAs of minikube, which are shared by every profile")
+	certsRotateCmd.Flags().BoolVar(&certsRotateForce, "force", false, "Regenerate the CAs of minikube with --ca even though other profiles share them")
+	certsIssuerCmd.Flags().BoolVar(&certsIssuerOnce, "once", false, "Sync the TLS secrets once instead of watching the Ingress objects")
+	for _, c := range []*cobra.Command{certsTrustCmd, certsUntrustCmd} {
+		c.Flags().StringVar(&certsTrustStoreDir, "trust-store-dir", "", "The trust store directory of the host, detected by default. The trust store is only updated for the directories of known Linux distributions")
+		c.Flags().BoolVar(&certsTrustNSS, "nss", true, "Also manage the CA in the NSS databases of Firefox and Chromium, with certutil")
+	}
+	certsCmd.AddCommand(certsCheckCmd)
+	certsCmd.AddCommand(certsRotateCmd)
+	certsCmd.AddCommand(certsIssuerCmd)
+	certsCmd.AddCommand(certsTrustCmd)
+	certsCmd.AddCommand(certsUntrustCmd)
+}
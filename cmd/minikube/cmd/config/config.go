@@ -168,10 +168,30 @@ var settings = []Setting{
 		name: config.Rootless,
 		set:  SetBool,
 	},
+	{
+		name:        config.ArtifactMirror,
+		set:         SetString,
+		validations: []setFn{IsValidArtifactMirror},
+	},
+	{
+		name:        config.DownloadSigningKey,
+		set:         SetString,
+		validations: []setFn{IsValidSigningKey},
+	},
 	{
 		name: config.MaxAuditEntries,
 		set:  SetInt,
 	},
+	{
+		name:        config.RegistryCacheMaxSize,
+		set:         SetString,
+		validations: []setFn{IsValidDiskSize},
+	},
+	{
+		name:        config.IssuerDomains,
+		set:         SetString,
+		validations: []setFn{IsValidIssuerDomains},
+	},
 }
 
 // ConfigCmd represents the config command
@@ -27,7 +27,10 @@ import (
 	units "github.com/docker/go-units"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/issuer"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 )
 
@@ -83,6 +86,22 @@ func IsValidURL(_, location string) error {
 	return nil
 }
 
+// IsValidArtifactMirror checks if a location can be used as an artifact mirror
+func IsValidArtifactMirror(_, location string) error {
+	return mirror.Validate(location)
+}
+
+// IsValidSigningKey checks if a key, or the file it is in, is a minisign public key
+func IsValidSigningKey(_, key string) error {
+	return download.ValidateSigningKey(key)
+}
+
+// IsValidIssuerDomains checks if a value is a comma separated list of domains
+func IsValidIssuerDomains(_, domains string) error {
+	_, err := issuer.ParseDomains(domains)
+	return err
+}
+
 // IsURLExists checks if a location actually exists
 func IsURLExists(_, location string) error {
 	parsed, err := url.Parse(location)
@@ -55,6 +55,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/run"
 	"k8s.io/minikube/pkg/minikube/sshagent"
 	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/users"
 )
 
 var (
@@ -102,6 +103,8 @@ var hostAndDirsDeleter = func(api libmachine.API, cc *config.ClusterConfig, prof
 
 	deleteHosts(api, cc)
 
+	deleteUserContexts(profileName)
+
 	// In case DeleteHost didn't complete the job.
 	deleteProfileDirectory(profileName)
 	deleteMachineDirectories(cc)
@@ -459,6 +462,20 @@ func deleteConfig(cname string) error {
 	return nil
 }
 
+// deleteUserContexts deletes the kubeconfig contexts of the client users of a profile
+func deleteUserContexts(profileName string) {
+	us, err := users.List(profileName)
+	if err != nil {
+		klog.Warningf("unable to list the users of %s: %v", profileName, err)
+		return
+	}
+	for _, u := range us {
+		if err := kubeconfig.DeleteUserContext(u.Context); err != nil {
+			klog.Warningf("unable to delete the context of %s: %v", u.Name, err)
+		}
+	}
+}
+
 func deleteContext(machineName string) error {
 	if err := kubeconfig.DeleteContext(machineName); err != nil {
 		return DeletionError{Err: fmt.Errorf("update config: %v", err), Errtype: Fatal}
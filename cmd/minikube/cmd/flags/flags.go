@@ -25,6 +25,7 @@ import (
 const (
 	Interactive  = "interactive"
 	DownloadOnly = "download-only"
+	Offline      = "offline"
 )
 
 // CommandOptions returns minikube runtime options from command line flags.
@@ -34,5 +35,6 @@ func CommandOptions() *run.CommandOptions {
 	return &run.CommandOptions{
 		NonInteractive: !viper.GetBool(Interactive),
 		DownloadOnly:   viper.GetBool(DownloadOnly),
+		Offline:        viper.GetBool(Offline),
 	}
 }
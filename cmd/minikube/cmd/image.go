@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"net/url"
 	"os"
@@ -24,15 +25,19 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/minikube/cmd/minikube/cmd/flags"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/image"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/run"
+	"k8s.io/minikube/pkg/minikube/style"
 	docker "k8s.io/minikube/third_party/go-dockerclient"
 )
 
@@ -47,16 +52,27 @@ var imageCmd = &cobra.Command{
 }
 
 var (
-	pull       bool
-	imgDaemon  bool
-	imgRemote  bool
-	overwrite  bool
-	tag        string
-	push       bool
-	dockerFile string
-	buildEnv   []string
-	buildOpt   []string
-	format     string
+	pull         bool
+	imgDaemon    bool
+	imgRemote    bool
+	overwrite    bool
+	tag          string
+	push         bool
+	dockerFile   string
+	buildEnv     []string
+	buildOpt     []string
+	platforms    []string
+	cacheFrom    string
+	cacheTo      string
+	format       string
+	reportFormat string
+	saveFormat   string
+	saveOutput   string
+	pruneDry     bool
+	syncFrom     string
+	syncFromHost bool
+	syncTo       []string
+	usedBy       bool
 )
 
 func saveFile(r io.Reader) (string, error) {
@@ -77,10 +93,10 @@ func saveFile(r io.Reader) (string, error) {
 
 // loadImageCmd represents the image load command
 var loadImageCmd = &cobra.Command{
-	Use:     "load IMAGE | ARCHIVE | -",
+	Use:     "load IMAGE | ARCHIVE | LAYOUT | -",
 	Short:   "Load an image into minikube",
-	Long:    "Load an image into minikube",
-	Example: "minikube image load image\nminikube image load image.tar",
+	Long:    "Load an image into minikube. Archives may contain several images, and OCI image layouts can be given as a directory or a tar archive.",
+	Example: "minikube image load image\nminikube image load image.tar\nminikube image load ./oci-layout",
 	Run: func(_ *cobra.Command, args []string) {
 		if len(args) == 0 {
 			exit.Message(reason.Usage, "Please provide an image in your local daemon to load into minikube via <minikube image load IMAGE_NAME>")
@@ -143,6 +159,18 @@ var loadImageCmd = &cobra.Command{
 				exit.Error(reason.GuestImageLoad, "Failed to load image", err)
 			}
 		} else if local {
+			// OCI image layouts are converted to docker archives, which all runtimes can load
+			for i, img := range args {
+				if !image.IsOCILayout(img) {
+					continue
+				}
+				tmp, err := image.ConvertOCILayout(img)
+				if err != nil {
+					exit.Error(reason.GuestImageLoad, "Failed to convert OCI layout", err)
+				}
+				defer os.Remove(tmp)
+				args[i] = tmp
+			}
 			// Load images from local files, without doing any caching or checks in container runtime
 			// This is similar to tarball.Image but it is done by the container runtime in the cluster.
 			if err := machine.DoLoadImages(args, []*config.Profile{profile}, "", overwrite, options); err != nil {
@@ -173,11 +201,14 @@ var saveImageCmd = &cobra.Command{
 	Use:     "save IMAGE [ARCHIVE | -]",
 	Short:   "Save a image from minikube",
 	Long:    "Save a image from minikube",
-	Example: "minikube image save image\nminikube image save image image.tar",
+	Example: "minikube image save image\nminikube image save image image.tar\nminikube image save --format oci -o images.tar image1 image2",
 	Run: func(_ *cobra.Command, args []string) {
 		if len(args) == 0 {
 			exit.Message(reason.Usage, "Please provide an image in the container runtime to save from minikube via <minikube image save IMAGE_NAME>")
 		}
+		if saveFormat != image.FormatDocker && saveFormat != image.FormatOCI {
+			exit.Message(reason.Usage, "invalid archive format: {{.format}}. Valid values: {{.formats}}", out.V{"format": saveFormat, "formats": strings.Join(image.ArchiveFormats(), ", ")})
+		}
 
 		options := flags.CommandOptions()
 		// Save images from container runtime
@@ -186,28 +217,41 @@ var saveImageCmd = &cobra.Command{
 			exit.Error(reason.Usage, "loading profile", err)
 		}
 
-		if len(args) > 1 {
-			output = args[1]
+		images := args[:1]
+		dst := ""
+		if saveOutput != "" {
+			images = args
+			dst = saveOutput
+		} else if len(args) > 1 {
+			dst = args[1]
+		}
 
-			if args[1] == "-" {
+		if dst != "" {
+			toStdout := dst == "-"
+			if toStdout {
 				tmp, err := os.CreateTemp("", "image.*.tar")
 				if err != nil {
 					exit.Error(reason.GuestImageSave, "Failed to get temp", err)
 				}
 				tmp.Close()
-				output = tmp.Name()
+				dst = tmp.Name()
 			}
 
-			if err := machine.DoSaveImages([]string{args[0]}, output, []*config.Profile{profile}, "", options); err != nil {
+			if len(images) > 1 || saveFormat != image.FormatDocker {
+				err = machine.SaveImagesToArchive(images, dst, saveFormat, profile, options)
+			} else {
+				err = machine.DoSaveImages(images, dst, []*config.Profile{profile}, "", options)
+			}
+			if err != nil {
 				exit.Error(reason.GuestImageSave, "Failed to save image", err)
 			}
 
-			if args[1] == "-" {
-				err := readFile(os.Stdout, output)
+			if toStdout {
+				err := readFile(os.Stdout, dst)
 				if err != nil {
 					exit.Error(reason.GuestImageSave, "Failed to read temp", err)
 				}
-				os.Remove(output)
+				os.Remove(dst)
 			}
 		} else {
 			if err := machine.SaveAndCacheImages([]string{args[0]}, []*config.Profile{profile}, options); err != nil {
@@ -276,10 +320,12 @@ func createTar(dir string) (string, error) {
 
 // buildImageCmd represents the image build command
 var buildImageCmd = &cobra.Command{
-	Use:     "build PATH | URL | -",
-	Short:   "Build a container image in minikube",
-	Long:    "Build a container image, using the container runtime.",
-	Example: `minikube image build .`,
+	Use:   "build PATH | URL | -",
+	Short: "Build a container image in minikube",
+	Long:  "Build a container image, using the container runtime.",
+	Example: `minikube image build .
+minikube image build --platform linux/amd64,linux/arm64 -t example.com/app --push .
+minikube image build --cache-from app --cache-to app -t app .`,
 	Run: func(_ *cobra.Command, args []string) {
 		if len(args) < 1 {
 			exit.Message(reason.Usage, "Please provide a path or url to build")
@@ -326,7 +372,16 @@ var buildImageCmd = &cobra.Command{
 			out.Stringf("minikube detects that you are using DOS-style path %s. minikube will convert it to UNIX-style by replacing all \\ to /\n", dockerFile)
 			dockerFile = strings.ReplaceAll(dockerFile, "\\", "/")
 		}
-		if err := machine.BuildImage(img, dockerFile, tag, push, buildEnv, buildOpt, []*config.Profile{profile}, allNodes, nodeName, options); err != nil {
+		buildPlatforms, err := cruntime.ParsePlatforms(platforms)
+		if err != nil {
+			exit.Message(reason.Usage, "Invalid --platform: {{.error}}", out.V{"error": err})
+		}
+		for _, name := range []string{cacheFrom, cacheTo} {
+			if name != "" && !machine.ValidBuildCacheName(name) {
+				exit.Message(reason.Usage, "Invalid build cache name {{.name}}: only alphanumeric characters, dots, dashes and underscores are permitted", out.V{"name": name})
+			}
+		}
+		if err := machine.BuildImage(img, dockerFile, tag, push, buildEnv, buildOpt, buildPlatforms, cacheFrom, cacheTo, []*config.Profile{profile}, allNodes, nodeName, options); err != nil {
 			exit.Error(reason.GuestImageBuild, "Failed to build image", err)
 		}
 		if tmp != "" {
@@ -340,6 +395,7 @@ var listImageCmd = &cobra.Command{
 	Short: "List images",
 	Example: `
 $ minikube image ls
+$ minikube image ls --used-by --format table
 `,
 	Aliases: []string{"list"},
 	Run: func(_ *cobra.Command, _ []string) {
@@ -349,6 +405,10 @@ $ minikube image ls
 			exit.Error(reason.Usage, "loading profile", err)
 		}
 
+		if usedBy {
+			listImageUsage(profile, options)
+			return
+		}
 		if err := machine.ListImages(profile, format, options); err != nil {
 			exit.Error(reason.GuestImageList, "Failed to list images", err)
 		}
@@ -398,6 +458,176 @@ $ minikube image push busybox
 	},
 }
 
+var duImageCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show image disk usage",
+	Long:  "Show image and container storage usage for each node, aggregated from the images in the container runtime and the node filesystem.",
+	Example: `
+$ minikube image du
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		options := flags.CommandOptions()
+		profile, err := config.LoadProfile(viper.GetString(config.ProfileName))
+		if err != nil {
+			exit.Error(reason.Usage, "loading profile", err)
+		}
+
+		usages, err := machine.ImageDiskUsage(profile, options)
+		if err != nil {
+			exit.Error(reason.GuestImageList, "Failed to get image disk usage", err)
+		}
+
+		switch reportFormat {
+		case "table":
+			var data [][]string
+			for _, u := range usages {
+				data = append(data, []string{u.Node, fmt.Sprintf("%d", u.Images), units.HumanSize(float64(u.ImagesSize)), units.HumanSize(float64(u.StorageSize)), units.HumanSize(float64(u.DiskUsed)), units.HumanSize(float64(u.DiskAvailable))})
+			}
+			renderTable([]string{"Node", "Images", "Images Size", "Runtime Storage", "Disk Used", "Disk Available"}, data)
+		case "json":
+			printJSON(usages)
+		case "yaml":
+			printYAML(usages)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'table', 'json', 'yaml'", out.V{"format": reportFormat})
+		}
+	},
+}
+
+var pruneImageCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove unused images",
+	Long:  "Remove images that are not referenced by any pod in the cluster from all nodes.",
+	Example: `
+$ minikube image prune --dry-run
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		options := flags.CommandOptions()
+		profile, err := config.LoadProfile(viper.GetString(config.ProfileName))
+		if err != nil {
+			exit.Error(reason.Usage, "loading profile", err)
+		}
+
+		pruned, err := machine.PruneImages(profile, pruneDry, options)
+		if err != nil {
+			exit.Error(reason.GuestImageRemove, "Failed to prune images", err)
+		}
+
+		switch reportFormat {
+		case "table":
+			var data [][]string
+			var total int64
+			for _, p := range pruned {
+				data = append(data, []string{p.Node, strings.Join(p.RepoTags, ", "), p.ID, units.HumanSize(float64(p.Size))})
+				total += p.Size
+			}
+			renderTable([]string{"Node", "Image", "Image ID", "Size"}, data)
+			if pruneDry {
+				out.Styled(style.Notice, "Would reclaim {{.size}}", out.V{"size": units.HumanSize(float64(total))})
+			} else {
+				out.Styled(style.Deleted, "Reclaimed {{.size}}", out.V{"size": units.HumanSize(float64(total))})
+			}
+		case "json":
+			printJSON(pruned)
+		case "yaml":
+			printYAML(pruned)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'table', 'json', 'yaml'", out.V{"format": reportFormat})
+		}
+	},
+}
+
+var syncImageCmd = &cobra.Command{
+	Use:   "sync [IMAGE...]",
+	Short: "Synchronise images between profiles, or from the host",
+	Long:  "Copy the images missing on the nodes of the target profiles from the source profile, or from the host container daemon. Images are compared by ID and streamed directly between the container runtimes. Without arguments, all tagged images of the source profile are synchronised.",
+	Example: `
+$ minikube image sync --from dev --to staging,test
+$ minikube image sync --from dev --to staging example.com/app:v1
+$ minikube image sync --from-host --to dev,staging example.com/app:v1
+`,
+	Run: func(_ *cobra.Command, args []string) {
+		if syncFromHost == (syncFrom != "") {
+			exit.Message(reason.Usage, "Please specify exactly one of --from or --from-host")
+		}
+		if len(syncTo) == 0 {
+			exit.Message(reason.Usage, "Please specify the target profiles with --to")
+		}
+		if syncFromHost && len(args) == 0 {
+			exit.Message(reason.Usage, "Please provide the images to sync from the host")
+		}
+		for _, p := range append([]string{syncFrom}, syncTo...) {
+			if p == "" {
+				continue
+			}
+			if _, err := config.LoadProfile(p); err != nil {
+				exit.Message(reason.Usage, "loading profile {{.profile}}: {{.error}}", out.V{"profile": p, "error": err})
+			}
+		}
+
+		options := flags.CommandOptions()
+		synced, err := machine.SyncImages(args, syncFrom, syncFromHost, syncTo, options)
+		for _, s := range synced {
+			out.Styled(style.Check, "Synced {{.image}} from {{.from}} to {{.to}}", out.V{"image": s.Image, "from": s.From, "to": s.To})
+		}
+		if err != nil {
+			exit.Error(reason.GuestImageLoad, "Failed to sync images", err)
+		}
+		if len(synced) == 0 {
+			out.Styled(style.Check, "All images are up to date")
+		}
+	},
+}
+
+// listImageUsage prints the images of profile together with the nodes holding them and the pods using them
+func listImageUsage(profile *config.Profile, options *run.CommandOptions) {
+	inventory, err := machine.ImageInventory(profile, options)
+	if err != nil {
+		exit.Error(reason.GuestImageList, "Failed to list image usage", err)
+	}
+
+	users := func(img machine.InventoryImage) []string {
+		pods := []string{}
+		for _, u := range img.UsedBy {
+			pods = append(pods, u.Namespace+"/"+u.Pod)
+		}
+		return pods
+	}
+	name := func(img machine.InventoryImage) string {
+		if img.Dangling {
+			return "<none>"
+		}
+		return strings.Join(img.RepoTags, ", ")
+	}
+
+	switch format {
+	case "short":
+		for _, img := range inventory {
+			pods := users(img)
+			if len(pods) == 0 {
+				pods = []string{"-"}
+			}
+			out.Ln("%s\t%s", name(img), strings.Join(pods, ","))
+		}
+	case "table":
+		var data [][]string
+		for _, img := range inventory {
+			pods := users(img)
+			if len(pods) == 0 {
+				pods = []string{"-"}
+			}
+			data = append(data, []string{name(img), img.ID[:min(len(img.ID), 13)], units.HumanSizeWithPrecision(float64(img.Size), 3), strings.Join(img.Nodes, ", "), strings.Join(pods, ", ")})
+		}
+		renderTable([]string{"Image", "Image ID", "Size", "Nodes", "Used By"}, data)
+	case "json":
+		printJSON(inventory)
+	case "yaml":
+		printYAML(inventory)
+	default:
+		exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'short', 'table', 'json', 'yaml'", out.V{"format": format})
+	}
+}
+
 func init() {
 	loadImageCmd.Flags().BoolVar(&pull, "pull", false, "Pull the remote image (no caching)")
 	loadImageCmd.Flags().BoolVar(&imgDaemon, "daemon", false, "Cache image from docker daemon")
@@ -413,12 +643,27 @@ func init() {
 	buildImageCmd.Flags().StringArrayVar(&buildOpt, "build-opt", nil, "Specify arbitrary flags to pass to the build. (format: key=value)")
 	buildImageCmd.Flags().StringVarP(&nodeName, "node", "n", "", "The node to build on. Defaults to the primary control plane.")
 	buildImageCmd.Flags().BoolVar(&allNodes, "all", false, "Build image on all nodes.")
+	buildImageCmd.Flags().StringSliceVar(&platforms, "platform", nil, "Platforms to build the image for, using QEMU emulation for foreign architectures (format: linux/amd64,linux/arm64). Several platforms produce a manifest list, and require the containerd or cri-o runtime.")
+	buildImageCmd.Flags().StringVar(&cacheFrom, "cache-from", "", "Name of a build cache in the minikube cache directory to import before building (optional)")
+	buildImageCmd.Flags().StringVar(&cacheTo, "cache-to", "", "Name of a build cache in the minikube cache directory to export after building (optional)")
 	imageCmd.AddCommand(buildImageCmd)
 	saveImageCmd.Flags().BoolVar(&imgDaemon, "daemon", false, "Cache image to docker daemon")
 	saveImageCmd.Flags().BoolVar(&imgRemote, "remote", false, "Cache image to remote registry")
+	saveImageCmd.Flags().StringVar(&saveFormat, "format", image.FormatDocker, "Archive format. One of: docker|oci. The oci format writes a layout directory, or an archive if the output ends with .tar")
+	saveImageCmd.Flags().StringVarP(&saveOutput, "output", "o", "", "Save all given images into this archive, sharing common layers")
 	imageCmd.AddCommand(saveImageCmd)
 	listImageCmd.Flags().StringVar(&format, "format", "short", "Format output. One of: short|table|json|yaml")
+	listImageCmd.Flags().BoolVar(&usedBy, "used-by", false, "Show the nodes holding each image and the pods using it, including dangling images")
 	imageCmd.AddCommand(listImageCmd)
 	imageCmd.AddCommand(tagImageCmd)
 	imageCmd.AddCommand(pushImageCmd)
+	duImageCmd.Flags().StringVar(&reportFormat, "format", "table", "Format output. One of: table|json|yaml")
+	imageCmd.AddCommand(duImageCmd)
+	pruneImageCmd.Flags().BoolVar(&pruneDry, "dry-run", false, "Only list the images that would be removed")
+	pruneImageCmd.Flags().StringVar(&reportFormat, "format", "table", "Format output. One of: table|json|yaml")
+	imageCmd.AddCommand(pruneImageCmd)
+	syncImageCmd.Flags().StringVar(&syncFrom, "from", "", "Profile to copy images from")
+	syncImageCmd.Flags().BoolVar(&syncFromHost, "from-host", false, "Copy images from the host container daemon")
+	syncImageCmd.Flags().StringSliceVar(&syncTo, "to", nil, "Profiles to copy images to (format: PROFILE[,PROFILE...])")
+	imageCmd.AddCommand(syncImageCmd)
 }
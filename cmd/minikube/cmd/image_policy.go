@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/cmd/minikube/cmd/flags"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/imagepolicy"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var policyFormat string
+
+// imagePolicy is the image policy of a cluster, as shown by `minikube image policy`
+type imagePolicy struct {
+	Allowed []string `json:"allowed" yaml:"allowed"`
+	Denied  []string `json:"denied" yaml:"denied"`
+	System  []string `json:"system" yaml:"system"`
+}
+
+var policyImageCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "View and edit the registries images may come from",
+	Long: `View and edit the image policy of the cluster. If registries are allowed, images may only come from them; images may never come from denied registries.
+The policy is enforced by the container runtime of each node, and on admission for pods outside of kube-system and the namespaces of the CNI (Kubernetes v1.30 or later). The registries of the system and CNI images are never blocked by the container runtime.
+The docker container runtime has no way to restrict registries, so with it the policy is only enforced on admission: images pulled on the nodes directly, for instance with "minikube ssh docker pull", are not restricted.`,
+	Example: `
+$ minikube image policy
+$ minikube image policy allow docker.io registry.k8s.io
+$ minikube image policy deny quay.io
+$ minikube image policy remove docker.io
+$ minikube image policy clear
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		showImagePolicy()
+	},
+}
+
+var showPolicyImageCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the image policy",
+	Run: func(_ *cobra.Command, _ []string) {
+		showImagePolicy()
+	},
+}
+
+var allowPolicyImageCmd = &cobra.Command{
+	Use:   "allow REGISTRY...",
+	Short: "Only allow images from the given registries, in addition to the already allowed ones",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		updateImagePolicy(func(p *config.ImagePolicy, registries []string) {
+			p.AllowedRegistries = append(p.AllowedRegistries, registries...)
+			p.DeniedRegistries = withoutRegistries(p.DeniedRegistries, registries)
+		}, args)
+	},
+}
+
+var denyPolicyImageCmd = &cobra.Command{
+	Use:   "deny REGISTRY...",
+	Short: "Deny images from the given registries",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		updateImagePolicy(func(p *config.ImagePolicy, registries []string) {
+			p.DeniedRegistries = append(p.DeniedRegistries, registries...)
+			p.AllowedRegistries = withoutRegistries(p.AllowedRegistries, registries)
+		}, args)
+	},
+}
+
+var removePolicyImageCmd = &cobra.Command{
+	Use:   "remove REGISTRY...",
+	Short: "Remove registries from the allowed and denied registries",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		updateImagePolicy(func(p *config.ImagePolicy, registries []string) {
+			p.AllowedRegistries = withoutRegistries(p.AllowedRegistries, registries)
+			p.DeniedRegistries = withoutRegistries(p.DeniedRegistries, registries)
+		}, args)
+	},
+}
+
+var clearPolicyImageCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the image policy, allowing images from any registry",
+	Run: func(_ *cobra.Command, _ []string) {
+		updateImagePolicy(func(p *config.ImagePolicy, _ []string) {
+			*p = config.ImagePolicy{}
+		}, nil)
+	},
+}
+
+// withoutRegistries returns list without the registries in remove
+func withoutRegistries(list []string, remove []string) []string {
+	kept := []string{}
+	for _, r := range list {
+		found := false
+		for _, rm := range remove {
+			if r == rm {
+				found = true
+				break
+			}
+		}
+		if !found {
+			kept = append(kept, r)
+		}
+	}
+	return kept
+}
+
+func showImagePolicy() {
+	_, cc := mustload.Partial(ClusterFlagValue(), flags.CommandOptions())
+	p := imagePolicy{
+		Allowed: cc.ImagePolicy.AllowedRegistries,
+		Denied:  cc.ImagePolicy.DeniedRegistries,
+		System:  imagepolicy.SystemRegistries(*cc),
+	}
+	switch policyFormat {
+	case "table":
+		if imagepolicy.IsEmpty(cc.ImagePolicy) {
+			out.Styled(style.Option, "No image policy, images may come from any registry")
+			return
+		}
+		var data [][]string
+		for _, r := range p.Allowed {
+			data = append(data, []string{r, "allowed"})
+		}
+		for _, r := range p.Denied {
+			data = append(data, []string{r, "denied"})
+		}
+		renderTable([]string{"Registry", "Policy"}, data)
+		out.Styled(style.Notice, "The container runtime never blocks the system image registries: {{.registries}}", out.V{"registries": strings.Join(p.System, ", ")})
+		if cc.KubernetesConfig.ContainerRuntime == constants.Docker {
+			out.Styled(style.Notice, "The docker container runtime cannot block registries, the image policy is only enforced on admission")
+		}
+	case "json":
+		printJSON(p)
+	case "yaml":
+		printYAML(p)
+	default:
+		exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'table', 'json', 'yaml'", out.V{"format": policyFormat})
+	}
+}
+
+// updateImagePolicy edits the image policy of the cluster, saves it, and applies it to the running nodes
+func updateImagePolicy(edit func(p *config.ImagePolicy, registries []string), args []string) {
+	registries, err := imagepolicy.Normalize(args)
+	if err != nil {
+		exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+	}
+
+	options := flags.CommandOptions()
+	_, cc := mustload.Partial(ClusterFlagValue(), options)
+	edit(&cc.ImagePolicy, registries)
+	if cc.ImagePolicy.AllowedRegistries, err = imagepolicy.Normalize(cc.ImagePolicy.AllowedRegistries); err != nil {
+		exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+	}
+	if cc.ImagePolicy.DeniedRegistries, err = imagepolicy.Normalize(cc.ImagePolicy.DeniedRegistries); err != nil {
+		exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+	}
+	if err := config.SaveProfile(cc.Name, cc); err != nil {
+		exit.Error(reason.HostSaveProfile, "Failed to save the image policy", err)
+	}
+
+	err = imagepolicy.Apply(cc, options)
+	if errors.Is(err, imagepolicy.ErrAdmissionUnsupported) {
+		out.WarningT("The image policy is not enforced on admission: {{.error}}", out.V{"error": err})
+	} else if err != nil {
+		exit.Error(reason.GuestImagePolicy, "Failed to apply the image policy", err)
+	}
+	out.Styled(style.Check, "Updated the image policy of {{.profile}}", out.V{"profile": cc.Name})
+	showImagePolicy()
+}
+
+func init() {
+	policyImageCmd.PersistentFlags().StringVar(&policyFormat, "format", "table", "Format output. One of: table|json|yaml")
+	policyImageCmd.AddCommand(showPolicyImageCmd)
+	policyImageCmd.AddCommand(allowPolicyImageCmd)
+	policyImageCmd.AddCommand(denyPolicyImageCmd)
+	policyImageCmd.AddCommand(removePolicyImageCmd)
+	policyImageCmd.AddCommand(clearPolicyImageCmd)
+	imageCmd.AddCommand(policyImageCmd)
+}
@@ -57,6 +57,10 @@ var (
 	auditLogs bool
 	// lastStartOnly shows logs from last start
 	lastStartOnly bool
+	// k8sAuditLogs only shows the audit logs of the apiserver
+	k8sAuditLogs bool
+	// k8sAuditFilter selects the apiserver audit events to show
+	k8sAuditFilter logs.AuditFilter
 )
 
 // logsCmd represents the logs command
@@ -103,6 +107,16 @@ var logsCmd = &cobra.Command{
 
 		co := mustload.Running(ClusterFlagValue(), options)
 
+		if k8sAuditLogs {
+			if co.Config.KubernetesConfig.AuditPolicy == "" {
+				exit.Message(reason.Usage, "The apiserver audit log is not enabled, restart the cluster with --apiserver-audit-policy")
+			}
+			if err := logs.OutputK8sAudit(co.CP.Runner, k8sAuditFilter, numberOfLines, logOutput); err != nil {
+				exit.Error(reason.InternalCommandRunner, "Unable to read the apiserver audit log", err)
+			}
+			return
+		}
+
 		bs, err := cluster.Bootstrapper(co.API, viper.GetString(cmdcfg.Bootstrapper), *co.Config, co.CP.Runner)
 		if err != nil {
 			exit.Error(reason.InternalBootstrapper, "Error getting cluster bootstrapper", err)
@@ -163,4 +177,8 @@ func init() {
 	logsCmd.Flags().StringVar(&fileOutput, "file", "", "If present, writes to the provided file instead of stdout.")
 	logsCmd.Flags().BoolVar(&auditLogs, "audit", false, "Show only the audit logs")
 	logsCmd.Flags().BoolVar(&lastStartOnly, "last-start-only", false, "Show only the last start logs.")
+	logsCmd.Flags().BoolVar(&k8sAuditLogs, "k8s-audit", false, "Show only the audit logs of the Kubernetes apiserver, enabled with 'minikube start --apiserver-audit-policy'")
+	logsCmd.Flags().StringVar(&k8sAuditFilter.User, "k8s-audit-user", "", "Show only the Kubernetes audit events of a user")
+	logsCmd.Flags().StringSliceVar(&k8sAuditFilter.Verbs, "k8s-audit-verb", nil, "Show only the Kubernetes audit events of API verbs, such as get, list or delete")
+	logsCmd.Flags().StringSliceVar(&k8sAuditFilter.Resources, "k8s-audit-resource", nil, "Show only the Kubernetes audit events of resources, such as pods, pods/log or deployments.apps")
 }
@@ -0,0 +1,64 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+// renderTable renders a table to stdout, for the commands with a table output format
+func renderTable(header []string, data [][]string) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header(header)
+	table.Options(
+		tablewriter.WithHeaderAutoFormat(tw.Off),
+		tablewriter.WithRowAlignment(tw.AlignLeft),
+	)
+	if err := table.Bulk(data); err != nil {
+		klog.Warningf("error rendering table: %v", err)
+	}
+	if err := table.Render(); err != nil {
+		klog.Warningf("error rendering table: %v", err)
+	}
+}
+
+// printJSON prints a value as JSON to stdout, for the commands with a json output format
+func printJSON(v interface{}) {
+	jsondata, err := json.Marshal(v)
+	if err != nil {
+		exit.Error(reason.InternalJSONMarshal, "json encoding failure", err)
+	}
+	out.Ln("%s", jsondata)
+}
+
+// printYAML prints a value as YAML to stdout, for the commands with a yaml output format
+func printYAML(v interface{}) {
+	yamldata, err := yaml.Marshal(v)
+	if err != nil {
+		exit.Error(reason.InternalYamlMarshal, "yaml encoding failure", err)
+	}
+	out.Ln("%s", yamldata)
+}
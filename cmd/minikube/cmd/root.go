@@ -35,8 +35,10 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/notify"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
@@ -85,6 +87,12 @@ var RootCmd = &cobra.Command{
 		if viper.GetBool(config.Rootless) {
 			os.Setenv(constants.MinikubeRootlessEnv, "true")
 		}
+		if err := mirror.Set(viper.GetString(config.ArtifactMirror)); err != nil {
+			exit.Message(reason.Usage, "Invalid artifact mirror: {{.error}}", out.V{"error": err})
+		}
+		if err := download.SetSigningKey(viper.GetString(config.DownloadSigningKey)); err != nil {
+			exit.Message(reason.Usage, "Invalid download signing key: {{.error}}", out.V{"error": err})
+		}
 	},
 	PersistentPostRun: func(_ *cobra.Command, _ []string) {
 		if err := audit.LogCommandEnd(auditID); err != nil {
@@ -225,6 +233,8 @@ func init() {
 	RootCmd.PersistentFlags().String(config.UserFlag, "", "Specifies the user executing the operation. Useful for auditing operations executed by 3rd party tools. Defaults to the operating system username.")
 	RootCmd.PersistentFlags().Bool(config.SkipAuditFlag, false, "Skip recording the current command in the audit logs.")
 	RootCmd.PersistentFlags().Bool(config.Rootless, false, "Force to use rootless driver (docker and podman driver only)")
+	RootCmd.PersistentFlags().String(config.ArtifactMirror, "", "Base URL of a mirror to download the ISO, base image, preloads, Kubernetes binaries, drivers and release information from, verified against the checksums it hosts. See https://minikube.sigs.k8s.io/docs/handbook/offline/#artifact-mirror for its layout.")
+	RootCmd.PersistentFlags().String(config.DownloadSigningKey, "", "Minisign public key, or the path of a file containing it, to verify the detached <artifact>.minisig signature of the ISO, kicbase tarball, preload, binary and driver downloads against. Base images pulled from a registry must then be pinned by digest.")
 
 	translate.DetermineLocale()
 
@@ -248,6 +258,7 @@ func init() {
 				podmanEnvCmd,
 				cacheCmd,
 				imageCmd,
+				bundleCmd,
 			},
 		},
 		{
@@ -257,6 +268,9 @@ func init() {
 				configCmd.ConfigCmd,
 				configCmd.ProfileCmd,
 				updateContextCmd,
+				secretsCmd,
+				userCmd,
+				certsCmd,
 			},
 		},
 		{
@@ -294,6 +308,7 @@ func init() {
 	// Ungrouped commands will show up in the "Other Commands" section
 	RootCmd.AddCommand(completionCmd)
 	RootCmd.AddCommand(licenseCmd)
+	RootCmd.AddCommand(sbomCmd)
 	templates.ActsAsRootCommand(RootCmd, []string{"options"}, groups...)
 
 	if err := viper.BindPFlags(RootCmd.PersistentFlags()); err != nil {
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/cmd/minikube/cmd/flags"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/sbom"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	sbomFormat string
+	sbomOutput string
+)
+
+// sbomCmd represents the sbom command
+var sbomCmd = &cobra.Command{
+	Use:   "sbom",
+	Short: "Outputs a software bill of materials of the cluster",
+	Long: `Outputs a software bill of materials of the cluster, in SPDX or CycloneDX JSON.
+It lists the base image or ISO, the operating system and container runtime of the nodes, the Kubernetes binaries with their checksums, the CNI, and the container images with their digests.`,
+	Example: `
+$ minikube sbom
+$ minikube sbom --format cyclonedx -o sbom.cdx.json
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		var encode func(*sbom.BOM) ([]byte, error)
+		switch sbomFormat {
+		case "spdx":
+			encode = sbom.SPDX
+		case "cyclonedx":
+			encode = sbom.CycloneDX
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'spdx', 'cyclonedx'", out.V{"format": sbomFormat})
+		}
+
+		options := flags.CommandOptions()
+		_, cc := mustload.Partial(ClusterFlagValue(), options)
+		bom, err := sbom.Collect(cc, options)
+		if err != nil {
+			exit.Error(reason.GuestSBOM, "Failed to collect the bill of materials", err)
+		}
+		data, err := encode(bom)
+		if err != nil {
+			exit.Error(reason.GuestSBOM, "Failed to encode the bill of materials", err)
+		}
+
+		if sbomOutput == "" || sbomOutput == "-" {
+			out.Ln("%s", data)
+			return
+		}
+		if err := os.WriteFile(sbomOutput, append(data, '\n'), 0o644); err != nil {
+			exit.Error(reason.GuestSBOM, "Failed to write the bill of materials", err)
+		}
+		out.Styled(style.Check, "Wrote the bill of materials of {{.profile}} to {{.path}}", out.V{"profile": cc.Name, "path": sbomOutput})
+	},
+}
+
+func init() {
+	sbomCmd.Flags().StringVar(&sbomFormat, "format", "spdx", "Format output. One of: spdx|cyclonedx")
+	sbomCmd.Flags().StringVarP(&sbomOutput, "output", "o", "", "File to write the bill of materials to, instead of stdout")
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/cmd/minikube/cmd/flags"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/encryption"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// rotateKeyProvider is the provider of the new encryption key
+var rotateKeyProvider string
+
+// secretsCmd represents the secrets command
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage the encryption of secrets at rest",
+	Long:  "Manage the encryption at rest of the secrets of a cluster started with --secrets-encryption.",
+}
+
+// secretsRotateKeyCmd represents the secrets rotate-key command
+var secretsRotateKeyCmd = &cobra.Command{
+	Use:   "rotate-key",
+	Short: "Rotate the key encrypting the secrets and re-encrypt them",
+	Long: `Generate a new key encrypting the secrets of the cluster, re-encrypt every secret with it, and remove the old keys.
+The apiservers are restarted while the key is rotated, and a rotation interrupted after the new key was added is completed by the next start.`,
+	Example: `
+$ minikube secrets rotate-key
+$ minikube secrets rotate-key --provider=secretbox
+`,
+	Run: func(_ *cobra.Command, _ []string) {
+		co := mustload.Running(ClusterFlagValue(), flags.CommandOptions())
+		cc := co.Config
+		if cc.KubernetesConfig.SecretsEncryption == "" {
+			exit.Message(reason.Usage, "Secrets are not encrypted, start the cluster with --secrets-encryption")
+		}
+		provider := rotateKeyProvider
+		if provider == "" {
+			provider = cc.KubernetesConfig.SecretsEncryption
+		}
+		newKey, err := encryption.NewKey(provider)
+		if err != nil {
+			exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+		}
+		current, err := encryption.Load(cc.Name)
+		if err != nil {
+			exit.Error(reason.GuestSecretsEncryption, "Unable to load the encryption configuration", err)
+		}
+
+		rotateKey(co.API, cc, co.CP.Runner, encryption.RotationSteps(current, newKey), provider)
+		out.Styled(style.Success, "Secrets are encrypted with the new {{.provider}} key {{.key}}", out.V{"provider": provider, "key": newKey.Name})
+	},
+}
+
+// resumeKeyRotation completes the key rotation interrupted on a running cluster, if any
+func resumeKeyRotation(api libmachine.API, cc *config.ClusterConfig, primary command.Runner) {
+	if cc.KubernetesConfig.SecretsEncryption == "" {
+		return
+	}
+	current, err := encryption.Load(cc.Name)
+	if err != nil {
+		exit.Error(reason.GuestSecretsEncryption, "Unable to load the encryption configuration", err)
+	}
+	newKey, ok := current.Pending()
+	if !ok {
+		return
+	}
+	out.Step(style.Provisioning, "Resuming the rotation of the encryption key to {{.provider}} key {{.key}} ...", out.V{"provider": newKey.Provider, "key": newKey.Name})
+	rotateKey(api, cc, primary, encryption.ResumeSteps(current), newKey.Provider)
+}
+
+// rotateKey runs the steps of a key rotation on the control-plane nodes of a cluster, and saves the provider of the new key
+func rotateKey(api libmachine.API, cc *config.ClusterConfig, primary command.Runner, steps []encryption.RotationStep, provider string) {
+	type controlPlane struct {
+		name   string
+		port   int
+		runner cruntime.CommandRunner
+		cr     cruntime.Manager
+	}
+	cps := []controlPlane{}
+	for _, n := range config.ControlPlanes(*cc) {
+		host, err := machine.LoadHost(api, config.MachineName(*cc, n))
+		if err != nil {
+			exit.Error(reason.GuestLoadHost, "Error getting host", err)
+		}
+		r, err := machine.CommandRunner(host)
+		if err != nil {
+			exit.Error(reason.InternalCommandRunner, "Failed to get command runner", err)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: r})
+		if err != nil {
+			exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
+		}
+		cps = append(cps, controlPlane{name: config.MachineName(*cc, n), port: n.Port, runner: r, cr: cr})
+	}
+
+	for _, step := range steps {
+		out.Step(style.Provisioning, "{{.step}} ...", out.V{"step": step.Description})
+		if step.Config == nil {
+			if err := encryption.Reencrypt(primary, cc.KubernetesConfig.KubernetesVersion); err != nil {
+				exit.Error(reason.GuestSecretsEncryption, "Unable to re-encrypt the secrets", err)
+			}
+			continue
+		}
+		// the profile is the source of truth, so that an interrupted rotation is completed by the next start
+		if err := encryption.Save(cc.Name, step.Config); err != nil {
+			exit.Error(reason.GuestSecretsEncryption, "Unable to save the encryption configuration", err)
+		}
+		for _, cp := range cps {
+			if err := encryption.Apply(cp.runner, cp.cr, step.Config, cc.KubernetesConfig.KubernetesVersion, cp.port); err != nil {
+				exit.Error(reason.GuestSecretsEncryption, "Unable to apply the encryption configuration to "+cp.name, err)
+			}
+		}
+	}
+
+	if provider != cc.KubernetesConfig.SecretsEncryption {
+		cc.KubernetesConfig.SecretsEncryption = provider
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+		}
+	}
+}
+
+func init() {
+	secretsRotateKeyCmd.Flags().StringVar(&rotateKeyProvider, "provider", "", "The provider of the new key, one of "+strings.Join(encryption.Providers, ", ")+". Defaults to the current provider.")
+	secretsCmd.AddCommand(secretsRotateKeyCmd)
+}
@@ -56,6 +56,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/bundle"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
@@ -64,11 +65,14 @@ import (
 	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/driver/auxdriver"
+	"k8s.io/minikube/pkg/minikube/encryption"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/firewall"
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/manifests"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/notify"
@@ -168,6 +172,13 @@ func runStart(cmd *cobra.Command, _ []string) {
 
 	displayVersion(version.GetVersion())
 	go download.CleanUpOlderPreloads()
+	if options.Offline {
+		download.SetOffline()
+	}
+	// the default of --iso-url is determined before the artifact mirror is set
+	if mirror.Enabled() && !viper.IsSet(isoURL) {
+		viper.Set(isoURL, download.DefaultISOURLs())
+	}
 
 	// Avoid blocking execution on optional HTTP fetches
 	go notify.MaybePrintUpdateTextFromGithub(options)
@@ -226,6 +237,9 @@ func runStart(cmd *cobra.Command, _ []string) {
 				},
 			)
 		}
+		if err := download.VerifyImagePinned(viper.GetString(kicBaseImage)); err != nil {
+			exit.Message(reason.Usage, "--{{.imgFlag}} must be pinned by digest (<image>:<tag>@sha256:<digest>) when a download signing key is set: {{.error}}", out.V{"imgFlag": kicBaseImage, "error": err})
+		}
 	}
 
 	useForce := viper.GetBool(force)
@@ -299,6 +313,17 @@ func runStart(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	// apply the manifests of the profile once the cluster is healthy (intentionally non-fatal)
+	if starter.Cfg.KubernetesConfig.KubernetesVersion != constants.NoKubernetesVersion {
+		// a key rotation interrupted by a previous command is completed once every apiserver is running
+		resumeKeyRotation(starter.MachineAPI, starter.Cfg, starter.Runner)
+		if err := manifests.Apply(*starter.Cfg, starter.Runner); err != nil {
+			out.WarningT("Unable to apply the manifests of the profile: {{.error}}", out.V{"error": err})
+		}
+		// issue the TLS secrets of the Ingress objects, including those of the manifests (intentionally non-fatal)
+		syncIngressSecrets(starter.Cfg.Name)
+	}
+
 	if err := showKubectlInfo(configInfo, starter.Node.KubernetesVersion, starter.Node.ContainerRuntime, starter.Cfg.Name); err != nil {
 		klog.Errorf("kubectl info: %v", err)
 	}
@@ -352,6 +377,45 @@ func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *
 	}
 	klog.Infof("cluster config:\n%+v", cc)
 
+	if options.Offline {
+		addons := bundle.EnabledAddons(&cc, viper.GetStringSlice(config.AddonListFlag))
+		if err := bundle.CheckOffline(&cc, addons, viper.GetStringSlice(isoURL)); err != nil {
+			exit.Message(reason.InetOfflineMissing, "Unable to start offline: {{.error}}", out.V{"error": err})
+		}
+	}
+
+	if cc.KubernetesConfig.KubeadmPatches != "" {
+		if err := bsutil.ValidateKubeadmPatches(cc.KubernetesConfig.KubeadmPatches, cc.KubernetesConfig.KubernetesVersion); err != nil {
+			exit.Message(reason.Usage, "Invalid kubeadm patches: {{.error}}", out.V{"error": err})
+		}
+	}
+
+	if cc.KubernetesConfig.AuditPolicy != "" {
+		if _, err := bsutil.LoadAuditPolicy(cc.KubernetesConfig.AuditPolicy); err != nil {
+			exit.Message(reason.Usage, "Invalid apiserver audit policy: {{.error}}", out.V{"error": err})
+		}
+	}
+
+	if cc.KubernetesConfig.SecretsEncryption != "" {
+		if _, err := encryption.Ensure(cc.Name, cc.KubernetesConfig.SecretsEncryption); err != nil {
+			exit.Message(reason.Usage, "Unable to encrypt secrets: {{.error}}", out.V{"error": err})
+		}
+	}
+
+	if len(cc.KubernetesConfig.KubeletConfiguration) > 0 {
+		validateKubeletConfiguration(cc.KubernetesConfig)
+	}
+
+	validateComponentFlags(cc.KubernetesConfig)
+
+	if cc.KubernetesConfig.CACert != "" || cc.KubernetesConfig.CAKey != "" {
+		validateCustomCA(cc)
+	}
+
+	if err := manifests.ValidateStaticPods(cc.Name); err != nil {
+		exit.Message(reason.Usage, "Invalid static pods: {{.error}}", out.V{"error": err})
+	}
+
 	if firewall.IsBootpdBlocked(cc) {
 		if err := firewall.UnblockBootpd(options); err != nil {
 			klog.Warningf("failed unblocking bootpd from firewall: %v", err)
@@ -1394,6 +1458,61 @@ func validateFlags(cmd *cobra.Command, drvName string) { //nolint:gocyclo
 	validateInsecureRegistry()
 }
 
+// validateComponentFlags rejects --extra-config flags and feature gates unknown to the Kubernetes version,
+// or only warns about them if the version is newer than the catalog of flags
+func validateComponentFlags(k8s config.KubernetesConfig) {
+	problems, approximate, err := bsutil.ValidateComponentFlags(k8s)
+	if err != nil {
+		klog.Warningf("unable to validate the component flags: %v", err)
+		return
+	}
+	if len(problems) == 0 {
+		return
+	}
+	for _, p := range problems {
+		out.WarningT("{{.problem}}", out.V{"problem": p})
+	}
+	if approximate {
+		out.WarningT("Kubernetes {{.version}} is newer than the known flags and feature gates, the components may fail to start", out.V{"version": k8s.KubernetesVersion})
+		return
+	}
+	exitIfNotForced(reason.Usage, "Invalid --extra-config or --feature-gates for Kubernetes {{.version}}, use --force to start anyway", out.V{"version": k8s.KubernetesVersion})
+}
+
+// validateKubeletConfiguration checks the fields of --kubelet-config against the catalog of the Kubernetes version,
+// before the kubelet fails to start on an unknown field
+func validateKubeletConfiguration(k8s config.KubernetesConfig) {
+	problems, approximate, err := bsutil.ValidateKubeletConfiguration(k8s.KubeletConfiguration, k8s.KubernetesVersion)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid kubelet configuration: {{.error}}", out.V{"error": err})
+	}
+	if len(problems) == 0 {
+		return
+	}
+	for _, p := range problems {
+		out.WarningT("{{.problem}}", out.V{"problem": p})
+	}
+	if approximate {
+		out.WarningT("Kubernetes {{.version}} is newer than the known KubeletConfiguration fields, the kubelet may fail to start", out.V{"version": k8s.KubernetesVersion})
+		return
+	}
+	exitIfNotForced(reason.Usage, "Invalid kubelet configuration for Kubernetes {{.version}}, use --force to start anyway", out.V{"version": k8s.KubernetesVersion})
+}
+
+// validateCustomCA rejects a CA supplied with --ca-cert and --ca-key that cannot sign the certificates of the cluster
+func validateCustomCA(cc config.ClusterConfig) {
+	if cc.KubernetesConfig.CACert == "" || cc.KubernetesConfig.CAKey == "" {
+		exit.Message(reason.Usage, "--ca-cert and --ca-key must be used together")
+	}
+	ca, err := util.ValidateCACert(cc.KubernetesConfig.CACert, cc.KubernetesConfig.CAKey)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid CA: {{.error}}", out.V{"error": err})
+	}
+	if ca.NotAfter.Before(time.Now().Add(cc.CertExpiration)) {
+		out.WarningT("The CA expires on {{.date}}, before the certificates of the cluster, which stop being trusted then", out.V{"date": ca.NotAfter.Format(time.RFC3339)})
+	}
+}
+
 // validatePorts validates that the --ports are not outside range
 func validatePorts(ports []string) error {
 	var exposedPorts, hostPorts, portSpecs []string
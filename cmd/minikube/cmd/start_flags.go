@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -32,6 +33,7 @@ import (
 	"k8s.io/minikube/pkg/drivers/common/vmnet"
 	"k8s.io/minikube/pkg/drivers/kic"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/cni"
@@ -41,6 +43,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/encryption"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/proxy"
@@ -80,6 +83,12 @@ const (
 	keepContext             = "keep-context"
 	createMount             = "mount"
 	featureGates            = "feature-gates"
+	kubeadmPatches          = "kubeadm-patches"
+	kubeletConfigFile       = "kubelet-config-file"
+	apiServerAuditPolicy    = "apiserver-audit-policy"
+	secretsEncryption       = "secrets-encryption"
+	caCertFile              = "ca-cert"
+	caKeyFile               = "ca-key"
 	apiServerName           = "apiserver-name"
 	apiServerPort           = "apiserver-port"
 	dnsDomain               = "dns-domain"
@@ -146,6 +155,7 @@ const (
 	staticIP                = "static-ip"
 	gpus                    = "gpus"
 	autoPauseInterval       = "auto-pause-interval"
+	registryCache           = "registry-cache"
 )
 
 var (
@@ -167,6 +177,7 @@ func initMinikubeFlags() {
 	startCmd.Flags().StringP(memory, "m", "", fmt.Sprintf("Amount of RAM to allocate to Kubernetes (format: <number>[<unit>], where unit = b, k, m or g). Use %q to use the maximum amount of memory. Use %q to not specify a limit (Docker/Podman only)", constants.MaxResources, constants.NoLimit))
 	startCmd.Flags().String(humanReadableDiskSize, defaultDiskSize, "Disk size allocated to the minikube VM (format: <number>[<unit>], where unit = b, k, m or g).")
 	startCmd.Flags().Bool(flags.DownloadOnly, false, "If true, only download and cache files for later use - don't install or start anything.")
+	startCmd.Flags().Bool(flags.Offline, false, "If true, start without any network access, using only cached artifacts such as those installed by 'minikube bundle install'.")
 	startCmd.Flags().Bool(cacheImages, true, "If true, cache docker images for the current bootstrapper and load them into the machine. Always false with --driver=none.")
 	startCmd.Flags().StringSlice(isoURL, download.DefaultISOURLs(), "Locations to fetch the minikube ISO from.")
 	startCmd.Flags().String(kicBaseImage, kic.BaseImage, "The base image to use for docker/podman drivers. Intended for local development.")
@@ -211,6 +222,7 @@ func initMinikubeFlags() {
 	startCmd.Flags().String(staticIP, "", "Set a static IP for the minikube cluster, the IP must be: private, IPv4, and the last octet must be between 2 and 254, for example 192.168.200.200 (Docker and Podman drivers only)")
 	startCmd.Flags().StringP(gpus, "g", "", "Allow pods to use your GPUs. Options include: [all,nvidia,amd] (Docker driver with Docker container-runtime only)")
 	startCmd.Flags().Duration(autoPauseInterval, time.Minute*1, "Duration of inactivity before the minikube VM is paused (default 1m0s)")
+	startCmd.Flags().Bool(registryCache, false, "If set, pull Docker Hub images through a registry cache on the host, which is shared by all profiles. Its size is capped by the registry-cache-max-size config.")
 }
 
 // initKubernetesFlags inits the commandline flags for Kubernetes related options
@@ -223,6 +235,12 @@ func initKubernetesFlags() {
 		Valid components are: kubelet, kubeadm, apiserver, controller-manager, etcd, proxy, scheduler
 		Valid kubeadm parameters: `+fmt.Sprintf("%s, %s", strings.Join(bsutil.KubeadmExtraArgsAllowed[bsutil.KubeadmCmdParam], ", "), strings.Join(bsutil.KubeadmExtraArgsAllowed[bsutil.KubeadmConfigParam], ",")))
 	startCmd.Flags().String(featureGates, "", "A set of key=value pairs that describe feature gates for alpha/experimental features.")
+	startCmd.Flags().String(kubeadmPatches, "", "Directory of kubeadm patches applied to the static pods of the control plane and to the kubelet configuration on every node, named <component>[suffix][+strategic|merge|json].{yaml|json} (Kubernetes v1.23+)")
+	startCmd.Flags().String(apiServerAuditPolicy, "", fmt.Sprintf("Enable the audit log of the apiserver with an audit policy file, or one of the built-in policies logging every request at a level: %s. Show the log with 'minikube logs --k8s-audit'", strings.Join(bsutil.AuditPolicyPresets(), ", ")))
+	startCmd.Flags().String(caCertFile, "", "CA certificate, optionally followed by its chain, signing the certificates of the cluster and of the ingress addon instead of the generated minikubeCA, which the other profiles keep. Requires --ca-key")
+	startCmd.Flags().String(caKeyFile, "", "RSA private key of the --ca-cert CA")
+	startCmd.Flags().String(secretsEncryption, "", fmt.Sprintf("Encrypt secrets at rest with a provider: %s. The key is generated in the profile directory, and rotated with 'minikube secrets rotate-key'", strings.Join(encryption.Providers, ", ")))
+	startCmd.Flags().String(kubeletConfigFile, "", "YAML file of KubeletConfiguration fields merged into the kubelet configuration of every node, for settings that are not available as kubelet flags")
 	startCmd.Flags().String(dnsDomain, constants.ClusterDNSDomain, "The cluster dns domain name used in the Kubernetes cluster")
 	startCmd.Flags().Int(apiServerPort, constants.APIServerPort, "The apiserver listening port")
 	startCmd.Flags().String(apiServerName, constants.APIServerName, "The authoritative apiserver hostname for apiserver certificates and connectivity. This can be used if you want to make the apiserver available from outside the machine")
@@ -631,6 +649,7 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 		SocketVMnetClientPath:   detect.SocketVMNetClientPath(),
 		SocketVMnetPath:         detect.SocketVMNetPath(),
 		StaticIP:                viper.GetString(staticIP),
+		RegistryCache:           viper.GetBool(registryCache),
 		KubernetesConfig: config.KubernetesConfig{
 			KubernetesVersion:      k8sVersion,
 			ClusterName:            ClusterFlagValue(),
@@ -640,6 +659,12 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 			APIServerIPs:           apiServerIPs,
 			DNSDomain:              viper.GetString(dnsDomain),
 			FeatureGates:           viper.GetString(featureGates),
+			KubeadmPatches:         kubeadmPatchesDir(),
+			KubeletConfiguration:   kubeletConfiguration(),
+			AuditPolicy:            auditPolicy(),
+			SecretsEncryption:      viper.GetString(secretsEncryption),
+			CACert:                 absFlagPath(caCertFile),
+			CAKey:                  absFlagPath(caKeyFile),
 			ContainerRuntime:       rtime,
 			CRISocket:              viper.GetString(criSocket),
 			NetworkPlugin:          chosenNetworkPlugin,
@@ -655,6 +680,11 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 	}
 	cc.VerifyComponents = interpretWaitFlag(*cmd)
 
+	// the ingress addon serves a default certificate issued by the supplied CA
+	if cc.KubernetesConfig.CACert != "" {
+		cc.KubernetesConfig.CustomIngressCert = bootstrapper.IngressCertSecret
+	}
+
 	if viper.GetString(mountString) != "" && driver.IsKIC(drvName) {
 		cc.ContainerVolumeMounts = []string{viper.GetString(mountString)}
 	}
@@ -700,6 +730,62 @@ func generateNewConfigFromFlags(cmd *cobra.Command, k8sVersion string, rtime str
 	return cc
 }
 
+// kubeadmPatchesDir returns the absolute path of the kubeadm patches directory, as the cluster may be restarted from another directory
+func kubeadmPatchesDir() string {
+	dir := viper.GetString(kubeadmPatches)
+	if dir == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		klog.Warningf("failed to get the absolute path of %s: %v", dir, err)
+		return dir
+	}
+	return abs
+}
+
+// absFlagPath returns the absolute path of a file flag, as the cluster may be restarted from another directory
+func absFlagPath(flag string) string {
+	p := viper.GetString(flag)
+	if p == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		klog.Warningf("failed to get the absolute path of %s: %v", p, err)
+		return p
+	}
+	return abs
+}
+
+// kubeletConfiguration returns the KubeletConfiguration fields of the kubelet config file, which are stored in the cluster config
+// so that they are applied again when the cluster is restarted
+func kubeletConfiguration() map[string]interface{} {
+	file := viper.GetString(kubeletConfigFile)
+	if file == "" {
+		return nil
+	}
+	kc, err := bsutil.LoadKubeletConfiguration(file)
+	if err != nil {
+		exit.Message(reason.Usage, "Invalid kubelet configuration: {{.error}}", out.V{"error": err})
+	}
+	return kc
+}
+
+// auditPolicy returns the apiserver audit policy, with the absolute path of a policy file as the cluster may be restarted from another directory
+func auditPolicy() string {
+	policy := viper.GetString(apiServerAuditPolicy)
+	if policy == "" || bsutil.IsAuditPolicyPreset(policy) {
+		return strings.ToLower(policy)
+	}
+	abs, err := filepath.Abs(policy)
+	if err != nil {
+		klog.Warningf("failed to get the absolute path of %s: %v", policy, err)
+		return policy
+	}
+	return abs
+}
+
 func addFeatureGate(featureGates, s string) string {
 	if len(featureGates) == 0 {
 		return s
@@ -861,6 +947,28 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 	updateStringSliceFromFlag(cmd, &cc.KubernetesConfig.APIServerNames, "apiserver-names")
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.DNSDomain, dnsDomain)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.FeatureGates, featureGates)
+	if cmd.Flags().Changed(kubeadmPatches) {
+		cc.KubernetesConfig.KubeadmPatches = kubeadmPatchesDir()
+	}
+	if cmd.Flags().Changed(kubeletConfigFile) {
+		cc.KubernetesConfig.KubeletConfiguration = kubeletConfiguration()
+	}
+	if cmd.Flags().Changed(apiServerAuditPolicy) {
+		cc.KubernetesConfig.AuditPolicy = auditPolicy()
+	}
+	if cmd.Flags().Changed(secretsEncryption) {
+		// the apiserver could not read the secrets encrypted so far without the encryption configuration
+		if cc.KubernetesConfig.SecretsEncryption != "" && viper.GetString(secretsEncryption) == "" {
+			exit.Message(reason.Usage, "Secrets encryption cannot be disabled once enabled, delete the cluster to disable it")
+		}
+		updateStringFromFlag(cmd, &cc.KubernetesConfig.SecretsEncryption, secretsEncryption)
+	}
+	if cmd.Flags().Changed(caCertFile) || cmd.Flags().Changed(caKeyFile) {
+		// the certificates of the running control plane are signed by the CA the cluster was created with
+		if absFlagPath(caCertFile) != cc.KubernetesConfig.CACert || absFlagPath(caKeyFile) != cc.KubernetesConfig.CAKey {
+			exit.Message(reason.Usage, "The CA of an existing cluster cannot be changed, delete the cluster to change it")
+		}
+	}
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.ContainerRuntime, containerRuntime)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.CRISocket, criSocket)
 	updateStringFromFlag(cmd, &cc.KubernetesConfig.NetworkPlugin, networkPlugin)
@@ -882,6 +990,7 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 	updateStringFromFlag(cmd, &cc.SocketVMnetClientPath, socketVMnetClientPath)
 	updateStringFromFlag(cmd, &cc.SocketVMnetPath, socketVMnetPath)
 	updateDurationFromFlag(cmd, &cc.AutoPauseInterval, autoPauseInterval)
+	updateBoolFromFlag(cmd, &cc.RegistryCache, registryCache)
 
 	if cmd.Flags().Changed(kubernetesVersion) {
 		kubeVer, err := getKubernetesVersion(existing)
@@ -30,7 +30,7 @@ var updateCheckCmd = &cobra.Command{
 	Short: "Print current and latest version number",
 	Long:  `Print current and latest version number`,
 	Run: func(_ *cobra.Command, _ []string) {
-		url := notify.GithubMinikubeReleasesURL
+		url := notify.ReleasesURL()
 		r, err := notify.AllVersionsFromURL(url)
 		if err != nil {
 			exit.Error(reason.InetVersionUnavailable, "Unable to fetch latest version info", err)
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/cmd/minikube/cmd/flags"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/users"
+)
+
+var (
+	userGroups      []string
+	userTTL         time.Duration
+	userClusterRole string
+	userNamespace   string
+	userOutput      string
+)
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage the client users of a cluster",
+	Long:  "Manage client users authenticating with certificates signed by the cluster CA, to test RBAC with other identities than the minikube-user admin.",
+}
+
+// userAddCmd represents the user add command
+var userAddCmd = &cobra.Command{
+	Use:   "add NAME",
+	Short: "Issue a client certificate for a user and add its kubeconfig context",
+	Long: `Issue a client certificate for a user in groups, signed by the cluster CA, and add a "NAME@PROFILE" kubeconfig context for it.
+The user has no permissions but the ones of its groups, unless it is bound to a cluster role with --clusterrole.
+Adding an existing user issues a new certificate.`,
+	Example: `
+$ minikube user add alice --clusterrole=view
+$ minikube user add bob --group=dev --clusterrole=edit --namespace=dev --ttl=24h
+$ kubectl --context=alice@minikube get pods
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		name := args[0]
+		if err := users.ValidateName(name); err != nil {
+			exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+		}
+		if userNamespace != "" && userClusterRole == "" {
+			exit.Message(reason.Usage, "--namespace requires --clusterrole")
+		}
+		for _, g := range userGroups {
+			if g == "system:masters" {
+				out.WarningT("Members of system:masters are cluster admins, and cannot be revoked before their certificate expires or the CA is rotated")
+			}
+		}
+
+		co := mustload.Running(ClusterFlagValue(), flags.CommandOptions())
+		cc := co.Config
+		ttl := userTTL
+		if ttl == 0 {
+			ttl = cc.CertExpiration
+		}
+		host, port, err := kubeconfig.Endpoint(cc.Name, kubeconfig.PathFromEnv())
+		if err != nil {
+			exit.Message(reason.HostKubeconfigUpdate, `Unable to find the "{{.context}}" cluster in the kubeconfig, run "minikube update-context": {{.error}}`, out.V{"context": cc.Name, "error": err})
+		}
+
+		u, err := users.Add(cc.Name, name, userGroups, ttl)
+		if err != nil {
+			exit.Error(reason.HostCerts, "Unable to issue the certificate of the user", err)
+		}
+		if userClusterRole != "" {
+			if err := users.Bind(*cc, co.CP.Runner, name, userClusterRole, userNamespace); err != nil {
+				exit.Error(reason.GuestRBAC, "Unable to bind the user to the cluster role", err)
+			}
+		}
+
+		kcs := &kubeconfig.Settings{
+			ClusterName:          cc.Name,
+			User:                 u.Context,
+			Namespace:            cc.KubernetesConfig.Namespace,
+			ClusterServerAddress: "https://" + net.JoinHostPort(host, strconv.Itoa(port)),
+			ClientCertificate:    users.CertPath(cc.Name, name),
+			ClientKey:            users.KeyPath(cc.Name, name),
+			CertificateAuthority: localpath.ProfileCACert(cc.Name),
+			KeepContext:          true,
+			EmbedCerts:           cc.EmbedCerts,
+		}
+		kcs.SetPath(kubeconfig.PathFromEnv())
+		if err := kubeconfig.Update(kcs); err != nil {
+			exit.Error(reason.HostKubeconfigUpdate, "Failed to update kubeconfig", err)
+		}
+		out.Styled(style.Success, `Added user "{{.name}}", use it with "kubectl --context={{.context}}"`, out.V{"name": name, "context": u.Context})
+	},
+}
+
+// userListCmd represents the user list command
+var userListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the client users of a cluster",
+	Run: func(_ *cobra.Command, _ []string) {
+		_, cc := mustload.Partial(ClusterFlagValue(), flags.CommandOptions())
+		us, err := users.List(cc.Name)
+		if err != nil {
+			exit.Error(reason.HostCerts, "Unable to list the users", err)
+		}
+		switch strings.ToLower(userOutput) {
+		case "table":
+			if len(us) == 0 {
+				out.Styled(style.Empty, `No users, add one with "minikube user add"`)
+				return
+			}
+			data := [][]string{}
+			for _, u := range us {
+				expires := u.Expires.Local().Format(time.RFC3339)
+				if time.Now().After(u.Expires) {
+					expires += " (expired)"
+				}
+				data = append(data, []string{u.Name, strings.Join(u.Groups, ","), expires, u.Context})
+			}
+			renderTable([]string{"Name", "Groups", "Expires", "Context"}, data)
+		case "json":
+			printJSON(us)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.format}}. Valid values: 'table', 'json'", out.V{"format": userOutput})
+		}
+	},
+}
+
+// userRevokeCmd represents the user revoke command
+var userRevokeCmd = &cobra.Command{
+	Use:   "revoke NAME",
+	Short: "Revoke a client user",
+	Long: fmt.Sprintf(`Delete the RBAC bindings labeled %s=NAME, then the certificate and kubeconfig context of the user.
+Kubernetes does not check the revocation of client certificates: the permissions of the groups of the user last until its certificate expires or the CA is rotated.`, users.UserLabel),
+	Args: cobra.ExactArgs(1),
+	Run: func(_ *cobra.Command, args []string) {
+		name := args[0]
+		co := mustload.Running(ClusterFlagValue(), flags.CommandOptions())
+		cc := co.Config
+		u, err := users.Get(cc.Name, name)
+		if err == users.ErrNotFound {
+			exit.Message(reason.Usage, `No user "{{.name}}", see "minikube user list"`, out.V{"name": name})
+		}
+		if err != nil {
+			exit.Error(reason.HostCerts, "Unable to read the certificate of the user", err)
+		}
+
+		if err := users.Unbind(*cc, co.CP.Runner, name); err != nil {
+			exit.Error(reason.GuestRBAC, "Unable to delete the RBAC bindings of the user", err)
+		}
+		if err := kubeconfig.DeleteUserContext(u.Context, kubeconfig.PathFromEnv()); err != nil {
+			exit.Error(reason.HostKubeconfigDeleteCtx, "Failed to delete the kubeconfig context", err)
+		}
+		if err := users.Remove(cc.Name, name); err != nil {
+			exit.Error(reason.HostCerts, "Unable to remove the certificate of the user", err)
+		}
+		out.Styled(style.Deleted, `Revoked user "{{.name}}"`, out.V{"name": name})
+		if len(u.Groups) > 0 {
+			out.WarningT("The certificate of {{.name}} stays valid for the groups {{.groups}} until {{.expires}}", out.V{"name": name, "groups": strings.Join(u.Groups, ","), "expires": u.Expires.Local().Format(time.RFC3339)})
+		}
+	},
+}
+
+func init() {
+	userAddCmd.Flags().StringSliceVar(&userGroups, "group", nil, "A group of the user, may be repeated")
+	userAddCmd.Flags().DurationVar(&userTTL, "ttl", 0, "Duration until the certificate of the user expires. Defaults to the --cert-expiration of the cluster.")
+	userAddCmd.Flags().StringVar(&userClusterRole, "clusterrole", "", "A cluster role to bind the user to, such as view, edit or admin")
+	userAddCmd.Flags().StringVar(&userNamespace, "namespace", "", "Bind the cluster role in this namespace only, rather than cluster wide")
+	userListCmd.Flags().StringVarP(&userOutput, "output", "o", "table", "The output format, one of 'table', 'json'")
+	userCmd.AddCommand(userAddCmd)
+	userCmd.AddCommand(userListCmd)
+	userCmd.AddCommand(userRevokeCmd)
+}
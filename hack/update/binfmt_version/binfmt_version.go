@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/hack/update"
+)
+
+var (
+	schema = map[string]update.Item{
+		"pkg/minikube/cruntime/platform.go": {
+			Replace: map[string]string{
+				`tonistiigi/binfmt:.*"`: `tonistiigi/binfmt:{{.Version}}@{{.SHA}}"`,
+			},
+		},
+	}
+
+	// only the releases of QEMU, not the buildkit helpers published in the same repository
+	qemuTag = regexp.MustCompile(`^qemu-v\d+\.\d+\.\d+$`)
+)
+
+type Data struct {
+	Version string
+	SHA     string
+}
+
+func main() {
+	tags, err := update.ImageTagsFromDockerHub("tonistiigi/binfmt")
+	if err != nil {
+		klog.Fatal(err)
+	}
+	version := ""
+	for _, tag := range tags {
+		if qemuTag.MatchString(tag) {
+			version = tag
+			break
+		}
+	}
+	if version == "" {
+		klog.Fatalf("no QEMU release in the tags of tonistiigi/binfmt: %v", tags)
+	}
+	sha, err := update.GetImageSHA(fmt.Sprintf("docker.io/tonistiigi/binfmt:%s", version))
+	if err != nil {
+		klog.Fatalf("failed to get image SHA: %v", err)
+	}
+
+	data := Data{Version: version, SHA: sha}
+
+	if err := update.Apply(schema, data); err != nil {
+		klog.Fatalf("unable to apply update: %v", err)
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"log"
 	"os"
 	"os/exec"
@@ -37,6 +38,7 @@ type dependency struct {
 
 var dependencies = map[string]dependency{
 	"amd-device-gpu-plugin":   {addonsFile, `rocm/k8s-device-plugin:(.*)@`},
+	"binfmt":                  {"pkg/minikube/cruntime/platform.go", `tonistiigi/binfmt:([^@"]*)`},
 	"buildkit":                {"deploy/iso/minikube-iso/arch/x86_64/package/buildkit-bin/buildkit-bin.mk", `BUILDKIT_BIN_VERSION = (.*)`},
 	"calico":                  {"pkg/minikube/bootstrapper/images/images.go", `calicoVersion = "(.*)"`},
 	"cilium":                  {"pkg/minikube/cni/cilium.yaml", `quay.io/cilium/cilium:(.*)@`},
@@ -110,6 +112,13 @@ func main() {
 		}
 		os.Stdout.WriteString(version)
 		return
+	case "kubernetes-catalog":
+		version, err := getKubernetesCatalogVersion()
+		if err != nil {
+			log.Fatalf("failed to get kubernetes catalog version: %v", err)
+		}
+		os.Stdout.WriteString(version)
+		return
 	case "kubernetes-versions-list":
 		version, err := getKubernetesVersionsList()
 		if err != nil {
@@ -215,6 +224,25 @@ func getKubernetesVersion() (string, error) {
 	return string(matches[1]), nil
 }
 
+// getKubernetesCatalogVersion returns the range of minor versions of the kubernetes catalog
+func getKubernetesCatalogVersion() (string, error) {
+	data, err := os.ReadFile("../pkg/minikube/bootstrapper/bsutil/kcatalog/catalog.json")
+	if err != nil {
+		return "", err
+	}
+
+	var catalog struct {
+		Versions []string `json:"versions"`
+	}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return "", err
+	}
+	if len(catalog.Versions) == 0 {
+		return "no-versions", nil
+	}
+	return catalog.Versions[0] + ".." + catalog.Versions[len(catalog.Versions)-1], nil
+}
+
 // getKubernetesVersionsList returns a count of supported kubernetes versions
 func getKubernetesVersionsList() (string, error) {
 	data, err := os.ReadFile("../pkg/minikube/constants/constants_kubernetes_versions.go")
@@ -0,0 +1,273 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubernetes_catalog generates the catalog of the flags and feature gates of the Kubernetes components,
+// from the help of the released binaries and the feature gates declared in the Kubernetes sources,
+// and of the KubeletConfiguration fields, from the v1beta1 types in the Kubernetes sources.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+
+	"golang.org/x/mod/semver"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+const (
+	binaryURL  = "https://dl.k8s.io/release/%s/bin/linux/%s/%s"
+	sourceURL  = "https://raw.githubusercontent.com/kubernetes/kubernetes/%s/%s"
+	catalogOut = "../pkg/minikube/bootstrapper/bsutil/kcatalog/catalog.json"
+)
+
+// binaries are the component binaries, by the component names of --extra-config
+var binaries = map[string]string{
+	"apiserver":          "kube-apiserver",
+	"controller-manager": "kube-controller-manager",
+	"scheduler":          "kube-scheduler",
+	"kubelet":            "kubelet",
+}
+
+// featureFiles are the sources declaring the feature gates shared by the components, missing in some versions
+var featureFiles = []string{
+	"pkg/features/kube_features.go",
+	"staging/src/k8s.io/apiserver/pkg/features/kube_features.go",
+	"staging/src/k8s.io/apiextensions-apiserver/pkg/features/kube_features.go",
+	"staging/src/k8s.io/client-go/features/known_features.go",
+	"staging/src/k8s.io/component-base/features/kube_features.go",
+	"staging/src/k8s.io/component-base/logs/api/v1/kube_features.go",
+	"staging/src/k8s.io/component-base/metrics/features/kube_features.go",
+	"staging/src/k8s.io/controller-manager/pkg/features/kube_features.go",
+}
+
+// kubeletConfigFile is the source declaring the v1beta1 KubeletConfiguration
+const kubeletConfigFile = "staging/src/k8s.io/kubelet/config/v1beta1/types.go"
+
+var (
+	flagRe    = regexp.MustCompile(`(?m)^\s+(?:-\w, )?--([a-z0-9][a-z0-9-]*)`)
+	featureRe = regexp.MustCompile(`(?m)^\s*\w+\s+(?:featuregate\.)?Feature\s*=\s*"(\w+)"`)
+	// kubeletConfigRe matches the KubeletConfiguration type, up to the closing brace of its declaration
+	kubeletConfigRe = regexp.MustCompile(`(?ms)^type KubeletConfiguration struct \{$(.*?)^\}`)
+	jsonTagRe       = regexp.MustCompile(`json:"(\w+)`)
+)
+
+// Lifecycle is the range of minor versions in which a flag or feature gate exists
+type Lifecycle struct {
+	Added   string `json:"added,omitempty"`
+	Removed string `json:"removed,omitempty"`
+}
+
+// Catalog is the content of catalog.json
+type Catalog struct {
+	Versions            []string                        `json:"versions"`
+	Flags               map[string]map[string]Lifecycle `json:"flags"`
+	FeatureGates        map[string]Lifecycle            `json:"featureGates"`
+	KubeletConfigFields map[string]Lifecycle            `json:"kubeletConfigFields"`
+}
+
+func main() {
+	releases := latestPatches()
+	minors := []string{}
+	for _, r := range releases {
+		minors = append(minors, semver.MajorMinor(r))
+	}
+
+	flags := map[string][]map[string]bool{}
+	gates := []map[string]bool{}
+	kubeletFields := []map[string]bool{}
+	for _, release := range releases {
+		klog.Infof("cataloging Kubernetes %s", release)
+		for component, binary := range binaries {
+			names, err := binaryFlags(release, binary)
+			if err != nil {
+				klog.Fatalf("failed to get the flags of %s %s: %v", binary, release, err)
+			}
+			flags[component] = append(flags[component], names)
+		}
+		names, err := featureGates(release)
+		if err != nil {
+			klog.Fatalf("failed to get the feature gates of %s: %v", release, err)
+		}
+		gates = append(gates, names)
+		fields, err := kubeletConfigFields(release)
+		if err != nil {
+			klog.Fatalf("failed to get the KubeletConfiguration fields of %s: %v", release, err)
+		}
+		kubeletFields = append(kubeletFields, fields)
+	}
+
+	c := Catalog{Versions: minors, Flags: map[string]map[string]Lifecycle{}, FeatureGates: lifecycles(minors, gates), KubeletConfigFields: lifecycles(minors, kubeletFields)}
+	for component, sets := range flags {
+		c.Flags[component] = lifecycles(minors, sets)
+	}
+	data, err := json.MarshalIndent(c, "", "\t")
+	if err != nil {
+		klog.Fatalf("failed to marshal the catalog: %v", err)
+	}
+	if err := os.WriteFile(catalogOut, append(data, '\n'), 0644); err != nil {
+		klog.Fatalf("failed to write the catalog: %v", err)
+	}
+}
+
+// latestPatches returns the latest stable patch release of each minor version supported by minikube, oldest first
+func latestPatches() []string {
+	oldest := semver.MajorMinor(constants.OldestKubernetesVersion)
+	newest := semver.MajorMinor(constants.NewestKubernetesVersion)
+	latest := map[string]string{}
+	for _, v := range constants.ValidKubernetesVersions {
+		minor := semver.MajorMinor(v)
+		if semver.Prerelease(v) != "" || semver.Compare(minor, oldest) < 0 || semver.Compare(minor, newest) > 0 {
+			continue
+		}
+		if semver.Compare(v, latest[minor]) > 0 {
+			latest[minor] = v
+		}
+	}
+	releases := []string{}
+	for _, v := range latest {
+		releases = append(releases, v)
+	}
+	sort.Slice(releases, func(i, j int) bool { return semver.Compare(releases[i], releases[j]) < 0 })
+	return releases
+}
+
+// lifecycles returns when each name was added and removed, from the names of each minor version
+func lifecycles(minors []string, sets []map[string]bool) map[string]Lifecycle {
+	l := map[string]Lifecycle{}
+	for i, set := range sets {
+		for name := range set {
+			if _, ok := l[name]; !ok && i > 0 {
+				l[name] = Lifecycle{Added: minors[i]}
+			} else if !ok {
+				l[name] = Lifecycle{}
+			}
+		}
+		if i == 0 {
+			continue
+		}
+		for name, lc := range l {
+			if sets[i-1][name] && !set[name] {
+				lc.Removed = minors[i]
+				l[name] = lc
+			}
+		}
+	}
+	return l
+}
+
+// binaryFlags returns the flags in the help of a component binary
+func binaryFlags(release, binary string) (map[string]bool, error) {
+	dir, err := os.MkdirTemp("", "kubernetes_catalog")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, binary)
+	if err := download(fmt.Sprintf(binaryURL, release, runtime.GOARCH, binary), path); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0755); err != nil {
+		return nil, err
+	}
+	help, err := exec.Command(path, "--help").CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("%s --help: %v", binary, err)
+	}
+	names := map[string]bool{}
+	for _, m := range flagRe.FindAllStringSubmatch(string(help), -1) {
+		names[m[1]] = true
+	}
+	return names, nil
+}
+
+// featureGates returns the feature gates declared in the sources of a release
+func featureGates(release string) (map[string]bool, error) {
+	names := map[string]bool{}
+	for _, file := range featureFiles {
+		resp, err := http.Get(fmt.Sprintf(sourceURL, release, file))
+		if err != nil {
+			return nil, err
+		}
+		src, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetching %s: %s", file, resp.Status)
+		}
+		for _, m := range featureRe.FindAllStringSubmatch(string(src), -1) {
+			names[m[1]] = true
+		}
+	}
+	return names, nil
+}
+
+// kubeletConfigFields returns the json names of the fields of the KubeletConfiguration in the sources of a release
+func kubeletConfigFields(release string) (map[string]bool, error) {
+	resp, err := http.Get(fmt.Sprintf(sourceURL, release, kubeletConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", kubeletConfigFile, resp.Status)
+	}
+	src, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	m := kubeletConfigRe.FindSubmatch(src)
+	if m == nil {
+		return nil, fmt.Errorf("no KubeletConfiguration type in %s", kubeletConfigFile)
+	}
+	names := map[string]bool{}
+	for _, t := range jsonTagRe.FindAllSubmatch(m[1], -1) {
+		names[string(t[1])] = true
+	}
+	return names, nil
+}
+
+func download(url, path string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
@@ -31,6 +31,11 @@ var schema = map[string]update.Item{
 			`"registry:.*`: `"registry:{{.Version}}@{{.SHA}}",`,
 		},
 	},
+	"pkg/minikube/registrycache/registrycache.go": {
+		Replace: map[string]string{
+			`"docker.io/library/registry:.*"`: `"docker.io/library/registry:{{.Version}}@{{.SHA}}"`,
+		},
+	},
 }
 
 type Data struct {
@@ -48,7 +48,8 @@ var (
 )
 
 func shouldSkip(component string) bool {
-	if runtime.GOOS != "linux" && component == "kubeadm_constants" { // kubeadm constants update job only works on linux
+	// kubeadm constants and kubernetes catalog update jobs only work on linux, they run the Kubernetes binaries
+	if runtime.GOOS != "linux" && (component == "kubeadm_constants" || component == "kubernetes_catalog") {
 		log.Printf("Skipping %s on non-linux OS: %s", component, runtime.GOOS)
 		return true
 	}
@@ -37,6 +37,7 @@ import (
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/kapi"
 	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -319,6 +320,12 @@ func addonSpecificChecks(cc *config.ClusterConfig, name string, enable bool, run
 				out.Styled(style.Tip, `After the addon is enabled, please run "minikube tunnel" and your ingress resources would be available at "127.0.0.1"`)
 			}
 		}
+		// the default certificate issued by the CA supplied with --ca-cert must exist before the controller starts
+		if name == "ingress" && cc.KubernetesConfig.CustomIngressCert == bootstrapper.IngressCertSecret {
+			if err := applyIngressCert(cc); err != nil {
+				return false, errors.Wrap(err, "applying the default ingress certificate")
+			}
+		}
 	}
 
 	if strings.HasPrefix(name, "istio") && enable {
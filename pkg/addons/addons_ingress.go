@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/service"
+)
+
+// applyIngressCert creates or updates the secret of the default ingress certificate, issued by the minikube CA
+func applyIngressCert(cc *config.ClusterConfig) error {
+	certPath, keyPath, err := bootstrapper.GenerateIngressCert(*cc)
+	if err != nil {
+		return err
+	}
+	cert, err := os.ReadFile(certPath)
+	if err != nil {
+		return errors.Wrap(err, "reading ingress cert")
+	}
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return errors.Wrap(err, "reading ingress key")
+	}
+
+	namespace, name, _ := strings.Cut(bootstrapper.IngressCertSecret, "/")
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       cert,
+			corev1.TLSPrivateKeyKey: key,
+		},
+	}
+
+	client, err := service.K8s.GetCoreClient(cc.Name)
+	if err != nil {
+		return err
+	}
+	secrets := client.Secrets(namespace)
+	klog.Infof("applying the default ingress certificate secret %s", bootstrapper.IngressCertSecret)
+	if _, err := secrets.Create(context.TODO(), secret, metav1.CreateOptions{}); err == nil || !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	_, err = secrets.Update(context.TODO(), secret, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ContainerNetworkIP returns the IPv4 address of a container on a network,
+// or an empty string if the container is not connected to it
+func ContainerNetworkIP(ociBin string, containerName string, networkName string) (string, error) {
+	if networkName == "" {
+		networkName = defaultBridgeName(ociBin)
+	}
+	format := fmt.Sprintf("{{with index .NetworkSettings.Networks %q}}{{.IPAddress}}{{end}}", networkName)
+	lines, err := inspect(ociBin, containerName, format)
+	if err != nil {
+		return "", errors.Wrapf(err, "inspecting network %s of %s", networkName, containerName)
+	}
+	if len(lines) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(lines[0]), nil
+}
+
+// ConnectNetwork connects a running container to a network
+func ConnectNetwork(ociBin string, networkName string, containerName string) error {
+	if _, err := runCmd(exec.Command(ociBin, "network", "connect", networkName, containerName)); err != nil {
+		return errors.Wrapf(err, "connecting %s to network %s", containerName, networkName)
+	}
+	return nil
+}
+
+// DisconnectNetwork disconnects a container from a network
+func DisconnectNetwork(ociBin string, networkName string, containerName string) error {
+	if _, err := runCmd(exec.Command(ociBin, "network", "disconnect", networkName, containerName)); err != nil {
+		return errors.Wrapf(err, "disconnecting %s from network %s", containerName, networkName)
+	}
+	return nil
+}
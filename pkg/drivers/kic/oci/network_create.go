@@ -316,8 +316,8 @@ func networkExists(ociBin string, name string) bool {
 	return err == nil
 }
 
-// networkNamesByLabel returns all network names created by a label
-func networkNamesByLabel(ociBin string, label string) ([]string, error) {
+// NetworkNamesByLabel returns all network names created by a label
+func NetworkNamesByLabel(ociBin string, label string) ([]string, error) {
 	// docker network ls --filter='label=created_by.minikube.sigs.k8s.io=true' --format '{{.Name}}'
 	rr, err := runCmd(exec.Command(ociBin, "network", "ls", fmt.Sprintf("--filter=label=%s", label), "--format", "{{.Name}}"))
 	if err != nil {
@@ -335,7 +335,7 @@ func networkNamesByLabel(ociBin string, label string) ([]string, error) {
 // DeleteKICNetworksByLabel deletes all networks that have a specific label
 func DeleteKICNetworksByLabel(ociBin string, label string) []error {
 	var errs []error
-	ns, err := networkNamesByLabel(ociBin, label)
+	ns, err := NetworkNamesByLabel(ociBin, label)
 	if err != nil {
 		return []error{errors.Wrap(err, "list all volume")}
 	}
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -30,6 +29,7 @@ import (
 
 	"github.com/docker/machine/libmachine/mcnutils"
 	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/mirror"
 )
 
 const (
@@ -44,9 +44,9 @@ const (
 `
 )
 
-var (
-	shimURL   = releaseURL() + "containerd-shim-runsc-v1"
-	gvisorURL = releaseURL() + "runsc"
+const (
+	shimBinary   = "containerd-shim-runsc-v1"
+	gvisorBinary = "runsc"
 )
 
 func releaseURL() string {
@@ -60,12 +60,23 @@ func releaseURL() string {
 	return fmt.Sprintf("https://storage.googleapis.com/gvisor/releases/release/latest/%s/", arch)
 }
 
+// binaryURL returns the URL of a gvisor binary, on the artifact mirror if one is set
+func binaryURL(binary string) string {
+	if mirror.Enabled() {
+		return mirror.URL("gvisor", runtime.GOARCH, binary)
+	}
+	return releaseURL() + binary
+}
+
 // Enable follows these steps for enabling gvisor in minikube:
 //  1. creates necessary directories for storing binaries and runsc logs
-//  2. downloads runsc and gvisor-containerd-shim
+//  2. downloads runsc and gvisor-containerd-shim, from the artifact mirror in $MINIKUBE_ARTIFACT_MIRROR if set
 //  3. configures containerd
 //  4. restarts containerd
 func Enable() error {
+	if err := mirror.Set(os.Getenv(mirror.Env)); err != nil {
+		return errors.Wrap(err, "artifact mirror")
+	}
 	if err := makeGvisorDirs(); err != nil {
 		return errors.Wrap(err, "creating directories on node")
 	}
@@ -123,30 +134,24 @@ func downloadBinaries() error {
 
 // downloads the gvisor-containerd-shim
 func gvisorContainerdShim() error {
-	dest := filepath.Join(nodeDir, "usr/bin/containerd-shim-runsc-v1")
-	return downloadFileToDest(shimURL, dest)
+	dest := filepath.Join(nodeDir, "usr/bin", shimBinary)
+	return downloadFileToDest(binaryURL(shimBinary), dest)
 }
 
 // downloads the runsc binary and returns a path to the binary
 func runsc() error {
-	dest := filepath.Join(nodeDir, "usr/bin/runsc")
-	return downloadFileToDest(gvisorURL, dest)
+	dest := filepath.Join(nodeDir, "usr/bin", gvisorBinary)
+	return downloadFileToDest(binaryURL(gvisorBinary), dest)
 }
 
 // downloadFileToDest downloads the given file to the dest
 // if something already exists at dest, first remove it
 func downloadFileToDest(url, dest string) error {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return errors.Wrapf(err, "creating request for %s", url)
-	}
-	req.Header.Set("User-Agent", "minikube")
-	resp, err := client.Do(req)
+	body, err := mirror.Open(url)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	defer body.Close()
 	if _, err := os.Stat(dest); err == nil {
 		if err := os.Remove(dest); err != nil {
 			return errors.Wrapf(err, "removing %s for overwrite", dest)
@@ -157,9 +162,15 @@ func downloadFileToDest(url, dest string) error {
 		return errors.Wrapf(err, "creating %s", dest)
 	}
 	defer fi.Close()
-	if _, err := io.Copy(fi, resp.Body); err != nil {
+	if _, err := io.Copy(fi, body); err != nil {
 		return errors.Wrap(err, "copying binary")
 	}
+	if mirror.Enabled() {
+		if err := mirror.Verify(dest, url); err != nil {
+			os.Remove(dest)
+			return errors.Wrap(err, "verifying binary")
+		}
+	}
 	if err := fi.Chmod(0777); err != nil {
 		return errors.Wrap(err, "fixing perms")
 	}
@@ -19,15 +19,19 @@ package assets
 import (
 	"fmt"
 	"os"
+	"path"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
 	semver "github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
 	"k8s.io/minikube/deploy/addons"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/vmpath"
 	"k8s.io/minikube/pkg/util"
@@ -884,6 +888,43 @@ func SelectAndPersistImages(addon *Addon, cc *config.ClusterConfig) (images, cus
 	return images, customRegistries, nil
 }
 
+// AddonImages returns the images an addon runs, qualified with their registries, taking the custom images
+// and registries of the cluster into account without persisting anything
+func AddonImages(addon *Addon, cc *config.ClusterConfig) []string {
+	images := overrideDefaults(addon.Images, cc.CustomAddonImages)
+	registries := filterKeySpace(addon.Images, cc.CustomAddonRegistries)
+
+	names := []string{}
+	for name := range images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	imgs := []string{}
+	for _, name := range names {
+		img := images[name]
+		registry := addon.Registries[name]
+		if r, ok := registries[name]; ok {
+			registry = r
+		}
+		if registry != "" {
+			img = path.Join(registry, img)
+		}
+		imgs = append(imgs, img)
+	}
+	return imgs
+}
+
+// podArtifactMirror returns the artifact mirror for the addons that download from it in the cluster,
+// which cannot read a file:// mirror on the host
+func podArtifactMirror() string {
+	if _, ok := mirror.LocalPath(mirror.Base()); ok {
+		klog.Warningf("not passing the file:// artifact mirror %s to the cluster, it is only readable from the host", mirror.Base())
+		return ""
+	}
+	return mirror.Base()
+}
+
 // GenerateTemplateData generates template data for template assets
 func GenerateTemplateData(addon *Addon, cc *config.ClusterConfig, netInfo NetworkInfo, images, customRegistries map[string]string, enable bool) interface{} {
 	cfg := cc.KubernetesConfig
@@ -938,6 +979,7 @@ func GenerateTemplateData(addon *Addon, cc *config.ClusterConfig, netInfo Networ
 		NetworkInfo:            make(map[string]string),
 		Environment: map[string]string{
 			"MockGoogleToken": os.Getenv("MOCK_GOOGLE_TOKEN"),
+			"ArtifactMirror":  podArtifactMirror(),
 		},
 		LegacyPodSecurityPolicy: v.LT(semver.Version{Major: 1, Minor: 25}),
 		LegacyRuntimeClass:      v.LT(semver.Version{Major: 1, Minor: 25}),
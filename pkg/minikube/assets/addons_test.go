@@ -373,3 +373,27 @@ func checkMatches(t *testing.T, name string, got, expected map[string]string) {
 		t.Errorf("expected %q to be %q, but got %q", name, expected[name], got[name])
 	}
 }
+
+func TestAddonImages(t *testing.T) {
+	ms := Addons["metrics-server"]
+	const img = "metrics-server/metrics-server:v0.8.0@sha256:89258156d0e9af60403eafd44da9676fd66f600c7934d468ccc17e42b199aee2"
+
+	tests := []struct {
+		name string
+		cc   *config.ClusterConfig
+		want string
+	}{
+		{"Default", &config.ClusterConfig{}, "registry.k8s.io/" + img},
+		{"CustomRegistry", &config.ClusterConfig{CustomAddonRegistries: map[string]string{"MetricsServer": "mirror.local:5000"}}, "mirror.local:5000/" + img},
+		{"CustomImage", &config.ClusterConfig{CustomAddonImages: map[string]string{"MetricsServer": "my/metrics:dev"}}, "registry.k8s.io/my/metrics:dev"},
+		{"UnknownCustomImage", &config.ClusterConfig{CustomAddonImages: map[string]string{"Other": "other:dev"}}, "registry.k8s.io/" + img},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := AddonImages(ms, tc.cc)
+			if len(got) != 1 || got[0] != tc.want {
+				t.Errorf("AddonImages() = %v, want [%s]", got, tc.want)
+			}
+		})
+	}
+}
@@ -308,6 +308,56 @@ func NewMemoryAsset(d []byte, targetDir, targetName, permissions string) *Memory
 	}
 }
 
+// StreamAsset is an asset that streams the content copied from a host to a writer
+type StreamAsset struct {
+	BaseAsset
+	writer io.Writer
+	length int
+}
+
+// GetLength returns length
+func (s *StreamAsset) GetLength() int {
+	return s.length
+}
+
+// SetLength sets length
+func (s *StreamAsset) SetLength(length int) {
+	s.length = length
+}
+
+// Read is not supported, a StreamAsset can only be the destination of a copy
+func (s *StreamAsset) Read(_ []byte) (int, error) {
+	return 0, errors.New("stream asset is write-only")
+}
+
+// Write writes the asset
+func (s *StreamAsset) Write(p []byte) (int, error) {
+	return s.writer.Write(p)
+}
+
+// Seek is not supported for streams
+func (s *StreamAsset) Seek(_ int64, _ int) (int64, error) {
+	return 0, errors.New("stream asset is not seekable")
+}
+
+// Close implemented for CopyableFile interface. Always return nil.
+func (s *StreamAsset) Close() error {
+	return nil
+}
+
+// NewStreamAsset creates a new StreamAsset, which writes the target file to w when used with CopyFrom
+func NewStreamAsset(w io.Writer, targetDir, targetName, permissions string) *StreamAsset {
+	return &StreamAsset{
+		BaseAsset: BaseAsset{
+			TargetDir:   targetDir,
+			TargetName:  targetName,
+			Permissions: permissions,
+			SourcePath:  MemorySource,
+		},
+		writer: w,
+	}
+}
+
 // BinAsset is a bindata (binary data) asset
 type BinAsset struct {
 	embed.FS
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bsutil will eventually be renamed to kubeadm package after getting rid of older one
+package bsutil
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+var (
+	// AuditPolicyDir is where the audit policy of the apiserver is copied to on the control-plane nodes
+	AuditPolicyDir = "/etc/kubernetes/audit"
+	// AuditLogDir is where the apiserver writes its audit log
+	AuditLogDir = "/var/log/kubernetes/audit"
+	// AuditLogPath is the audit log of the apiserver
+	AuditLogPath = path.Join(AuditLogDir, "audit.log")
+)
+
+// auditPolicyTmpl is the audit policy of the presets, logging every request at a level.
+// Health checks and leases are too frequent to be of interest, and secrets are only ever logged at the Metadata level
+// so that their content does not end up in the log.
+const auditPolicyTmpl = `apiVersion: audit.k8s.io/v1
+kind: Policy
+omitStages:
+  - "RequestReceived"
+rules:
+  - level: None
+    nonResourceURLs:
+      - "/healthz*"
+      - "/livez*"
+      - "/readyz*"
+      - "/version"
+  - level: None
+    resources:
+      - group: "coordination.k8s.io"
+        resources: ["leases"]
+  - level: None
+    users: ["system:kube-proxy"]
+    verbs: ["watch"]
+  - level: Metadata
+    resources:
+      - group: ""
+        resources: ["secrets", "configmaps", "serviceaccounts/token"]
+      - group: "authentication.k8s.io"
+        resources: ["tokenreviews"]
+  - level: %s
+`
+
+// auditPolicyPresets are the built-in audit policies, by name
+var auditPolicyPresets = map[string]string{
+	"metadata":        fmt.Sprintf(auditPolicyTmpl, "Metadata"),
+	"request":         fmt.Sprintf(auditPolicyTmpl, "Request"),
+	"requestresponse": fmt.Sprintf(auditPolicyTmpl, "RequestResponse"),
+}
+
+// AuditPolicyPresets returns the names of the built-in audit policies
+func AuditPolicyPresets() []string {
+	names := []string{}
+	for name := range auditPolicyPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsAuditPolicyPreset returns true if an audit policy is the name of a built-in one rather than a file
+func IsAuditPolicyPreset(policy string) bool {
+	_, ok := auditPolicyPresets[strings.ToLower(policy)]
+	return ok
+}
+
+// LoadAuditPolicy returns the content of an audit policy, either the name of a built-in one or the path of a file
+func LoadAuditPolicy(policy string) ([]byte, error) {
+	if p, ok := auditPolicyPresets[strings.ToLower(policy)]; ok {
+		return []byte(p), nil
+	}
+	data, err := os.ReadFile(policy)
+	if err != nil {
+		return nil, fmt.Errorf("%q is neither an audit policy file nor one of the presets %s: %v", policy, strings.Join(AuditPolicyPresets(), ", "), err)
+	}
+	var p struct {
+		APIVersion string        `yaml:"apiVersion"`
+		Kind       string        `yaml:"kind"`
+		Rules      []interface{} `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, errors.Wrapf(err, "parsing audit policy %s", policy)
+	}
+	if p.APIVersion != "audit.k8s.io/v1" || p.Kind != "Policy" {
+		return nil, fmt.Errorf("%s is not an audit.k8s.io/v1 Policy", policy)
+	}
+	if len(p.Rules) == 0 {
+		return nil, fmt.Errorf("audit policy %s has no rules", policy)
+	}
+	return data, nil
+}
+
+// auditPolicyPath returns the path of an audit policy on the control-plane nodes.
+// The name changes with the policy so that the kubeadm config does too, and the apiserver is restarted to load it.
+func auditPolicyPath(policy []byte) string {
+	sum := sha256.Sum256(policy)
+	return path.Join(AuditPolicyDir, fmt.Sprintf("policy-%x.yaml", sum[:6]))
+}
+
+// AuditPolicyAsset returns the audit policy of a cluster as a file to copy to the control-plane nodes
+func AuditPolicyAsset(policy string) (assets.CopyableFile, error) {
+	data, err := LoadAuditPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	return assets.NewMemoryAssetTarget(data, auditPolicyPath(data), "0600"), nil
+}
+
+// addAuditPolicy configures the apiserver of the kubeadm component options to log to AuditLogPath according to an audit policy
+func addAuditPolicy(components []componentOptions, policy string) error {
+	data, err := LoadAuditPolicy(policy)
+	if err != nil {
+		return err
+	}
+	args := map[string]string{
+		"audit-policy-file":   auditPolicyPath(data),
+		"audit-log-path":      AuditLogPath,
+		"audit-log-maxage":    "7",
+		"audit-log-maxbackup": "1",
+		"audit-log-maxsize":   "100",
+	}
+	c, err := apiServerOptions(components)
+	if err != nil {
+		return err
+	}
+	c.setDefaultArgs(args)
+	c.addExtraVolume("audit-policy", AuditPolicyDir, true)
+	c.addExtraVolume("audit-log", AuditLogDir, false)
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+func TestLoadAuditPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{"preset", "metadata", false},
+		{"preset-case", "RequestResponse", false},
+		{"file", write("policy.yaml", "apiVersion: audit.k8s.io/v1\nkind: Policy\nrules:\n- level: Metadata\n"), false},
+		{"no-rules", write("empty.yaml", "apiVersion: audit.k8s.io/v1\nkind: Policy\n"), true},
+		{"other-kind", write("pod.yaml", "apiVersion: v1\nkind: Pod\n"), true},
+		{"missing", "verbose", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := LoadAuditPolicy(tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("LoadAuditPolicy(%q) = %v, wantErr %v", tc.policy, err, tc.wantErr)
+			}
+		})
+	}
+	for _, name := range AuditPolicyPresets() {
+		var p map[string]interface{}
+		if err := yaml.Unmarshal([]byte(auditPolicyPresets[name]), &p); err != nil {
+			t.Errorf("preset %s is not valid YAML: %v", name, err)
+		}
+	}
+}
+
+func TestGenerateKubeadmYAMLAuditPolicy(t *testing.T) {
+	fcr := command.NewFakeCommandRunner()
+	fcr.SetCommandToOutput(map[string]string{
+		"docker info --format {{.CgroupDriver}}": "systemd\n",
+	})
+	r, err := cruntime.New(cruntime.Config{Type: "docker", Runner: fcr, Socket: "/var/run/dockershim.sock"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+
+	for _, version := range []string{"v1.28.0", "v1.31.0"} {
+		t.Run(version, func(t *testing.T) {
+			configs := map[string]string{}
+			for _, policy := range []string{"metadata", "requestresponse"} {
+				cfg := config.ClusterConfig{
+					Name: "mk",
+					KubernetesConfig: config.KubernetesConfig{
+						KubernetesVersion: version,
+						ClusterName:       "kubernetes",
+						AuditPolicy:       policy,
+					},
+					Nodes: []config.Node{{IP: "1.1.1.1", Name: "mk", ControlPlane: true}},
+				}
+				got, err := GenerateKubeadmYAML(cfg, cfg.Nodes[0], r)
+				if err != nil {
+					t.Fatalf("GenerateKubeadmYAML() = %v", err)
+				}
+				configs[policy] = string(got)
+
+				cc := strings.Split(string(got), "\n---\n")[1]
+				var parsed struct {
+					APIServer struct {
+						ExtraVolumes []map[string]interface{} `yaml:"extraVolumes"`
+					} `yaml:"apiServer"`
+				}
+				if err := yaml.Unmarshal([]byte(cc), &parsed); err != nil {
+					t.Fatalf("parsing the cluster configuration: %v\n%s", err, cc)
+				}
+				if len(parsed.APIServer.ExtraVolumes) != 2 {
+					t.Errorf("expected the audit policy and log to be mounted in the apiserver:\n%s", cc)
+				}
+				data, err := LoadAuditPolicy(policy)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, arg := range []string{"audit-policy-file", auditPolicyPath(data), "audit-log-path", AuditLogPath} {
+					if !strings.Contains(cc, arg) {
+						t.Errorf("expected %s in the cluster configuration:\n%s", arg, cc)
+					}
+				}
+			}
+			if configs["metadata"] == configs["requestresponse"] {
+				t.Errorf("expected the kubeadm config to change with the audit policy")
+			}
+		})
+	}
+}
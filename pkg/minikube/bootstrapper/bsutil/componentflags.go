@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bsutil will eventually be renamed to kubeadm package after getting rid of older one
+package bsutil
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kcatalog"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/util"
+)
+
+// ValidateComponentFlags checks the --extra-config flags and the feature gates of the Kubernetes components
+// against the catalog of their version, before they crash-loop on an unknown flag.
+// The problems are only likely for Kubernetes versions newer than the catalog, which approximate reports.
+func ValidateComponentFlags(k8s config.KubernetesConfig) (problems []error, approximate bool, err error) {
+	version, err := util.ParseKubernetesVersion(k8s.KubernetesVersion)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "parsing Kubernetes version")
+	}
+	c, ok := kcatalog.ForVersion(version)
+	if !ok {
+		klog.Infof("not validating the component flags of Kubernetes %s, which is older than the catalog", k8s.KubernetesVersion)
+		return nil, false, nil
+	}
+
+	seen := map[string]bool{}
+	add := func(err error) {
+		if err != nil && !seen[err.Error()] {
+			seen[err.Error()] = true
+			problems = append(problems, err)
+		}
+	}
+	checkGates := func(featureGates string) error {
+		// kubeadm feature gates are not passed to the components
+		_, componentGates, err := parseFeatureArgs(featureGates)
+		if err != nil {
+			return err
+		}
+		for _, fg := range strings.Split(componentGates, ",") {
+			if name := strings.TrimSpace(strings.SplitN(fg, "=", 2)[0]); name != "" {
+				add(c.CheckFeatureGate(name))
+			}
+		}
+		return nil
+	}
+
+	if err := checkGates(k8s.FeatureGates); err != nil {
+		add(fmt.Errorf("invalid --feature-gates: %v", err))
+	}
+	for _, eo := range k8s.ExtraOptions {
+		if !c.HasComponent(eo.Component) {
+			continue
+		}
+		// kubelet configuration fields are set in the kubeadm config rather than as flags
+		if eo.Component == Kubelet && slices.Contains(kubeletConfigParams, eo.Key) {
+			continue
+		}
+		add(c.CheckFlag(eo.Component, eo.Key))
+		if eo.Key == "feature-gates" {
+			if err := checkGates(eo.Value); err != nil {
+				add(fmt.Errorf("invalid %s: %v", eo.String(), err))
+			}
+		}
+	}
+	return problems, c.Approximate, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestValidateComponentFlags(t *testing.T) {
+	tests := []struct {
+		name         string
+		version      string
+		featureGates string
+		extraConfig  []string
+		want         []string
+		approximate  bool
+	}{
+		{
+			name:         "valid",
+			version:      "v1.31.0",
+			featureGates: "InPlacePodVerticalScaling=true,PublicKeysECDSA=true",
+			extraConfig: []string{"apiserver.audit-log-path=/var/log/audit.log", "kubelet.max-pods=200", "kubelet.localStorageCapacityIsolation=false",
+				"etcd.anything=1", "kube-proxy.mode=ipvs", "kubelet.feature-gates=ImageVolume=true"},
+		},
+		{
+			name:         "invalid",
+			version:      "v1.31.0",
+			featureGates: "InPlacePodVerticalScalng=true",
+			extraConfig:  []string{"apiserver.foo=bar", "kubelet.network-plugin=cni", "scheduler.feature-gates=SeccompDefault=true"},
+			want: []string{
+				`unknown feature gate "InPlacePodVerticalScalng" in Kubernetes v1.31, did you mean "InPlacePodVerticalScaling"?`,
+				`unknown flag "foo" of apiserver in Kubernetes v1.31`,
+				`unknown flag "network-plugin" of kubelet in Kubernetes v1.31`,
+				`feature gate "SeccompDefault" was removed in Kubernetes v1.29`,
+			},
+		},
+		{
+			name:        "newer than the catalog",
+			version:     "v1.99.0",
+			extraConfig: []string{"apiserver.foo=bar"},
+			want:        []string{`unknown flag "foo" of apiserver in Kubernetes v1.34`},
+			approximate: true,
+		},
+		{
+			name:        "older than the catalog",
+			version:     "v1.20.0",
+			extraConfig: []string{"apiserver.foo=bar"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			k8s := config.KubernetesConfig{KubernetesVersion: tc.version, FeatureGates: tc.featureGates}
+			for _, eo := range tc.extraConfig {
+				if err := k8s.ExtraOptions.Set(eo); err != nil {
+					t.Fatalf("setting %s: %v", eo, err)
+				}
+			}
+			problems, approximate, err := ValidateComponentFlags(k8s)
+			if err != nil {
+				t.Fatalf("ValidateComponentFlags() = %v", err)
+			}
+			got := []string{}
+			for _, p := range problems {
+				got = append(got, p.Error())
+			}
+			if strings.Join(got, "\n") != strings.Join(tc.want, "\n") {
+				t.Errorf("ValidateComponentFlags() problems:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tc.want, "\n"))
+			}
+			if approximate != tc.approximate {
+				t.Errorf("ValidateComponentFlags() approximate = %v, want %v", approximate, tc.approximate)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bsutil will eventually be renamed to kubeadm package after getting rid of older one
+package bsutil
+
+import (
+	"k8s.io/minikube/pkg/minikube/encryption"
+)
+
+// addSecretsEncryption configures the apiserver of the kubeadm component options to encrypt secrets at rest.
+// The path of the encryption configuration does not change with its keys, which are rotated by restarting the apiservers.
+func addSecretsEncryption(components []componentOptions) error {
+	c, err := apiServerOptions(components)
+	if err != nil {
+		return err
+	}
+	c.setDefaultArgs(map[string]string{"encryption-provider-config": encryption.GuestConfig})
+	c.addExtraVolume("encryption-config", encryption.GuestDir, true)
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/encryption"
+)
+
+func TestGenerateKubeadmYAMLSecretsEncryption(t *testing.T) {
+	fcr := command.NewFakeCommandRunner()
+	fcr.SetCommandToOutput(map[string]string{
+		"docker info --format {{.CgroupDriver}}": "systemd\n",
+	})
+	r, err := cruntime.New(cruntime.Config{Type: "docker", Runner: fcr, Socket: "/var/run/dockershim.sock"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+
+	cfg := config.ClusterConfig{
+		Name: "mk",
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.31.0",
+			ClusterName:       "kubernetes",
+			AuditPolicy:       "metadata",
+			SecretsEncryption: encryption.AESCBC,
+		},
+		Nodes: []config.Node{{IP: "1.1.1.1", Name: "mk", ControlPlane: true}},
+	}
+	got, err := GenerateKubeadmYAML(cfg, cfg.Nodes[0], r)
+	if err != nil {
+		t.Fatalf("GenerateKubeadmYAML() = %v", err)
+	}
+	cc := strings.Split(string(got), "\n---\n")[1]
+	var parsed struct {
+		APIServer struct {
+			ExtraVolumes []map[string]interface{} `yaml:"extraVolumes"`
+		} `yaml:"apiServer"`
+	}
+	if err := yaml.Unmarshal([]byte(cc), &parsed); err != nil {
+		t.Fatalf("parsing the cluster configuration: %v\n%s", err, cc)
+	}
+	names := []string{}
+	for _, v := range parsed.APIServer.ExtraVolumes {
+		names = append(names, v["name"].(string))
+	}
+	if strings.Join(names, ",") != "audit-policy,audit-log,encryption-config" {
+		t.Errorf("apiserver volumes = %v, want the audit and encryption volumes:\n%s", names, cc)
+	}
+	if !strings.Contains(cc, encryption.GuestConfig) {
+		t.Errorf("expected the encryption provider config in the cluster configuration:\n%s", cc)
+	}
+}
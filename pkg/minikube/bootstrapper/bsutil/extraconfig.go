@@ -178,6 +178,38 @@ func newComponentOptions(opts config.ExtraOptionSlice, version semver.Version, f
 	return kubeadmExtraArgs, nil
 }
 
+// apiServerOptions returns the options of the apiserver among the kubeadm component options
+func apiServerOptions(components []componentOptions) (*componentOptions, error) {
+	for i := range components {
+		if components[i].Component == componentToKubeadmConfigKey[Apiserver] {
+			return &components[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no apiserver options in the kubeadm config")
+}
+
+// setDefaultArgs sets extra args of a component, unless they are set with --extra-config
+func (c *componentOptions) setDefaultArgs(args map[string]string) {
+	for k, v := range args {
+		if _, ok := c.ExtraArgs[k]; !ok {
+			c.ExtraArgs[k] = v
+		}
+	}
+}
+
+// addExtraVolume mounts a host directory at the same path in the static pod of a component
+func (c *componentOptions) addExtraVolume(name string, dir string, readOnly bool) {
+	if c.Pairs == nil {
+		c.Pairs = map[string]string{}
+	}
+	volume := fmt.Sprintf(`{name: "%s", hostPath: "%s", mountPath: "%s", readOnly: %t, pathType: DirectoryOrCreate}`, name, dir, dir, readOnly)
+	if volumes, ok := c.Pairs["extraVolumes"]; ok {
+		c.Pairs["extraVolumes"] = strings.TrimSuffix(volumes, "]") + ", " + volume + "]"
+		return
+	}
+	c.Pairs["extraVolumes"] = "[" + volume + "]"
+}
+
 // optionPairsForComponent generates a map of value pairs for a k8s component
 func optionPairsForComponent(component string, cp config.Node) map[string]string {
 	if component == Apiserver {
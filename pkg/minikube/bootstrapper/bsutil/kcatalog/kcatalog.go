@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kcatalog is a catalog of the flags and feature gates of the Kubernetes components,
+// and of the fields of the KubeletConfiguration, for each minor version.
+//
+// The catalog is generated from the Kubernetes releases by `make update-kubernetes-catalog`, which runs
+// hack/update/kubernetes_catalog.
+package kcatalog
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+// Lifecycle is the range of minor versions in which a flag or feature gate exists
+type Lifecycle struct {
+	// Added is the first minor version with it, empty if it predates the catalog
+	Added string `json:"added,omitempty"`
+	// Removed is the first minor version without it, empty if it still exists
+	Removed string `json:"removed,omitempty"`
+}
+
+// data is the content of catalog.json
+type data struct {
+	// Versions are the minor versions of the catalog, oldest first
+	Versions []string `json:"versions"`
+	// Flags are the flags of each component, by the component names of --extra-config
+	Flags map[string]map[string]Lifecycle `json:"flags"`
+	// FeatureGates are the feature gates shared by the components
+	FeatureGates map[string]Lifecycle `json:"featureGates"`
+	// KubeletConfigFields are the top-level fields of the v1beta1 KubeletConfiguration
+	KubeletConfigFields map[string]Lifecycle `json:"kubeletConfigFields"`
+}
+
+var catalog data
+
+func init() {
+	if err := json.Unmarshal(catalogJSON, &catalog); err != nil {
+		panic(fmt.Sprintf("parsing the Kubernetes catalog: %v", err))
+	}
+}
+
+// Catalog is the flags, feature gates and KubeletConfiguration fields of a Kubernetes version
+type Catalog struct {
+	// Version is the minor version of the catalog
+	Version string
+	// Approximate is true for Kubernetes versions newer than the catalog, which get the catalog of the newest version
+	Approximate bool
+	minor       uint64
+}
+
+// ForVersion returns the catalog of a Kubernetes version, and false for versions older than the catalog
+func ForVersion(v semver.Version) (*Catalog, bool) {
+	oldest, newest := minorOf(catalog.Versions[0]), minorOf(catalog.Versions[len(catalog.Versions)-1])
+	if v.Major != 1 || v.Minor < oldest {
+		return nil, false
+	}
+	if v.Minor > newest {
+		return &Catalog{Version: catalog.Versions[len(catalog.Versions)-1], Approximate: true, minor: newest}, true
+	}
+	return &Catalog{Version: fmt.Sprintf("v1.%d", v.Minor), minor: v.Minor}, true
+}
+
+// minorOf returns the minor of a "v1.x" version of the catalog
+func minorOf(version string) uint64 {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		panic(fmt.Sprintf("invalid version %q in the Kubernetes catalog", version))
+	}
+	return v.Minor
+}
+
+// HasComponent returns whether the catalog knows the flags of a component
+func (c *Catalog) HasComponent(component string) bool {
+	_, ok := catalog.Flags[component]
+	return ok
+}
+
+// CheckFlag returns an error if a component has no such flag in the catalog version
+func (c *Catalog) CheckFlag(component, flag string) error {
+	return c.check(catalog.Flags[component], flag, fmt.Sprintf("flag %q of %s", flag, component))
+}
+
+// CheckFeatureGate returns an error if there is no such feature gate in the catalog version
+func (c *Catalog) CheckFeatureGate(gate string) error {
+	return c.check(catalog.FeatureGates, gate, fmt.Sprintf("feature gate %q", gate))
+}
+
+// CheckKubeletConfigField returns an error if there is no such KubeletConfiguration field in the catalog version
+func (c *Catalog) CheckKubeletConfigField(field string) error {
+	return c.check(catalog.KubeletConfigFields, field, fmt.Sprintf("KubeletConfiguration field %q", field))
+}
+
+// check returns an error if a name is not in the catalog version
+func (c *Catalog) check(names map[string]Lifecycle, name string, what string) error {
+	if l, ok := names[name]; ok {
+		if l.Added != "" && c.minor < minorOf(l.Added) {
+			return fmt.Errorf("%s requires Kubernetes %s or later", what, l.Added)
+		}
+		if l.Removed != "" && c.minor >= minorOf(l.Removed) {
+			return fmt.Errorf("%s was removed in Kubernetes %s", what, l.Removed)
+		}
+		return nil
+	}
+	msg := fmt.Sprintf("unknown %s in Kubernetes %s", what, c.Version)
+	if s := closest(name, c.names(names)); s != "" {
+		msg += fmt.Sprintf(", did you mean %q?", s)
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// names returns the names existing in the catalog version
+func (c *Catalog) names(names map[string]Lifecycle) []string {
+	existing := []string{}
+	for n, l := range names {
+		if (l.Added == "" || c.minor >= minorOf(l.Added)) && (l.Removed == "" || c.minor < minorOf(l.Removed)) {
+			existing = append(existing, n)
+		}
+	}
+	sort.Strings(existing)
+	return existing
+}
+
+// closest returns the candidate closest to a name, if it is close enough to be a typo
+func closest(name string, candidates []string) string {
+	best, bestDistance := "", 0
+	for _, c := range candidates {
+		d := distance(strings.ToLower(name), strings.ToLower(c))
+		if best == "" || d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if best == "" || bestDistance > 2+len(name)/5 {
+		return ""
+	}
+	return best
+}
+
+// distance returns the Levenshtein distance between two strings
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
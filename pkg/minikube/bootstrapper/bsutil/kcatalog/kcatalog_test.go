@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kcatalog
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+)
+
+func TestForVersion(t *testing.T) {
+	tests := []struct {
+		version     string
+		ok          bool
+		catalog     string
+		approximate bool
+	}{
+		{"1.20.0", false, "", false},
+		{"1.28.15", true, "v1.28", false},
+		{"1.31.0", true, "v1.31", false},
+		{"1.99.0-alpha.1", true, catalog.Versions[len(catalog.Versions)-1], true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.version, func(t *testing.T) {
+			c, ok := ForVersion(semver.MustParse(tc.version))
+			if ok != tc.ok {
+				t.Fatalf("ForVersion(%s) = %v, want %v", tc.version, ok, tc.ok)
+			}
+			if ok && (c.Version != tc.catalog || c.Approximate != tc.approximate) {
+				t.Errorf("ForVersion(%s) = %+v, want %s approximate %v", tc.version, c, tc.catalog, tc.approximate)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c, _ := ForVersion(semver.MustParse("1.30.0"))
+	tests := []struct {
+		name string
+		err  func() error
+		want string
+	}{
+		{"flag", func() error { return c.CheckFlag("apiserver", "audit-log-path") }, ""},
+		{"typo", func() error { return c.CheckFlag("apiserver", "audit-log-pth") },
+			`unknown flag "audit-log-pth" of apiserver in Kubernetes v1.30, did you mean "audit-log-path"?`},
+		{"unknown", func() error { return c.CheckFlag("apiserver", "foo") }, `unknown flag "foo" of apiserver in Kubernetes v1.30`},
+		{"other component", func() error { return c.CheckFlag("scheduler", "audit-log-path") },
+			`unknown flag "audit-log-path" of scheduler in Kubernetes v1.30`},
+		{"removed flag", func() error { return c.CheckFlag("kubelet", "azure-container-registry-config") },
+			`flag "azure-container-registry-config" of kubelet was removed in Kubernetes v1.30`},
+		{"gate", func() error { return c.CheckFeatureGate("InPlacePodVerticalScaling") }, ""},
+		{"gate case", func() error { return c.CheckFeatureGate("inplacepodverticalscaling") },
+			`unknown feature gate "inplacepodverticalscaling" in Kubernetes v1.30, did you mean "InPlacePodVerticalScaling"?`},
+		{"future gate", func() error { return c.CheckFeatureGate("ImageVolume") },
+			`feature gate "ImageVolume" requires Kubernetes v1.31 or later`},
+		{"removed gate", func() error { return c.CheckFeatureGate("SeccompDefault") },
+			`feature gate "SeccompDefault" was removed in Kubernetes v1.29`},
+		{"kubelet config field", func() error { return c.CheckKubeletConfigField("containerLogMaxWorkers") }, ""},
+		{"kubelet config typo", func() error { return c.CheckKubeletConfigField("maxPod") },
+			`unknown KubeletConfiguration field "maxPod" in Kubernetes v1.30, did you mean "maxPods"?`},
+		{"future kubelet config field", func() error { return c.CheckKubeletConfigField("failCgroupV1") },
+			`KubeletConfiguration field "failCgroupV1" requires Kubernetes v1.31 or later`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.err()
+			got := ""
+			if err != nil {
+				got = err.Error()
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCatalogVersions(t *testing.T) {
+	known := map[string]bool{}
+	for _, v := range catalog.Versions {
+		known[v] = true
+	}
+	check := func(what string, l Lifecycle) {
+		for _, v := range []string{l.Added, l.Removed} {
+			if v != "" && !known[v] {
+				t.Errorf("%s has version %q, which is not in the catalog versions %v", what, v, catalog.Versions)
+			}
+		}
+	}
+	for component, flags := range catalog.Flags {
+		for name, l := range flags {
+			check(component+" flag "+name, l)
+		}
+	}
+	for name, l := range catalog.FeatureGates {
+		check("feature gate "+name, l)
+	}
+}
@@ -39,6 +39,11 @@ nodeRegistration:
   kubeletExtraArgs:
     node-ip: {{.NodeIP}}
   taints: []
+{{- if .PatchesDir}}
+patches:
+  directory: {{.PatchesDir}}
+# patches checksum: {{.PatchesChecksum}}
+{{- end}}
 ---
 apiVersion: kubeadm.k8s.io/v1beta3
 kind: ClusterConfiguration
@@ -40,6 +40,11 @@ nodeRegistration:
     - name: "node-ip"
       value: "{{.NodeIP}}"
   taints: []
+{{- if .PatchesDir}}
+patches:
+  directory: {{.PatchesDir}}
+# patches checksum: {{.PatchesChecksum}}
+{{- end}}
 ---
 apiVersion: kubeadm.k8s.io/v1beta4
 kind: ClusterConfiguration
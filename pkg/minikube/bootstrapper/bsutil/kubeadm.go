@@ -71,6 +71,16 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 	if err != nil {
 		return nil, errors.Wrap(err, "generating extra component config for kubeadm")
 	}
+	if k8s.AuditPolicy != "" {
+		if err := addAuditPolicy(componentOpts, k8s.AuditPolicy); err != nil {
+			return nil, errors.Wrap(err, "audit policy")
+		}
+	}
+	if k8s.SecretsEncryption != "" {
+		if err := addSecretsEncryption(componentOpts); err != nil {
+			return nil, errors.Wrap(err, "secrets encryption")
+		}
+	}
 
 	cnm, err := cni.New(&cc)
 	if err != nil {
@@ -133,6 +143,8 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		ResolvConfSearchRegression bool
 		KubeletConfigOpts          map[string]string
 		PrependCriSocketUnix       bool
+		PatchesDir                 string
+		PatchesChecksum            string
 	}{
 		CertDir:           vmpath.GuestKubernetesCertsDir,
 		ServiceCIDR:       constants.DefaultServiceCIDR,
@@ -187,15 +199,40 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		opts.PrependCriSocketUnix = true
 	}
 
+	if k8s.KubeadmPatches != "" {
+		if err := ValidateKubeadmPatches(k8s.KubeadmPatches, k8s.KubernetesVersion); err != nil {
+			return nil, errors.Wrap(err, "kubeadm patches")
+		}
+		patches, err := LoadKubeadmPatches(k8s.KubeadmPatches)
+		if err != nil {
+			return nil, errors.Wrap(err, "kubeadm patches")
+		}
+		// the checksum changes the kubeadm config when the patches change, so that the control plane is reconfigured on restart
+		opts.PatchesDir = KubeadmPatchesDir
+		opts.PatchesChecksum = kubeadmPatchesChecksum(patches)
+	}
+
 	klog.Infof("kubeadm options: %+v", opts)
 
 	b := bytes.Buffer{}
 	if err := configTmpl.Execute(&b, opts); err != nil {
 		return nil, err
 	}
-	klog.Infof("kubeadm config:\n%s\n", b.String())
+	kubeadmCfg := b.Bytes()
+
+	if len(k8s.KubeletConfiguration) > 0 {
+		// the fields unknown to the catalog are reported by start, and left for the kubelet to decide on
+		if _, _, err := ValidateKubeletConfiguration(k8s.KubeletConfiguration, k8s.KubernetesVersion); err != nil {
+			return nil, errors.Wrap(err, "kubelet configuration")
+		}
+		kubeadmCfg, err = mergeKubeletConfiguration(kubeadmCfg, k8s.KubeletConfiguration)
+		if err != nil {
+			return nil, errors.Wrap(err, "kubelet configuration")
+		}
+	}
+	klog.Infof("kubeadm config:\n%s\n", kubeadmCfg)
 
-	return b.Bytes(), nil
+	return kubeadmCfg, nil
 }
 
 // These are the components that can be configured
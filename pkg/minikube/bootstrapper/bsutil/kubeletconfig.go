@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bsutil will eventually be renamed to kubeadm package after getting rid of older one
+package bsutil
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kcatalog"
+	"k8s.io/minikube/pkg/util"
+)
+
+const (
+	kubeletConfigAPIVersion = "kubelet.config.k8s.io/v1beta1"
+	kubeletConfigKind       = "KubeletConfiguration"
+)
+
+// kubeletConfigManaged are the fields minikube sets from the cluster configuration, which may not be overridden
+var kubeletConfigManaged = map[string]string{
+	"staticPodPath": "minikube runs the control plane from its own static pod path",
+	"cgroupDriver":  "the cgroup driver must match the one of the container runtime",
+}
+
+// LoadKubeletConfiguration returns the KubeletConfiguration fields in a YAML file,
+// either a whole KubeletConfiguration or only the fields to override
+func LoadKubeletConfiguration(file string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading kubelet configuration")
+	}
+	var raw map[interface{}]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", file)
+	}
+	kc, ok := normalizeYAML(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not a KubeletConfiguration", file)
+	}
+	if v, ok := kc["apiVersion"]; ok && v != kubeletConfigAPIVersion {
+		return nil, fmt.Errorf("%s: unsupported apiVersion %v, only %s is supported", file, v, kubeletConfigAPIVersion)
+	}
+	if k, ok := kc["kind"]; ok && k != kubeletConfigKind {
+		return nil, fmt.Errorf("%s: kind is %v, not %s", file, k, kubeletConfigKind)
+	}
+	delete(kc, "apiVersion")
+	delete(kc, "kind")
+	return kc, nil
+}
+
+// normalizeYAML converts the maps decoded from YAML to maps with string keys, which can be stored as JSON,
+// and whole numbers decoded from JSON to integers
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := map[string]interface{}{}
+		for k, val := range v {
+			m[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return m
+	case map[string]interface{}:
+		m := map[string]interface{}{}
+		for k, val := range v {
+			m[k] = normalizeYAML(val)
+		}
+		return m
+	case []interface{}:
+		l := []interface{}{}
+		for _, val := range v {
+			l = append(l, normalizeYAML(val))
+		}
+		return l
+	case float64:
+		// whole numbers are decoded from the JSON of the cluster config as floats, which YAML would print in exponent notation
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// ValidateKubeletConfiguration returns an error if KubeletConfiguration fields are managed by minikube, and the problems of the fields
+// unknown to the catalog of a Kubernetes version, or not supported by it.
+// The problems are only likely for Kubernetes versions newer than the catalog, which approximate reports.
+func ValidateKubeletConfiguration(kc map[string]interface{}, k8sVersion string) (problems []error, approximate bool, err error) {
+	version, err := util.ParseKubernetesVersion(k8sVersion)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "parsing Kubernetes version")
+	}
+	fields := []string{}
+	for f := range kc {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		if why, ok := kubeletConfigManaged[f]; ok {
+			return nil, false, fmt.Errorf("KubeletConfiguration field %q cannot be overridden: %s", f, why)
+		}
+	}
+	c, ok := kcatalog.ForVersion(version)
+	if !ok {
+		klog.Infof("not validating the KubeletConfiguration fields of Kubernetes %s, which is older than the catalog", k8sVersion)
+		return nil, false, nil
+	}
+	for _, f := range fields {
+		if err := c.CheckKubeletConfigField(f); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return problems, c.Approximate, nil
+}
+
+// mergeKubeletConfiguration merges KubeletConfiguration fields into the KubeletConfiguration document of a kubeadm config.
+// Maps are merged recursively, any other value replaces the generated one.
+func mergeKubeletConfiguration(kubeadmCfg []byte, kc map[string]interface{}) ([]byte, error) {
+	docs := strings.Split(string(kubeadmCfg), "\n---\n")
+	for i, doc := range docs {
+		if !strings.Contains(doc, "\nkind: "+kubeletConfigKind+"\n") {
+			continue
+		}
+		var raw map[interface{}]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &raw); err != nil {
+			return nil, errors.Wrap(err, "parsing generated kubelet configuration")
+		}
+		merged := mergeMaps(normalizeYAML(raw).(map[string]interface{}), normalizeYAML(kc).(map[string]interface{}))
+		b, err := yaml.Marshal(merged)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling kubelet configuration")
+		}
+		docs[i] = strings.TrimSuffix(string(b), "\n")
+		return []byte(strings.Join(docs, "\n---\n")), nil
+	}
+	return nil, fmt.Errorf("no %s in the kubeadm config", kubeletConfigKind)
+}
+
+// KubeletConfigChanged returns whether the KubeletConfiguration of a kubeadm config differs from the one of the
+// previous kubeadm config, which the kubelet-config ConfigMap of the cluster was uploaded from
+func KubeletConfigChanged(previous, kubeadmCfg []byte) bool {
+	return kubeletConfigDocument(previous) != kubeletConfigDocument(kubeadmCfg)
+}
+
+// kubeletConfigDocument returns the KubeletConfiguration document of a kubeadm config
+func kubeletConfigDocument(kubeadmCfg []byte) string {
+	for _, doc := range strings.Split(string(kubeadmCfg), "\n---\n") {
+		if strings.Contains(doc, "\nkind: "+kubeletConfigKind+"\n") {
+			return strings.TrimSpace(doc)
+		}
+	}
+	return ""
+}
+
+// mergeMaps returns a copy of dst with the values of src merged in recursively
+func mergeMaps(dst, src map[string]interface{}) map[string]interface{} {
+	out := map[string]interface{}{}
+	for k, v := range dst {
+		out[k] = v
+	}
+	for k, v := range src {
+		sm, sok := v.(map[string]interface{})
+		dm, dok := out[k].(map[string]interface{})
+		if sok && dok {
+			out[k] = mergeMaps(dm, sm)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
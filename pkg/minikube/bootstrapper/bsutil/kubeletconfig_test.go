@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+func TestLoadKubeletConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:    "fields",
+			content: "maxPods: 250\nevictionHard:\n  memory.available: 100Mi\n",
+			want:    map[string]interface{}{"maxPods": 250, "evictionHard": map[string]interface{}{"memory.available": "100Mi"}},
+		},
+		{
+			name:    "document",
+			content: "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeletConfiguration\nserializeImagePulls: false\n",
+			want:    map[string]interface{}{"serializeImagePulls": false},
+		},
+		{name: "other-kind", content: "apiVersion: kubelet.config.k8s.io/v1beta1\nkind: KubeProxyConfiguration\n", wantErr: true},
+		{name: "other-version", content: "apiVersion: kubelet.config.k8s.io/v1alpha1\nkind: KubeletConfiguration\n", wantErr: true},
+		{name: "not-a-map", content: "- maxPods\n", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "kubelet.yaml")
+			if err := os.WriteFile(file, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadKubeletConfiguration(file)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("LoadKubeletConfiguration() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("LoadKubeletConfiguration() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateKubeletConfiguration(t *testing.T) {
+	tests := []struct {
+		name         string
+		kc           map[string]interface{}
+		version      string
+		wantProblems int
+		approximate  bool
+		wantErr      bool
+	}{
+		{"known", map[string]interface{}{"maxPods": 250, "serializeImagePulls": false}, "v1.28.0", 0, false, false},
+		{"new-field", map[string]interface{}{"failCgroupV1": true}, "v1.31.0", 0, false, false},
+		{"too-new-field", map[string]interface{}{"failCgroupV1": true}, "v1.30.0", 1, false, false},
+		{"unknown", map[string]interface{}{"maxPod": 250, "evictionHrad": map[string]interface{}{}}, "v1.31.0", 2, false, false},
+		{"newer-than-catalog", map[string]interface{}{"someFutureField": true}, "v1.99.0", 1, true, false},
+		{"older-than-catalog", map[string]interface{}{"maxPod": 250}, "v1.20.0", 0, false, false},
+		{"managed", map[string]interface{}{"cgroupDriver": "cgroupfs"}, "v1.31.0", 0, false, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			problems, approximate, err := ValidateKubeletConfiguration(tc.kc, tc.version)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ValidateKubeletConfiguration() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if len(problems) != tc.wantProblems || approximate != tc.approximate {
+				t.Errorf("ValidateKubeletConfiguration() = %v, approximate %v, want %d problems, approximate %v", problems, approximate, tc.wantProblems, tc.approximate)
+			}
+		})
+	}
+}
+
+func TestGenerateKubeadmYAMLKubeletConfiguration(t *testing.T) {
+	fcr := command.NewFakeCommandRunner()
+	fcr.SetCommandToOutput(map[string]string{
+		"docker info --format {{.CgroupDriver}}": "systemd\n",
+	})
+	r, err := cruntime.New(cruntime.Config{Type: "docker", Runner: fcr, Socket: "/var/run/dockershim.sock"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+
+	// the cluster config is stored as JSON, so use the fields as they are loaded from it
+	var kc map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"maxPods": 1000000, "evictionHard": {"memory.available": "100Mi"}, "failSwapOn": true}`), &kc); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.ClusterConfig{
+		Name: "mk",
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion:    "v1.31.0",
+			ClusterName:          "kubernetes",
+			KubeletConfiguration: kc,
+		},
+		Nodes: []config.Node{{IP: "1.1.1.1", Name: "mk", ControlPlane: true}},
+	}
+	got, err := GenerateKubeadmYAML(cfg, cfg.Nodes[0], r)
+	if err != nil {
+		t.Fatalf("GenerateKubeadmYAML() = %v", err)
+	}
+
+	docs := strings.Split(string(got), "\n---\n")
+	if len(docs) != 4 {
+		t.Fatalf("expected 4 documents in the kubeadm config, got %d:\n%s", len(docs), got)
+	}
+	var kubelet map[string]interface{}
+	if err := yaml.Unmarshal([]byte(docs[2]), &kubelet); err != nil {
+		t.Fatalf("parsing the kubelet configuration: %v\n%s", err, docs[2])
+	}
+	if kubelet["kind"] != "KubeletConfiguration" || kubelet["cgroupDriver"] != "systemd" {
+		t.Errorf("expected the generated kubelet configuration to be kept:\n%s", docs[2])
+	}
+	if kubelet["maxPods"] != 1000000 || kubelet["failSwapOn"] != true {
+		t.Errorf("expected the kubelet configuration to be overridden:\n%s", docs[2])
+	}
+	evictionHard, ok := kubelet["evictionHard"].(map[interface{}]interface{})
+	if !ok || evictionHard["memory.available"] != "100Mi" || evictionHard["nodefs.available"] != "0%" {
+		t.Errorf("expected evictionHard to be merged with the generated one:\n%s", docs[2])
+	}
+	if !strings.HasPrefix(docs[3], "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\n") {
+		t.Errorf("expected the other documents to be kept:\n%s", docs[3])
+	}
+}
+
+func TestKubeletConfigChanged(t *testing.T) {
+	fcr := command.NewFakeCommandRunner()
+	fcr.SetCommandToOutput(map[string]string{
+		"docker info --format {{.CgroupDriver}}": "systemd\n",
+	})
+	r, err := cruntime.New(cruntime.Config{Type: "docker", Runner: fcr, Socket: "/var/run/dockershim.sock"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+	cfg := config.ClusterConfig{
+		Name: "mk",
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.31.0",
+			ClusterName:       "kubernetes",
+		},
+		Nodes: []config.Node{{IP: "1.1.1.1", Name: "mk", ControlPlane: true}},
+	}
+	generate := func(kc map[string]interface{}, nodeIP string) []byte {
+		cfg.KubernetesConfig.KubeletConfiguration = kc
+		cfg.Nodes[0].IP = nodeIP
+		b, err := GenerateKubeadmYAML(cfg, cfg.Nodes[0], r)
+		if err != nil {
+			t.Fatalf("GenerateKubeadmYAML() = %v", err)
+		}
+		return b
+	}
+
+	def := generate(nil, "1.1.1.1")
+	custom := generate(map[string]interface{}{"maxPods": 250}, "1.1.1.1")
+	tests := []struct {
+		name     string
+		previous []byte
+		next     []byte
+		want     bool
+	}{
+		{"unchanged default", def, generate(nil, "1.1.1.1"), false},
+		{"other documents changed", def, generate(nil, "2.2.2.2"), false},
+		{"custom config added", def, custom, true},
+		{"custom config changed", custom, generate(map[string]interface{}{"maxPods": 300}, "1.1.1.1"), true},
+		{"custom config removed", custom, def, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := KubeletConfigChanged(tc.previous, tc.next); got != tc.want {
+				t.Errorf("KubeletConfigChanged() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
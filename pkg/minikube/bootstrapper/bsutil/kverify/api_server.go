@@ -87,6 +87,10 @@ func APIServerPID(cr command.Runner) (int, error) {
 func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client *kubernetes.Clientset, start time.Time, hostname string, port int, timeout time.Duration) error {
 	klog.Infof("waiting for apiserver healthz status ...")
 	hStart := time.Now()
+	ca, err := clusterCACert(cfg.Name)
+	if err != nil {
+		return errors.Wrap(err, "ca certificate")
+	}
 
 	healthz := func(_ context.Context) (bool, error) {
 		if time.Since(start) > timeout {
@@ -98,7 +102,7 @@ func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, c
 			time.Sleep(kconst.APICallRetryInterval * 5)
 		}
 
-		status, err := apiServerHealthzNow(hostname, port)
+		status, err := apiServerHealthzNow(ca, hostname, port)
 		if err != nil {
 			klog.Warningf("status: %v", err)
 			return false, nil
@@ -148,11 +152,15 @@ func APIServerVersionMatch(client *kubernetes.Clientset, expected string) error
 // WaitForAPIServerStatus waits for 'to' duration to get apiserver pod running or stopped
 // this functions is intended to use in situations where apiserver process can be recreated
 // by container runtime restart for example and there is a gap before it comes back
-func WaitForAPIServerStatus(cr command.Runner, to time.Duration, hostname string, port int) (state.State, error) {
+func WaitForAPIServerStatus(cr command.Runner, to time.Duration, profile string, hostname string, port int) (state.State, error) {
+	ca, err := clusterCACert(profile)
+	if err != nil {
+		return state.Stopped, errors.Wrap(err, "ca certificate")
+	}
 	var st state.State
-	err := wait.PollUntilContextTimeout(context.Background(), 500*time.Millisecond, to, true, func(_ context.Context) (bool, error) {
+	err = wait.PollUntilContextTimeout(context.Background(), 500*time.Millisecond, to, true, func(_ context.Context) (bool, error) {
 		var err error
-		st, err = APIServerStatus(cr, hostname, port)
+		st, err = apiServerStatus(cr, ca, hostname, port)
 		if st == state.Stopped {
 			return false, nil
 		}
@@ -161,8 +169,19 @@ func WaitForAPIServerStatus(cr command.Runner, to time.Duration, hostname string
 	return st, err
 }
 
-// APIServerStatus returns apiserver status in libmachine style state.State
-func APIServerStatus(cr command.Runner, hostname string, port int) (state.State, error) {
+// APIServerStatus returns apiserver status in libmachine style state.State, verifying it with the CA of the cluster
+// of a profile
+func APIServerStatus(cr command.Runner, profile string, hostname string, port int) (state.State, error) {
+	ca, err := clusterCACert(profile)
+	if err != nil {
+		klog.Infof("ca certificate: %v", err)
+		return state.Stopped, err
+	}
+	return apiServerStatus(cr, ca, hostname, port)
+}
+
+// apiServerStatus returns apiserver status in libmachine style state.State
+func apiServerStatus(cr command.Runner, ca []byte, hostname string, port int) (state.State, error) {
 	klog.Infof("Checking apiserver status ...")
 
 	pid, err := APIServerPID(cr)
@@ -175,7 +194,7 @@ func APIServerStatus(cr command.Runner, hostname string, port int) (state.State,
 	rr, err := cr.RunCmd(exec.Command("sudo", "egrep", "^[0-9]+:freezer:", fmt.Sprintf("/proc/%d/cgroup", pid)))
 	if err != nil {
 		klog.Warningf("unable to find freezer cgroup: %v", err)
-		return nonFreezerServerStatus(cr, hostname, port)
+		return nonFreezerServerStatus(cr, ca, hostname, port)
 
 	}
 	freezer := strings.TrimSpace(rr.Stdout.String())
@@ -183,7 +202,7 @@ func APIServerStatus(cr command.Runner, hostname string, port int) (state.State,
 	fparts := strings.Split(freezer, ":")
 	if len(fparts) != 3 {
 		klog.Warningf("unable to parse freezer - found %d parts: %s", len(fparts), freezer)
-		return nonFreezerServerStatus(cr, hostname, port)
+		return nonFreezerServerStatus(cr, ca, hostname, port)
 	}
 
 	rr, err = cr.RunCmd(exec.Command("sudo", "cat", path.Join("/sys/fs/cgroup/freezer", fparts[2], "freezer.state")))
@@ -197,7 +216,7 @@ func APIServerStatus(cr command.Runner, hostname string, port int) (state.State,
 			klog.Warningf("unable to get freezer state: %s", rr.Stderr.String())
 		}
 
-		return nonFreezerServerStatus(cr, hostname, port)
+		return nonFreezerServerStatus(cr, ca, hostname, port)
 	}
 
 	fs := strings.TrimSpace(rr.Stdout.String())
@@ -205,11 +224,11 @@ func APIServerStatus(cr command.Runner, hostname string, port int) (state.State,
 	if fs == "FREEZING" || fs == "FROZEN" {
 		return state.Paused, nil
 	}
-	return apiServerHealthz(hostname, port)
+	return apiServerHealthz(ca, hostname, port)
 }
 
 // nonFreezerServerStatus is the alternative flow if the guest does not have the freezer cgroup so different methods to detect the apiserver status are used
-func nonFreezerServerStatus(cr command.Runner, hostname string, port int) (state.State, error) {
+func nonFreezerServerStatus(cr command.Runner, ca []byte, hostname string, port int) (state.State, error) {
 	rr, err := cr.RunCmd(exec.Command("ls"))
 	if err != nil {
 		return state.None, err
@@ -217,17 +236,17 @@ func nonFreezerServerStatus(cr command.Runner, hostname string, port int) (state
 	if strings.Contains(rr.Stdout.String(), "paused") {
 		return state.Paused, nil
 	}
-	return apiServerHealthz(hostname, port)
+	return apiServerHealthz(ca, hostname, port)
 }
 
 // apiServerHealthz checks apiserver in a patient and tolerant manner
-func apiServerHealthz(hostname string, port int) (state.State, error) {
+func apiServerHealthz(ca []byte, hostname string, port int) (state.State, error) {
 	var st state.State
 	var err error
 
 	check := func() error {
 		// etcd gets upset sometimes and causes healthz to report a failure. Be tolerant of it.
-		st, err = apiServerHealthzNow(hostname, port)
+		st, err = apiServerHealthzNow(ca, hostname, port)
 		if err != nil {
 			return err
 		}
@@ -247,17 +266,18 @@ func apiServerHealthz(hostname string, port int) (state.State, error) {
 	return st, err
 }
 
+// clusterCACert returns the CA certificate of the cluster of a profile: the CA it was started with, or the shared
+// minikubeCA
+func clusterCACert(profile string) ([]byte, error) {
+	return os.ReadFile(localpath.ProfileCACert(profile))
+}
+
 // apiServerHealthzNow hits the /healthz endpoint and returns libmachine style state.State
-func apiServerHealthzNow(hostname string, port int) (state.State, error) {
+func apiServerHealthzNow(ca []byte, hostname string, port int) (state.State, error) {
 	url := fmt.Sprintf("https://%s/healthz", net.JoinHostPort(hostname, fmt.Sprint(port)))
 	klog.Infof("Checking apiserver healthz at %s ...", url)
-	cert, err := os.ReadFile(localpath.CACert())
-	if err != nil {
-		klog.Infof("ca certificate: %v", err)
-		return state.Stopped, err
-	}
 	pool := x509.NewCertPool()
-	pool.AppendCertsFromPEM(cert)
+	pool.AppendCertsFromPEM(ca)
 	tr := &http.Transport{
 		Proxy:           nil, // Avoid using a proxy to speak to a local host
 		TLSClientConfig: &tls.Config{RootCAs: pool},
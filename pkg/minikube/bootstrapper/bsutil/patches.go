@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bsutil will eventually be renamed to kubeadm package after getting rid of older one
+package bsutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+// KubeadmPatchesDir is where the kubeadm patches of a cluster are copied to on every node
+var KubeadmPatchesDir = path.Join(vmpath.GuestPersistentDir, "kubeadm-patches")
+
+// patchFileRe matches the names of the patch files kubeadm applies: target[suffix][+patchtype].extension
+// ref: https://kubernetes.io/docs/setup/production-environment/tools/kubeadm/control-plane-flags/#patches
+var patchFileRe = regexp.MustCompile(`^(etcd|kube-apiserver|kube-controller-manager|kube-scheduler|kubeletconfiguration)[^+.]*(\+(strategic|merge|json))?\.(json|yaml)$`)
+
+var (
+	// minKubeadmPatchesVersion is the first version with the patches field in the kubeadm configuration
+	minKubeadmPatchesVersion = semver.MustParse("1.23.0")
+	// minKubeletPatchesVersion is the first version kubeadm patches the KubeletConfiguration in
+	minKubeletPatchesVersion = semver.MustParse("1.25.0")
+)
+
+// LoadKubeadmPatches returns the content of the kubeadm patch files in a host directory, by file name
+func LoadKubeadmPatches(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading kubeadm patches")
+	}
+	patches := map[string][]byte{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if !patchFileRe.MatchString(e.Name()) {
+			return nil, fmt.Errorf("%s is not a kubeadm patch: patch files are named <component>[suffix][+strategic|merge|json].{yaml|json}, where component is one of etcd, kube-apiserver, kube-controller-manager, kube-scheduler or kubeletconfiguration", e.Name())
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, "reading kubeadm patch")
+		}
+		patches[e.Name()] = data
+	}
+	return patches, nil
+}
+
+// ValidateKubeadmPatches returns an error if a host directory does not contain kubeadm patches for a Kubernetes version
+func ValidateKubeadmPatches(dir string, k8sVersion string) error {
+	version, err := util.ParseKubernetesVersion(k8sVersion)
+	if err != nil {
+		return errors.Wrap(err, "parsing Kubernetes version")
+	}
+	if version.LT(minKubeadmPatchesVersion) {
+		return fmt.Errorf("kubeadm patches require Kubernetes v%s or later", minKubeadmPatchesVersion)
+	}
+	patches, err := LoadKubeadmPatches(dir)
+	if err != nil {
+		return err
+	}
+	if len(patches) == 0 {
+		return fmt.Errorf("no kubeadm patches in %s", dir)
+	}
+	for name := range patches {
+		if patchFileRe.FindStringSubmatch(name)[1] == "kubeletconfiguration" && version.LT(minKubeletPatchesVersion) {
+			return fmt.Errorf("%s: patches of the kubelet configuration require Kubernetes v%s or later", name, minKubeletPatchesVersion)
+		}
+	}
+	return nil
+}
+
+// kubeadmPatchesChecksum returns a checksum of kubeadm patches, so that a change to them changes the kubeadm configuration
+func kubeadmPatchesChecksum(patches map[string][]byte) string {
+	names := []string{}
+	for name := range patches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(patches[name]))
+		h.Write(patches[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// KubeadmPatchesAssets returns the kubeadm patches in a host directory, as files to copy to KubeadmPatchesDir
+func KubeadmPatchesAssets(dir string) ([]assets.CopyableFile, error) {
+	patches, err := LoadKubeadmPatches(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []assets.CopyableFile{}
+	for name, data := range patches {
+		files = append(files, assets.NewMemoryAssetTarget(data, path.Join(KubeadmPatchesDir, name), "0644"))
+	}
+	return files, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+func writePatches(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestValidateKubeadmPatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		version string
+		wantErr bool
+	}{
+		{"apiserver", map[string]string{"kube-apiserver.yaml": "{}"}, "v1.31.0", false},
+		{"types", map[string]string{"etcd0+json.json": "[]", "kube-scheduler+merge.yaml": "{}", "kube-controller-manager+strategic.yaml": "{}"}, "v1.23.0", false},
+		{"kubelet", map[string]string{"kubeletconfiguration.yaml": "{}"}, "v1.25.0", false},
+		{"kubelet-too-old", map[string]string{"kubeletconfiguration.yaml": "{}"}, "v1.24.0", true},
+		{"too-old", map[string]string{"kube-apiserver.yaml": "{}"}, "v1.22.0", true},
+		{"unknown-target", map[string]string{"coredns.yaml": "{}"}, "v1.31.0", true},
+		{"unknown-type", map[string]string{"kube-apiserver+xml.yaml": "{}"}, "v1.31.0", true},
+		{"empty", map[string]string{}, "v1.31.0", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateKubeadmPatches(writePatches(t, tc.files), tc.version)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateKubeadmPatches() = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateKubeadmYAMLPatches(t *testing.T) {
+	fcr := command.NewFakeCommandRunner()
+	fcr.SetCommandToOutput(map[string]string{
+		"docker info --format {{.CgroupDriver}}": "systemd\n",
+	})
+	r, err := cruntime.New(cruntime.Config{Type: "docker", Runner: fcr, Socket: "/var/run/dockershim.sock"})
+	if err != nil {
+		t.Fatalf("runtime: %v", err)
+	}
+	dir := writePatches(t, map[string]string{"kube-apiserver.yaml": "spec: {}\n"})
+	cfg := config.ClusterConfig{
+		Name: "mk",
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.31.0",
+			ClusterName:       "kubernetes",
+			KubeadmPatches:    dir,
+		},
+		Nodes: []config.Node{{IP: "1.1.1.1", Name: "mk", ControlPlane: true}},
+	}
+
+	got, err := GenerateKubeadmYAML(cfg, cfg.Nodes[0], r)
+	if err != nil {
+		t.Fatalf("GenerateKubeadmYAML() = %v", err)
+	}
+	if !strings.Contains(string(got), "patches:\n  directory: "+KubeadmPatchesDir+"\n") {
+		t.Errorf("expected the patches directory in the kubeadm config:\n%s", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "kube-apiserver.yaml"), []byte("spec:\n  priority: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := GenerateKubeadmYAML(cfg, cfg.Nodes[0], r)
+	if err != nil {
+		t.Fatalf("GenerateKubeadmYAML() = %v", err)
+	}
+	if string(changed) == string(got) {
+		t.Errorf("expected the kubeadm config to change with the patches")
+	}
+}
@@ -68,7 +68,7 @@ func SetupCerts(k8s config.ClusterConfig, n config.Node, pcpCmd command.Runner,
 	localPath := localpath.Profile(k8s.KubernetesConfig.ClusterName)
 	klog.Infof("Setting up %s for IP: %s", localPath, n.IP)
 
-	sharedCerts, regen, err := generateSharedCACerts()
+	sharedCerts, regen, err := generateSharedCACerts(k8s.KubernetesConfig)
 	if err != nil {
 		return errors.Wrap(err, "generate shared ca certs")
 	}
@@ -107,7 +107,7 @@ func SetupCerts(k8s config.ClusterConfig, n config.Node, pcpCmd command.Runner,
 		copyableFiles = append(copyableFiles, certFile)
 	}
 
-	caCerts, err := collectCACerts()
+	caCerts, err := collectCACerts(sharedCerts.caCert)
 	if err != nil {
 		return errors.Wrap(err, "collect ca certs")
 	}
@@ -191,7 +191,9 @@ func SetupCerts(k8s config.ClusterConfig, n config.Node, pcpCmd command.Runner,
 }
 
 // generateSharedCACerts generates minikube Root CA and Proxy Client CA certs, but only if missing or expired.
-func generateSharedCACerts() (sharedCACerts, bool, error) {
+// The Root CA of the cluster is the one supplied with --ca-cert and --ca-key instead, if any, which is installed in
+// the profile directory and leaves the shared minikube Root CA alone.
+func generateSharedCACerts(k8s config.KubernetesConfig) (sharedCACerts, bool, error) {
 	klog.Info("generating shared ca certs ...")
 
 	regenProfileCerts := false
@@ -220,18 +222,23 @@ func generateSharedCACerts() (sharedCACerts, bool, error) {
 		},
 	}
 
-	// create a lock for "ca-certs" to avoid race condition over multiple minikube instances rewriting ca certs
-	hold := filepath.Join(globalPath, "ca-certs")
-	spec := lock.PathMutexSpec(hold)
-	spec.Timeout = 1 * time.Minute
-	klog.Infof("acquiring lock for ca certs: %+v", spec)
-	releaser, err := mutex.Acquire(spec)
+	releaser, err := lockSharedCACerts()
 	if err != nil {
-		return cc, false, errors.Wrapf(err, "acquire lock for ca certs %+v", spec)
+		return cc, false, err
 	}
 	defer releaser.Release()
 
 	for _, ca := range caCertSpecs {
+		if ca.certPath == cc.caCert && k8s.CACert != "" {
+			changed, err := installCustomCA(k8s.ClusterName, k8s.CACert, k8s.CAKey)
+			if err != nil {
+				return cc, false, errors.Wrap(err, "install custom ca cert")
+			}
+			regenProfileCerts = regenProfileCerts || changed
+			cc.caCert, cc.caKey = localpath.ProfileCACert(k8s.ClusterName), localpath.ProfileCAKey(k8s.ClusterName)
+			continue
+		}
+
 		if isValid(ca.certPath, ca.keyPath) {
 			klog.Infof("skipping valid %q ca cert: %s", ca.subject, ca.keyPath)
 			continue
@@ -247,6 +254,18 @@ func generateSharedCACerts() (sharedCACerts, bool, error) {
 	return cc, regenProfileCerts, nil
 }
 
+// lockSharedCACerts creates a lock for "ca-certs" to avoid race condition over multiple minikube instances rewriting ca certs
+func lockSharedCACerts() (mutex.Releaser, error) {
+	spec := lock.PathMutexSpec(filepath.Join(localpath.MiniPath(), "ca-certs"))
+	spec.Timeout = 1 * time.Minute
+	klog.Infof("acquiring lock for ca certs: %+v", spec)
+	releaser, err := mutex.Acquire(spec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "acquire lock for ca certs %+v", spec)
+	}
+	return releaser, nil
+}
+
 // generateProfileCerts generates certs for a profile, but only if missing, expired or needs regenerating.
 func generateProfileCerts(cfg config.ClusterConfig, n config.Node, shared sharedCACerts, regen bool) ([]string, error) {
 	// Only generate these certs for the api server
@@ -356,7 +375,8 @@ func generateProfileCerts(cfg config.ClusterConfig, n config.Node, shared shared
 			kp = kp + "." + spec.hash
 		}
 
-		if !regen && isValid(cp, kp) {
+		// the shared CA may have been replaced since by another profile
+		if !regen && isValid(cp, kp) && isSignedBy(cp, spec.caCertPath) {
 			klog.Infof("skipping valid signed profile cert regeneration for %q: %s", spec.subject, kp)
 			continue
 		}
@@ -452,8 +472,8 @@ func isValidPEMCertificate(filePath string) (bool, error) {
 // collectCACerts looks up all public pem certificates with .crt or .pem extension
 // in ~/.minikube/certs or ~/.minikube/files/etc/ssl/certs
 // to copy them to the vmpath.GuestCertAuthDir ("/usr/share/ca-certificates") in host.
-// minikube root CA is also included but libmachine certificates (ca.pem/cert.pem) are excluded.
-func collectCACerts() (map[string]string, error) {
+// The CA of the cluster is also included but libmachine certificates (ca.pem/cert.pem) are excluded.
+func collectCACerts(clusterCA string) (map[string]string, error) {
 	localPath := localpath.MiniPath()
 	// note: certFiles map's key is user os' path, whereas map's value is kic/iso (linux) path
 	certFiles := map[string]string{}
@@ -507,8 +527,8 @@ func collectCACerts() (map[string]string, error) {
 		}
 	}
 
-	// include minikube CA
-	certFiles[localpath.CACert()] = path.Join(vmpath.GuestCertAuthDir, "minikubeCA.pem")
+	// include the CA of the cluster
+	certFiles[clusterCA] = path.Join(vmpath.GuestCertAuthDir, "minikubeCA.pem")
 
 	filtered := map[string]string{}
 	for k, v := range certFiles {
@@ -0,0 +1,355 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapper
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/sysinit"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/util"
+	"k8s.io/minikube/pkg/util/retry"
+)
+
+const (
+	// kubeletKubeconfig is the kubeconfig of the kubelet, whose client certificate is rotated by the kubelet itself
+	kubeletKubeconfig = "/etc/kubernetes/kubelet.conf"
+	// kubeletPKIDir holds the client certificates the kubelet rotates
+	kubeletPKIDir = "/var/lib/kubelet/pki"
+	// controlPlaneRestartTimeout is how long the control plane may take to be ready again after a restart
+	controlPlaneRestartTimeout = 3 * time.Minute
+)
+
+// controlPlaneComponents are the static pods restarted to load renewed certificates
+var controlPlaneComponents = []string{"etcd", "kube-apiserver", "kube-controller-manager", "kube-scheduler"}
+
+// CertExpiry is the expiration of a certificate
+type CertExpiry struct {
+	// Location is "host" for the certificates of the host, or the machine name of a node
+	Location string
+	Path     string
+	Expires  time.Time
+}
+
+// HostCertExpiries returns the expiration of the certificates of the host used by a profile
+func HostCertExpiries(profile string) ([]CertExpiry, error) {
+	paths := []string{
+		localpath.ProfileCACert(profile),
+		filepath.Join(localpath.MiniPath(), "proxy-client-ca.crt"),
+		localpath.ClientCert(profile),
+		filepath.Join(localpath.Profile(profile), "apiserver.crt"),
+		filepath.Join(localpath.Profile(profile), "proxy-client.crt"),
+	}
+	users, err := filepath.Glob(filepath.Join(localpath.Profile(profile), "users", "*.crt"))
+	if err != nil {
+		return nil, err
+	}
+	paths = append(paths, users...)
+
+	expiries := []CertExpiry{}
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading certificate")
+		}
+		cert, err := parseCert(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", p)
+		}
+		expiries = append(expiries, CertExpiry{Location: "host", Path: p, Expires: cert.NotAfter})
+	}
+	return expiries, nil
+}
+
+// parseCert parses the first certificate of PEM data
+func parseCert(data []byte) (*x509.Certificate, error) {
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+		data = rest
+	}
+}
+
+// nodeCertsScript prints the path and end date of the certificates of a node, including the client certificates
+// embedded in the kubeconfigs of the control plane
+var nodeCertsScript = fmt.Sprintf(`for f in $(find %[1]s %[2]s \( -name '*.crt' -o -name kubelet-client-current.pem \) 2>/dev/null | sort); do
+  echo "$f	$(openssl x509 -noout -enddate -in "$f")"
+done
+for f in $(ls /etc/kubernetes/*.conf 2>/dev/null); do
+  d=$(sed -n 's/^ *client-certificate-data: *//p' "$f")
+  [ -n "$d" ] && echo "$f	$(echo "$d" | base64 -d | openssl x509 -noout -enddate)"
+done
+true`, vmpath.GuestKubernetesCertsDir, kubeletPKIDir)
+
+// NodeCertExpiries returns the expiration of the certificates of a node
+func NodeCertExpiries(r command.Runner) ([]CertExpiry, error) {
+	rr, err := r.RunCmd(exec.Command("sudo", "/bin/bash", "-c", nodeCertsScript))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing certificates")
+	}
+	return parseEndDates(rr.Stdout.String())
+}
+
+// parseEndDates parses the "<path>\tnotAfter=<date>" lines of the openssl end dates of certificates
+func parseEndDates(output string) ([]CertExpiry, error) {
+	expiries := []CertExpiry{}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		p, date, ok := strings.Cut(line, "\t")
+		date, found := strings.CutPrefix(strings.TrimSpace(date), "notAfter=")
+		if !ok || !found {
+			return nil, fmt.Errorf("unexpected certificate end date %q", line)
+		}
+		expires, err := time.Parse("Jan _2 15:04:05 2006 MST", strings.Join(strings.Fields(date), " "))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing the end date of %s", p)
+		}
+		expiries = append(expiries, CertExpiry{Path: p, Expires: expires})
+	}
+	return expiries, nil
+}
+
+// RotateSharedCACerts generates new minikube Root CA and Proxy Client CA certs, which are shared among profiles.
+// The Root CA supplied with --ca-cert and --ca-key is installed again in the profile directory instead, and the shared one is left alone.
+func RotateSharedCACerts(k8s config.KubernetesConfig) error {
+	releaser, err := lockSharedCACerts()
+	if err != nil {
+		return err
+	}
+	defer releaser.Release()
+
+	globalPath := localpath.MiniPath()
+	for _, ca := range []struct{ name, subject string }{{"ca", "minikubeCA"}, {"proxy-client-ca", "proxyClientCA"}} {
+		if ca.name == "ca" && k8s.CACert != "" {
+			if _, err := installCustomCA(k8s.ClusterName, k8s.CACert, k8s.CAKey); err != nil {
+				return errors.Wrap(err, "install custom ca cert")
+			}
+			continue
+		}
+		certPath, keyPath := filepath.Join(globalPath, ca.name+".crt"), filepath.Join(globalPath, ca.name+".key")
+		// GenerateCACert always generates a new key
+		if err := util.GenerateCACert(certPath, keyPath, ca.subject); err != nil {
+			return errors.Wrapf(err, "generate %q ca cert: %s", ca.subject, keyPath)
+		}
+	}
+	return nil
+}
+
+// RemoveProfileCerts removes the certs of a profile, so that the next SetupCerts generates them again
+func RemoveProfileCerts(profile string) error {
+	profilePath := localpath.Profile(profile)
+	// the apiserver cert and key also have copies suffixed with the hash of their names
+	patterns := []string{"client.crt", "client.key", "apiserver.crt*", "apiserver.key*", "proxy-client.crt", "proxy-client.key"}
+	for _, p := range patterns {
+		matches, err := filepath.Glob(filepath.Join(profilePath, p))
+		if err != nil {
+			return err
+		}
+		for _, m := range matches {
+			klog.Infof("removing %s", m)
+			if err := os.Remove(m); err != nil {
+				return errors.Wrap(err, "removing profile cert")
+			}
+		}
+	}
+	return nil
+}
+
+// NodeCAChanged returns whether the cluster CA of a node differs from the one of the profile on the host
+func NodeCAChanged(profile string, r command.Runner) (bool, error) {
+	hostCA, err := os.ReadFile(localpath.ProfileCACert(profile))
+	if err != nil {
+		return false, errors.Wrap(err, "reading ca cert")
+	}
+	rr, err := r.RunCmd(exec.Command("sudo", "cat", path.Join(vmpath.GuestKubernetesCertsDir, "ca.crt")))
+	if err != nil {
+		return false, errors.Wrap(err, "reading node ca cert")
+	}
+	return !bytes.Equal(bytes.TrimSpace(hostCA), bytes.TrimSpace(rr.Stdout.Bytes())), nil
+}
+
+// RenewKubeadmCerts renews the certificates kubeadm generated on a control-plane node, including the ones of the
+// kubeconfigs of the control plane, with the CAs of the node. The kubeadm config of the primary control-plane node
+// is passed, as the other nodes have none.
+func RenewKubeadmCerts(r command.Runner, cc config.ClusterConfig, kubeadmCfg []byte) error {
+	cfgPath := path.Join(vmpath.GuestEphemeralDir, "kubeadm-certs.yaml")
+	if err := r.Copy(assets.NewMemoryAssetTarget(kubeadmCfg, cfgPath, "0640")); err != nil {
+		return errors.Wrap(err, "copying kubeadm config")
+	}
+	defer func() {
+		if _, err := r.RunCmd(exec.Command("sudo", "rm", "-f", cfgPath)); err != nil {
+			klog.Warningf("unable to remove %s: %v", cfgPath, err)
+		}
+	}()
+	bashCmd := fmt.Sprintf("%s certs renew all --config %s", bsutil.KubeadmCmdWithPath(cc.KubernetesConfig.KubernetesVersion), cfgPath)
+	if _, err := r.RunCmd(exec.Command("sudo", "/bin/bash", "-c", bashCmd)); err != nil {
+		return errors.Wrap(err, "kubeadm certs renew")
+	}
+	return nil
+}
+
+// KubeadmConfig returns the kubeadm config of the primary control-plane node
+func KubeadmConfig(r command.Runner) ([]byte, error) {
+	rr, err := r.RunCmd(exec.Command("sudo", "cat", constants.KubeadmYamlPath))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading kubeadm config")
+	}
+	return rr.Stdout.Bytes(), nil
+}
+
+// RenewKubeletCert replaces the client certificate of the kubelet of a node with one signed by the cluster CA of the
+// host, for the kubelet cannot rotate its certificate once the CA changed, and restarts the kubelet
+func RenewKubeletCert(r command.Runner, cc config.ClusterConfig, n config.Node) error {
+	rr, err := r.RunCmd(exec.Command("sudo", "cat", kubeletKubeconfig))
+	if err != nil {
+		return errors.Wrap(err, "reading kubelet kubeconfig")
+	}
+	current, err := clientcmd.Load(rr.Stdout.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "parsing kubelet kubeconfig")
+	}
+	server := ""
+	if ctx, ok := current.Contexts[current.CurrentContext]; ok {
+		if cluster, ok := current.Clusters[ctx.Cluster]; ok {
+			server = cluster.Server
+		}
+	}
+	if server == "" {
+		return fmt.Errorf("no server in %s", kubeletKubeconfig)
+	}
+
+	data, err := kubeletKubeconfigData(cc.Name, config.MachineName(cc, n), server, cc.CertExpiration)
+	if err != nil {
+		return err
+	}
+	if err := r.Copy(assets.NewMemoryAssetTarget(data, kubeletKubeconfig, "0600")); err != nil {
+		return errors.Wrap(err, "copying kubelet kubeconfig")
+	}
+	// the kubelet prefers its rotated certificates to the one of its kubeconfig
+	if _, err := r.RunCmd(exec.Command("sudo", "/bin/bash", "-c", fmt.Sprintf("rm -f %s/kubelet-client-*.pem", kubeletPKIDir))); err != nil {
+		return errors.Wrap(err, "removing kubelet client certs")
+	}
+	return sysinit.New(r).Restart("kubelet")
+}
+
+// kubeletKubeconfigData returns a kubelet kubeconfig embedding a client certificate for a node, signed by the cluster
+// CA of the profile on the host
+func kubeletKubeconfigData(profile, nodeName, server string, expiration time.Duration) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "kubelet-cert")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	certPath, keyPath := filepath.Join(dir, "kubelet.crt"), filepath.Join(dir, "kubelet.key")
+	if err := util.GenerateClientCert(certPath, keyPath, "system:node:"+nodeName, []string{"system:nodes"}, localpath.ProfileCACert(profile), localpath.ProfileCAKey(profile), expiration); err != nil {
+		return nil, errors.Wrap(err, "generating kubelet client cert")
+	}
+
+	kcfg := api.NewConfig()
+	cluster := api.NewCluster()
+	cluster.Server = server
+	if cluster.CertificateAuthorityData, err = os.ReadFile(localpath.ProfileCACert(profile)); err != nil {
+		return nil, err
+	}
+	user := api.NewAuthInfo()
+	if user.ClientCertificateData, err = os.ReadFile(certPath); err != nil {
+		return nil, err
+	}
+	if user.ClientKeyData, err = os.ReadFile(keyPath); err != nil {
+		return nil, err
+	}
+	context := api.NewContext()
+	context.Cluster = "kubernetes"
+	context.AuthInfo = "system:node:" + nodeName
+	kcfg.Clusters["kubernetes"] = cluster
+	kcfg.AuthInfos[context.AuthInfo] = user
+	kcfg.Contexts["default"] = context
+	kcfg.CurrentContext = "default"
+	return clientcmd.Write(*kcfg)
+}
+
+// RestartControlPlane stops the control plane static pods of a node, which the kubelet restarts with their renewed
+// certificates, and waits for the apiserver to be ready again
+func RestartControlPlane(r command.Runner, cr cruntime.Manager, cc config.ClusterConfig, n config.Node) error {
+	ids := []string{}
+	for _, name := range controlPlaneComponents {
+		found, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Running, Name: name})
+		if err != nil {
+			return errors.Wrapf(err, "listing %s containers", name)
+		}
+		ids = append(ids, found...)
+	}
+	sort.Strings(ids)
+	if err := cr.StopContainers(ids); err != nil {
+		return errors.Wrap(err, "stopping control plane")
+	}
+
+	kubectl := path.Join(vmpath.GuestPersistentDir, "binaries", cc.KubernetesConfig.KubernetesVersion, "kubectl")
+	ready := func() error {
+		_, err := r.RunCmd(exec.Command("sudo", kubectl, fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")),
+			fmt.Sprintf("--server=https://localhost:%d", n.Port), "get", "--raw=/readyz"))
+		return err
+	}
+	if err := retry.Expo(ready, time.Second, controlPlaneRestartTimeout); err != nil {
+		return errors.Wrap(err, "waiting for the control plane to restart")
+	}
+	return nil
+}
+
+// RestartSystemWorkloads restarts the deployments and daemon sets of kube-system, whose pods cached the previous
+// cluster CA, using the kubectl of the control plane node runner
+func RestartSystemWorkloads(r command.Runner, cc config.ClusterConfig) error {
+	kubectl := path.Join(vmpath.GuestPersistentDir, "binaries", cc.KubernetesConfig.KubernetesVersion, "kubectl")
+	rr, err := r.RunCmd(exec.Command("sudo", kubectl, fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")),
+		"rollout", "restart", "deployments,daemonsets", "--namespace=kube-system"))
+	if err != nil {
+		return errors.Wrapf(err, "cmd: %s output: %s", rr.Command(), rr.Output())
+	}
+	return nil
+}
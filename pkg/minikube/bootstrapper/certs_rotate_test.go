@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapper
+
+import (
+	"bytes"
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/tests"
+)
+
+func TestParseEndDates(t *testing.T) {
+	output := "/var/lib/minikube/certs/ca.crt\tnotAfter=Oct 17 10:04:05 2036 GMT\n" +
+		"/etc/kubernetes/admin.conf\tnotAfter=Jan  5 00:00:00 2027 GMT\n\n"
+	got, err := parseEndDates(output)
+	if err != nil {
+		t.Fatalf("parseEndDates: %v", err)
+	}
+	want := []CertExpiry{
+		{Path: "/var/lib/minikube/certs/ca.crt", Expires: time.Date(2036, time.October, 17, 10, 4, 5, 0, time.UTC)},
+		{Path: "/etc/kubernetes/admin.conf", Expires: time.Date(2027, time.January, 5, 0, 0, 0, 0, time.UTC)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseEndDates = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i].Path != want[i].Path || !got[i].Expires.Equal(want[i].Expires) {
+			t.Errorf("parseEndDates[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if _, err := parseEndDates("/etc/kubernetes/admin.conf\tunable to load certificate"); err == nil {
+		t.Errorf("parseEndDates of an openssl error = nil, want an error")
+	}
+}
+
+func TestRotateProfileCerts(t *testing.T) {
+	tests.MakeTempDir(t)
+	cc := config.ClusterConfig{
+		Name:           "p",
+		CertExpiration: constants.DefaultCertExpiration,
+		KubernetesConfig: config.KubernetesConfig{
+			ClusterName:   "p",
+			APIServerName: constants.APIServerName,
+			DNSDomain:     constants.ClusterDNSDomain,
+			ServiceCIDR:   constants.DefaultServiceCIDR,
+		},
+	}
+	shared, _, err := generateSharedCACerts(config.KubernetesConfig{})
+	if err != nil {
+		t.Fatalf("generateSharedCACerts: %v", err)
+	}
+	if _, err := generateProfileCerts(cc, config.Node{ControlPlane: true}, shared, false); err != nil {
+		t.Fatalf("generateProfileCerts: %v", err)
+	}
+
+	expiries, err := HostCertExpiries("p")
+	if err != nil {
+		t.Fatalf("HostCertExpiries: %v", err)
+	}
+	if len(expiries) != 5 {
+		t.Errorf("HostCertExpiries = %v, want the 2 CAs and 3 profile certs", expiries)
+	}
+
+	oldCA, err := os.ReadFile(localpath.CACert())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RotateSharedCACerts(config.KubernetesConfig{}); err != nil {
+		t.Fatalf("RotateSharedCACerts: %v", err)
+	}
+	newCA, err := os.ReadFile(localpath.CACert())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(oldCA, newCA) {
+		t.Errorf("RotateSharedCACerts did not change the CA")
+	}
+
+	if err := RemoveProfileCerts("p"); err != nil {
+		t.Fatalf("RemoveProfileCerts: %v", err)
+	}
+	left, err := filepath.Glob(filepath.Join(localpath.Profile("p"), "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 0 {
+		t.Errorf("RemoveProfileCerts left %v", left)
+	}
+}
+
+func TestKubeletKubeconfigData(t *testing.T) {
+	tests.MakeTempDir(t)
+	if _, _, err := generateSharedCACerts(config.KubernetesConfig{}); err != nil {
+		t.Fatalf("generateSharedCACerts: %v", err)
+	}
+
+	data, err := kubeletKubeconfigData("p", "p-m02", "https://control-plane.minikube.internal:8443", time.Hour)
+	if err != nil {
+		t.Fatalf("kubeletKubeconfigData: %v", err)
+	}
+	kcfg, err := clientcmd.Load(data)
+	if err != nil {
+		t.Fatalf("parsing kubeconfig: %v", err)
+	}
+	if server := kcfg.Clusters["kubernetes"].Server; server != "https://control-plane.minikube.internal:8443" {
+		t.Errorf("server = %q", server)
+	}
+	cert, err := parseCert(kcfg.AuthInfos["system:node:p-m02"].ClientCertificateData)
+	if err != nil {
+		t.Fatalf("parsing client cert: %v", err)
+	}
+	if cert.Subject.CommonName != "system:node:p-m02" || len(cert.Subject.Organization) != 1 || cert.Subject.Organization[0] != "system:nodes" {
+		t.Errorf("subject = %v, want the p-m02 node", cert.Subject)
+	}
+	ca, err := parseCert(kcfg.Clusters["kubernetes"].CertificateAuthorityData)
+	if err != nil {
+		t.Fatalf("parsing ca cert: %v", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}}); err != nil {
+		t.Errorf("client cert is not signed by the cluster CA: %v", err)
+	}
+}
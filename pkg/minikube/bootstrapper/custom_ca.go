@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapper
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+// IngressCertSecret is the "namespace/secret" of the default certificate of the ingress addon, issued by the CA
+// supplied with --ca-cert
+const IngressCertSecret = "kube-system/minikube-ingress-cert"
+
+// installCustomCA copies a CA certificate and key into the directory of a profile, where they replace the shared
+// minikubeCA for its cluster only, returning whether they changed
+func installCustomCA(profile, certPath, keyPath string) (bool, error) {
+	caCert, caKey := filepath.Join(localpath.Profile(profile), "ca.crt"), filepath.Join(localpath.Profile(profile), "ca.key")
+	cert, err := os.ReadFile(certPath)
+	if err != nil {
+		return false, errors.Wrap(err, "reading ca cert")
+	}
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return false, errors.Wrap(err, "reading ca key")
+	}
+	installedCert, _ := os.ReadFile(caCert)
+	installedKey, _ := os.ReadFile(caKey)
+	if bytes.Equal(cert, installedCert) && bytes.Equal(key, installedKey) {
+		klog.Infof("skipping installed custom ca cert: %s", certPath)
+		return false, nil
+	}
+
+	klog.Infof("installing custom ca cert %s as %s", certPath, caCert)
+	if err := os.MkdirAll(localpath.Profile(profile), 0755); err != nil {
+		return false, errors.Wrap(err, "creating profile dir")
+	}
+	// the key first, as the cert decides whether the profile has its own CA
+	if err := os.WriteFile(caKey, key, 0600); err != nil {
+		return false, errors.Wrap(err, "writing ca key")
+	}
+	if err := os.WriteFile(caCert, cert, 0644); err != nil {
+		return false, errors.Wrap(err, "writing ca cert")
+	}
+	return true, nil
+}
+
+// InstallCustomCA installs the CA supplied with --ca-cert and --ca-key as the CA of a cluster, returning whether it changed
+func InstallCustomCA(k8s config.KubernetesConfig) (bool, error) {
+	return installCustomCA(k8s.ClusterName, k8s.CACert, k8s.CAKey)
+}
+
+// isSignedBy returns whether a cert was signed by the first certificate of a CA cert file
+func isSignedBy(certPath, caCertPath string) bool {
+	certs := []*x509.Certificate{}
+	for _, p := range []string{certPath, caCertPath} {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			klog.Infof("failed to read cert file %s: %v", p, err)
+			return false
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			klog.Infof("failed to decode cert file %s", p)
+			return false
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			klog.Infof("failed to parse cert file %s: %v", p, err)
+			return false
+		}
+		certs = append(certs, cert)
+	}
+	if err := certs[0].CheckSignatureFrom(certs[1]); err != nil {
+		klog.Infof("cert %s is not signed by %s: %v", certPath, caCertPath, err)
+		return false
+	}
+	return true
+}
+
+// GenerateIngressCert generates the default certificate of the ingress addon, signed by the CA of the cluster, but only
+// if missing, expired or signed by another CA. It is valid for localhost, the *.test domains of the ingress-dns
+// addon and the IPs of the nodes.
+func GenerateIngressCert(cc config.ClusterConfig) (string, string, error) {
+	certPath := filepath.Join(localpath.Profile(cc.Name), "ingress.crt")
+	keyPath := filepath.Join(localpath.Profile(cc.Name), "ingress.key")
+	if isValid(certPath, keyPath) && isSignedBy(certPath, localpath.ProfileCACert(cc.Name)) {
+		klog.Infof("skipping valid ingress cert regeneration: %s", certPath)
+		return certPath, keyPath, nil
+	}
+
+	ips := []net.IP{net.ParseIP("127.0.0.1")}
+	for _, n := range cc.Nodes {
+		if ip := net.ParseIP(n.IP); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	os.Remove(certPath)
+	os.Remove(keyPath)
+	if err := util.GenerateSignedCert(certPath, keyPath, "minikube-ingress", ips, []string{"localhost", "*.test"}, localpath.ProfileCACert(cc.Name), localpath.ProfileCAKey(cc.Name), cc.CertExpiration); err != nil {
+		return "", "", errors.Wrap(err, "generate ingress cert")
+	}
+	return certPath, keyPath, nil
+}
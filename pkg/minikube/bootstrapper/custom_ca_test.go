@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bootstrapper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/tests"
+	"k8s.io/minikube/pkg/util"
+)
+
+func TestCustomCA(t *testing.T) {
+	tests.MakeTempDir(t)
+	cc := config.ClusterConfig{
+		Name:           "p",
+		CertExpiration: constants.DefaultCertExpiration,
+		KubernetesConfig: config.KubernetesConfig{
+			ClusterName:   "p",
+			APIServerName: constants.APIServerName,
+			DNSDomain:     constants.ClusterDNSDomain,
+			ServiceCIDR:   constants.DefaultServiceCIDR,
+		},
+		Nodes: []config.Node{{ControlPlane: true, IP: "192.168.49.2"}},
+	}
+	// a profile created with the generated minikubeCA
+	shared, _, err := generateSharedCACerts(cc.KubernetesConfig)
+	if err != nil {
+		t.Fatalf("generateSharedCACerts: %v", err)
+	}
+	if _, err := generateProfileCerts(cc, cc.Nodes[0], shared, false); err != nil {
+		t.Fatalf("generateProfileCerts: %v", err)
+	}
+
+	dir := t.TempDir()
+	cc.KubernetesConfig.CACert, cc.KubernetesConfig.CAKey = filepath.Join(dir, "corp.crt"), filepath.Join(dir, "corp.key")
+	if err := util.GenerateCACert(cc.KubernetesConfig.CACert, cc.KubernetesConfig.CAKey, "corpCA"); err != nil {
+		t.Fatalf("GenerateCACert: %v", err)
+	}
+	if got := localpath.ProfileCACert("p"); got != localpath.CACert() {
+		t.Errorf("ProfileCACert before install = %s, want the shared %s", got, localpath.CACert())
+	}
+	sharedCA, err := os.ReadFile(localpath.CACert())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared, regen, err := generateSharedCACerts(cc.KubernetesConfig)
+	if err != nil {
+		t.Fatalf("generateSharedCACerts with a custom CA: %v", err)
+	}
+	if !regen {
+		t.Errorf("generateSharedCACerts with a new custom CA did not regenerate the profile certs")
+	}
+	if got, want := localpath.ProfileCACert("p"), filepath.Join(localpath.Profile("p"), "ca.crt"); got != want {
+		t.Errorf("ProfileCACert after install = %s, want %s", got, want)
+	}
+	if got := localpath.ProfileCACert("other"); got != localpath.CACert() {
+		t.Errorf("ProfileCACert of another profile = %s, want the shared %s", got, localpath.CACert())
+	}
+	// the other profiles keep the minikubeCA
+	if after, err := os.ReadFile(localpath.CACert()); err != nil || string(after) != string(sharedCA) {
+		t.Errorf("installing the custom CA changed the shared CA %s: %v", localpath.CACert(), err)
+	}
+	if _, regen, err := generateSharedCACerts(cc.KubernetesConfig); err != nil || regen {
+		t.Errorf("generateSharedCACerts with an installed custom CA = %v, %v, want no regeneration", regen, err)
+	}
+
+	// profile certs signed by the previous CA are regenerated even without regen
+	if _, err := generateProfileCerts(cc, cc.Nodes[0], shared, false); err != nil {
+		t.Fatalf("generateProfileCerts: %v", err)
+	}
+	for _, cert := range []string{localpath.ClientCert("p"), filepath.Join(localpath.Profile("p"), "apiserver.crt")} {
+		if !isSignedBy(cert, cc.KubernetesConfig.CACert) {
+			t.Errorf("%s is not signed by the custom CA", cert)
+		}
+	}
+
+	certPath, _, err := GenerateIngressCert(cc)
+	if err != nil {
+		t.Fatalf("GenerateIngressCert: %v", err)
+	}
+	if !isSignedBy(certPath, cc.KubernetesConfig.CACert) {
+		t.Errorf("the ingress cert is not signed by the custom CA")
+	}
+	before, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := GenerateIngressCert(cc); err != nil {
+		t.Fatalf("GenerateIngressCert: %v", err)
+	}
+	after, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(before) != string(after) {
+		t.Errorf("GenerateIngressCert regenerated a valid cert")
+	}
+}
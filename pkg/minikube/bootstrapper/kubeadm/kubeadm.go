@@ -57,6 +57,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/encryption"
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/out"
@@ -86,7 +87,7 @@ func NewBootstrapper(_ libmachine.API, cc config.ClusterConfig, r command.Runner
 
 // GetAPIServerStatus returns the api-server status
 func (k *Bootstrapper) GetAPIServerStatus(hostname string, port int) (string, error) {
-	s, err := kverify.APIServerStatus(k.c, hostname, port)
+	s, err := kverify.APIServerStatus(k.c, k.contextName, hostname, port)
 	if err != nil {
 		return state.Error.String(), err
 	}
@@ -655,6 +656,15 @@ func (k *Bootstrapper) restartPrimaryControlPlane(cfg config.ClusterConfig) erro
 
 	k.clearStaleConfigs(cfg)
 
+	// the other nodes get the kubelet configuration from the cluster when they rejoin it, so a changed one, including
+	// back to the default, must be uploaded again
+	uploadKubelet := true
+	if previous, err := k.c.RunCmd(exec.Command("sudo", "cat", conf)); err == nil {
+		if next, err := k.c.RunCmd(exec.Command("sudo", "cat", conf+".new")); err == nil {
+			uploadKubelet = bsutil.KubeletConfigChanged(previous.Stdout.Bytes(), next.Stdout.Bytes())
+		}
+	}
+
 	if _, err := k.c.RunCmd(exec.Command("sudo", "cp", conf+".new", conf)); err != nil {
 		return errors.Wrap(err, "cp")
 	}
@@ -693,6 +703,13 @@ func (k *Bootstrapper) restartPrimaryControlPlane(cfg config.ClusterConfig) erro
 		return errors.Wrap(err, "apiserver health")
 	}
 
+	if uploadKubelet {
+		uploadKubeletConfig := fmt.Sprintf("%s phase upload-config kubelet --config %s", baseCmd, conf)
+		if _, err := k.c.RunCmd(exec.Command("sudo", "/bin/bash", "-c", uploadKubeletConfig)); err != nil {
+			return errors.Wrap(err, "upload kubelet config")
+		}
+	}
+
 	// because reboots clear /etc/cni
 	if err := k.applyCNI(cfg); err != nil {
 		return errors.Wrap(err, "apply cni")
@@ -768,6 +785,10 @@ func (k *Bootstrapper) JoinCluster(cc config.ClusterConfig, n config.Node, joinC
 			" --apiserver-bind-port=" + strconv.Itoa(n.Port)
 	}
 
+	if cc.KubernetesConfig.KubeadmPatches != "" {
+		joinCmd += " --patches=" + bsutil.KubeadmPatchesDir
+	}
+
 	if _, err := k.c.RunCmd(exec.Command("sudo", "/bin/bash", "-c", joinCmd)); err != nil {
 		return errors.Wrapf(err, "kubeadm join")
 	}
@@ -962,6 +983,31 @@ func (k *Bootstrapper) UpdateNode(cfg config.ClusterConfig, n config.Node, r cru
 			}
 			files = append(files, assets.NewMemoryAssetTarget(kubeadmCfg, constants.KubeadmYamlPath+".new", "0640"))
 		}
+		// every control-plane node runs an apiserver, which loads the audit policy from its node
+		if _, err := k.c.RunCmd(exec.Command("sudo", "rm", "-rf", bsutil.AuditPolicyDir)); err != nil {
+			return errors.Wrap(err, "removing audit policy")
+		}
+		if cfg.KubernetesConfig.AuditPolicy != "" {
+			policy, err := bsutil.AuditPolicyAsset(cfg.KubernetesConfig.AuditPolicy)
+			if err != nil {
+				return errors.Wrap(err, "audit policy")
+			}
+			files = append(files, policy)
+		}
+		if _, err := k.c.RunCmd(exec.Command("sudo", "rm", "-rf", encryption.GuestDir)); err != nil {
+			return errors.Wrap(err, "removing encryption config")
+		}
+		if cfg.KubernetesConfig.SecretsEncryption != "" {
+			ec, err := encryption.Load(cfg.Name)
+			if err != nil {
+				return errors.Wrap(err, "secrets encryption")
+			}
+			f, err := ec.Asset()
+			if err != nil {
+				return errors.Wrap(err, "secrets encryption")
+			}
+			files = append(files, f)
+		}
 		// deploy kube-vip for ha (multi-control plane) cluster
 		if config.IsHA(cfg) {
 			// workaround for kube-vip
@@ -981,6 +1027,18 @@ func (k *Bootstrapper) UpdateNode(cfg config.ClusterConfig, n config.Node, r cru
 		}
 	}
 
+	// every node gets the kubeadm patches, as they also apply to the control plane and kubelet of joining nodes
+	if _, err := k.c.RunCmd(exec.Command("sudo", "rm", "-rf", bsutil.KubeadmPatchesDir)); err != nil {
+		return errors.Wrap(err, "removing kubeadm patches")
+	}
+	if cfg.KubernetesConfig.KubeadmPatches != "" {
+		patches, err := bsutil.KubeadmPatchesAssets(cfg.KubernetesConfig.KubeadmPatches)
+		if err != nil {
+			return errors.Wrap(err, "kubeadm patches")
+		}
+		files = append(files, patches...)
+	}
+
 	sm := sysinit.New(k.c)
 
 	if err := bsutil.TransferBinaries(cfg.KubernetesConfig, k.c, sm, cfg.BinaryMirror); err != nil {
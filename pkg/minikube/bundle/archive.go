@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// Create caches the artifacts of a bundle spec and writes them into a gzipped tar archive at dst
+func Create(spec Spec, dst string) (*Manifest, error) {
+	m, err := fetch(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := write(m, localpath.MiniPath(), dst); err != nil {
+		return nil, errors.Wrapf(err, "writing %s", dst)
+	}
+	return m, nil
+}
+
+// write writes the manifest, followed by the files it lists relative to home, into a gzipped tar archive
+func write(m *Manifest, home string, dst string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	gw := gzip.NewWriter(tmp)
+	tw := tar.NewWriter(gw)
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: ManifestName, Mode: 0644, Size: int64(len(data)), ModTime: m.Created}); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	for _, name := range m.Files {
+		if err := addFile(tw, filepath.Join(home, filepath.FromSlash(name)), name); err != nil {
+			tmp.Close()
+			return errors.Wrapf(err, "adding %s", name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+func addFile(tw *tar.Writer, src string, name string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(st, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// validName returns true if a bundle entry stays within the minikube cache directory
+func validName(name string) bool {
+	return path.Clean(name) == name && strings.HasPrefix(name, "cache/") && !strings.Contains(name, "..")
+}
+
+// Install seeds the minikube caches with the artifacts of a bundle
+func Install(src string) (*Manifest, error) {
+	return install(src, localpath.MiniPath())
+}
+
+func install(src string, home string) (*Manifest, error) {
+	f, err := os.Open(src)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "not a bundle")
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != ManifestName {
+		return nil, fmt.Errorf("not a bundle: %s is missing", ManifestName)
+	}
+	var m Manifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return nil, errors.Wrap(err, "decoding manifest")
+	}
+	if m.Arch != runtime.GOARCH {
+		return nil, fmt.Errorf("the bundle is for %s, not %s", m.Arch, runtime.GOARCH)
+	}
+	expected := map[string]bool{}
+	for _, name := range m.Files {
+		if !validName(name) {
+			return nil, fmt.Errorf("invalid bundle entry: %s", name)
+		}
+		expected[name] = true
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !expected[hdr.Name] || hdr.Typeflag != tar.TypeReg {
+			return nil, fmt.Errorf("unexpected bundle entry: %s", hdr.Name)
+		}
+		if err := extract(tr, filepath.Join(home, filepath.FromSlash(hdr.Name)), os.FileMode(hdr.Mode).Perm()); err != nil {
+			return nil, errors.Wrapf(err, "extracting %s", hdr.Name)
+		}
+		klog.Infof("Installed %s", hdr.Name)
+		delete(expected, hdr.Name)
+	}
+	if len(expected) > 0 {
+		return nil, fmt.Errorf("truncated bundle: %d entries are missing", len(expected))
+	}
+	return &m, nil
+}
+
+// extract writes an entry to dst, only replacing an existing file once it is complete
+func extract(r io.Reader, dst string, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filepath.Base(dst)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
@@ -0,0 +1,352 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bundle exports the artifacts needed to start a cluster into a single archive,
+// and seeds the local caches from it so that the cluster can be started offline
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/cni"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/image"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/version"
+)
+
+// ManifestName is the name of the manifest, the first entry of a bundle
+const ManifestName = "bundle.json"
+
+// Spec is what a bundle is created for
+type Spec struct {
+	KubernetesVersion string   `json:"kubernetesVersion"`
+	Driver            string   `json:"driver"`
+	ContainerRuntime  string   `json:"containerRuntime"`
+	CNI               string   `json:"cni,omitempty"`
+	Addons            []string `json:"addons"`
+}
+
+// Manifest describes the content of a bundle
+type Manifest struct {
+	Spec
+	MinikubeVersion string    `json:"minikubeVersion"`
+	Arch            string    `json:"arch"`
+	Created         time.Time `json:"created"`
+	// Files are the paths of the bundled artifacts, relative to the minikube home directory
+	Files []string `json:"files"`
+	// Images are the bundled container images, which are loaded into the nodes when starting offline
+	Images []string `json:"images"`
+}
+
+// MissingError is returned when artifacts needed to start offline are not cached
+type MissingError struct {
+	Artifacts []string
+}
+
+func (e *MissingError) Error() string {
+	return "not cached: " + strings.Join(e.Artifacts, ", ")
+}
+
+// ClusterConfig returns the cluster config a bundle spec describes, as far as its artifacts are concerned
+func ClusterConfig(spec Spec) *config.ClusterConfig {
+	cc := &config.ClusterConfig{
+		Name:         "bundle",
+		Driver:       spec.Driver,
+		KicBaseImage: kic.BaseImage,
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: spec.KubernetesVersion,
+			ContainerRuntime:  spec.ContainerRuntime,
+			CNI:               spec.CNI,
+		},
+		Addons: map[string]bool{},
+	}
+	for _, a := range spec.Addons {
+		cc.Addons[a] = true
+	}
+	return cc
+}
+
+// EnabledAddons returns the sorted names of the addons enabled in a cluster, including the default ones and additional ones
+func EnabledAddons(cc *config.ClusterConfig, additional []string) []string {
+	enabled := map[string]bool{}
+	for name, a := range assets.Addons {
+		if a.IsEnabledOrDefault(cc) {
+			enabled[name] = true
+		}
+	}
+	for _, name := range additional {
+		if _, ok := assets.Addons[name]; ok {
+			enabled[name] = true
+		}
+	}
+	names := []string{}
+	for name := range enabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Images returns the CNI and addon images a cluster runs, which are not part of its preload
+func Images(cc *config.ClusterConfig, addons []string) ([]string, error) {
+	cnm, err := cni.New(cc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cni")
+	}
+	imgs, err := cni.Images(cnm)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range addons {
+		a, ok := assets.Addons[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown addon: %s", name)
+		}
+		imgs = append(imgs, assets.AddonImages(a, cc)...)
+	}
+	return unique(imgs), nil
+}
+
+// preloadedImages returns the images the preload of a cluster contains, see hack/preload-images
+func preloadedImages(cc *config.ClusterConfig) map[string]bool {
+	preloaded := map[string]bool{}
+	imgs, err := images.Kubeadm(cc.KubernetesConfig.ImageRepository, cc.KubernetesConfig.KubernetesVersion)
+	if err != nil {
+		klog.Warningf("unable to get the kubeadm images: %v", err)
+	}
+	if cc.KubernetesConfig.ContainerRuntime != constants.Docker {
+		imgs = append(imgs, images.KindNet(cc.KubernetesConfig.ImageRepository))
+	}
+	for _, img := range imgs {
+		preloaded[img] = true
+	}
+	return preloaded
+}
+
+// imagePath returns where an image is saved in the image cache, the same as image.SaveToDir
+func imagePath(img string) string {
+	return localpath.SanitizeCacheDir(filepath.Join(detect.ImageCacheDir(), img))
+}
+
+// binaryPath returns where a Kubernetes binary for the nodes is cached, the same as download.Binary
+func binaryPath(binary, k8sVersion string) string {
+	return localpath.MakeMiniPath("cache", "linux", runtime.GOARCH, k8sVersion, binary)
+}
+
+// isoPaths returns where the ISO of each URL is cached
+func isoPaths(isoURLs []string) []string {
+	paths := []string{}
+	for _, u := range isoURLs {
+		paths = append(paths, strings.TrimPrefix(download.LocalISOResource(u), "file://"))
+	}
+	return paths
+}
+
+func cached(path string) bool {
+	st, err := os.Stat(path)
+	return err == nil && st.Size() > 0
+}
+
+// fetch caches the artifacts of a bundle spec, and returns the manifest of the bundle
+func fetch(spec Spec) (*Manifest, error) {
+	cc := ClusterConfig(spec)
+	m := &Manifest{
+		Spec:            spec,
+		MinikubeVersion: version.GetVersion(),
+		Arch:            runtime.GOARCH,
+		Created:         time.Now().UTC(),
+		Images:          []string{},
+	}
+	files := []string{}
+
+	switch {
+	case driver.IsKIC(spec.Driver):
+		out.Step(style.Pulling, "Caching base image {{.image}} ...", out.V{"image": kic.BaseImage})
+		if err := download.ImageToCache(kic.BaseImage); err != nil {
+			return nil, errors.Wrap(err, "caching kic base image")
+		}
+		files = append(files, download.ImagePathInCache(kic.BaseImage))
+	case driver.IsVM(spec.Driver) && !driver.IsSSH(spec.Driver):
+		u, err := download.ISO(download.DefaultISOURLs(), false)
+		if err != nil {
+			return nil, errors.Wrap(err, "caching ISO")
+		}
+		files = append(files, isoPaths([]string{u})...)
+	}
+
+	preload := download.PreloadExists(spec.KubernetesVersion, spec.ContainerRuntime, spec.Driver, true)
+	if preload {
+		if err := download.Preload(spec.KubernetesVersion, spec.ContainerRuntime, spec.Driver); err != nil {
+			return nil, errors.Wrap(err, "caching preload")
+		}
+		files = append(files, download.TarballPath(spec.KubernetesVersion, spec.ContainerRuntime))
+	}
+
+	out.Step(style.FileDownload, "Caching Kubernetes {{.version}} binaries ...", out.V{"version": spec.KubernetesVersion})
+	for _, b := range constants.KubernetesReleaseBinaries {
+		p, err := download.Binary(b, spec.KubernetesVersion, "linux", runtime.GOARCH, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "caching %s", b)
+		}
+		files = append(files, p)
+	}
+
+	imgs, err := Images(cc, spec.Addons)
+	if err != nil {
+		return nil, err
+	}
+	if !preload {
+		// without a preload, the Kubernetes images are loaded from the image cache
+		kimgs, err := images.Kubeadm("", spec.KubernetesVersion)
+		if err != nil {
+			return nil, errors.Wrap(err, "kubeadm images")
+		}
+		imgs = unique(append(kimgs, imgs...))
+	}
+	out.Step(style.Caching, "Caching {{.count}} images ...", out.V{"count": len(imgs)})
+	if err := image.SaveToDir(imgs, detect.ImageCacheDir(), false); err != nil {
+		return nil, errors.Wrap(err, "caching images")
+	}
+	for _, img := range imgs {
+		// images which do not exist were skipped with a warning
+		if p := imagePath(img); cached(p) {
+			files = append(files, p)
+			m.Images = append(m.Images, img)
+		}
+	}
+
+	for _, f := range files {
+		rel, err := filepath.Rel(localpath.MiniPath(), f)
+		if err != nil {
+			return nil, err
+		}
+		m.Files = append(m.Files, filepath.ToSlash(rel))
+	}
+	return m, nil
+}
+
+// CheckOffline returns a *MissingError if any artifact needed to start a cluster without network access is not cached
+func CheckOffline(cc *config.ClusterConfig, addons []string, isoURLs []string) error {
+	missing := []string{}
+
+	switch {
+	case driver.IsKIC(cc.Driver):
+		if !download.ImageExistsInCache(cc.KicBaseImage) && !(driver.IsDocker(cc.Driver) && download.ImageExistsInDaemon(cc.KicBaseImage)) {
+			missing = append(missing, "base image "+cc.KicBaseImage)
+		}
+	case driver.IsVM(cc.Driver) && !driver.IsSSH(cc.Driver):
+		found := false
+		for _, p := range isoPaths(isoURLs) {
+			if cached(p) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, "ISO "+strings.Join(isoURLs, " or "))
+		}
+	}
+
+	k8sVersion := cc.KubernetesConfig.KubernetesVersion
+	if k8sVersion == constants.NoKubernetesVersion {
+		return missingError(missing)
+	}
+
+	preload := download.PreloadExists(k8sVersion, cc.KubernetesConfig.ContainerRuntime, cc.Driver)
+	preloaded := map[string]bool{}
+	if preload {
+		preloaded = preloadedImages(cc)
+	} else {
+		for _, b := range constants.KubernetesReleaseBinaries {
+			if !cached(binaryPath(b, k8sVersion)) {
+				missing = append(missing, fmt.Sprintf("%s %s", b, k8sVersion))
+			}
+		}
+		kimgs, err := images.Kubeadm(cc.KubernetesConfig.ImageRepository, k8sVersion)
+		if err != nil {
+			return errors.Wrap(err, "kubeadm images")
+		}
+		for _, img := range kimgs {
+			if !cached(imagePath(img)) {
+				missing = append(missing, "image "+img)
+			}
+		}
+	}
+
+	imgs, err := Images(cc, addons)
+	if err != nil {
+		return err
+	}
+	for _, img := range imgs {
+		if !preloaded[img] && !cached(imagePath(img)) {
+			missing = append(missing, "image "+img)
+		}
+	}
+	return missingError(missing)
+}
+
+// CachedImages returns the CNI and addon images of a cluster which are in the image cache, to load them into its nodes
+func CachedImages(cc *config.ClusterConfig, addons []string) ([]string, error) {
+	imgs, err := Images(cc, addons)
+	if err != nil {
+		return nil, err
+	}
+	found := []string{}
+	for _, img := range imgs {
+		if cached(imagePath(img)) {
+			found = append(found, img)
+		}
+	}
+	return found, nil
+}
+
+func missingError(missing []string) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	return &MissingError{Artifacts: unique(missing)}
+}
+
+// unique returns the strings without duplicates, in order of first appearance
+func unique(list []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, s := range list {
+		if !seen[s] {
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func writeFiles(t *testing.T, home string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(home, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestArchive(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"cache/linux/amd64/v1.33.0/kubelet":             "kubelet",
+		"cache/images/amd64/registry.k8s.io/pause_3.10": "pause",
+	}
+	writeFiles(t, src, files)
+
+	m := &Manifest{
+		Spec:    Spec{KubernetesVersion: "v1.33.0", Driver: "none", ContainerRuntime: "containerd"},
+		Arch:    runtime.GOARCH,
+		Created: time.Now().UTC(),
+		Files:   []string{"cache/linux/amd64/v1.33.0/kubelet", "cache/images/amd64/registry.k8s.io/pause_3.10"},
+	}
+	archive := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := write(m, src, archive); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	dst := t.TempDir()
+	got, err := install(archive, dst)
+	if err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	if got.KubernetesVersion != "v1.33.0" || len(got.Files) != 2 {
+		t.Errorf("unexpected manifest %+v", got)
+	}
+	for name, content := range files {
+		b, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+		if err != nil || string(b) != content {
+			t.Errorf("%s = %q, %v; want %q", name, b, err, content)
+		}
+	}
+
+	t.Run("OtherArch", func(t *testing.T) {
+		other := *m
+		other.Arch = "s390x-" + runtime.GOARCH
+		archive := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		if err := write(&other, src, archive); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := install(archive, t.TempDir()); err == nil {
+			t.Errorf("expected a bundle for another architecture to be rejected")
+		}
+	})
+
+	t.Run("OutsideCache", func(t *testing.T) {
+		writeFiles(t, src, map[string]string{"config/config.json": "{}"})
+		bad := *m
+		bad.Files = []string{"cache/../config/config.json"}
+		archive := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		if err := write(&bad, src, archive); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := install(archive, t.TempDir()); err == nil {
+			t.Errorf("expected an entry outside of the cache to be rejected")
+		}
+	})
+}
+
+func TestCheckOffline(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv(localpath.MinikubeHome, home)
+
+	const k8sVersion = "v1.33.0"
+	cc := ClusterConfig(Spec{KubernetesVersion: k8sVersion, Driver: "none", ContainerRuntime: "containerd"})
+
+	err := CheckOffline(cc, nil, nil)
+	var missing *MissingError
+	if !errors.As(err, &missing) {
+		t.Fatalf("CheckOffline() = %v, want a *MissingError", err)
+	}
+	if !strings.Contains(err.Error(), "kubelet "+k8sVersion) {
+		t.Errorf("CheckOffline() = %v, want the kubelet binary to be missing", err)
+	}
+
+	kimgs, err := images.Kubeadm("", k8sVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range constants.KubernetesReleaseBinaries {
+		writeFiles(t, home, map[string]string{filepath.ToSlash(must(filepath.Rel(home, binaryPath(b, k8sVersion)))): b})
+	}
+	for _, img := range kimgs[1:] {
+		writeFiles(t, home, map[string]string{filepath.ToSlash(must(filepath.Rel(home, imagePath(img)))): img})
+	}
+	err = CheckOffline(cc, nil, nil)
+	if !errors.As(err, &missing) || len(missing.Artifacts) != 1 || missing.Artifacts[0] != "image "+kimgs[0] {
+		t.Fatalf("CheckOffline() = %v, want only %s to be missing", err, kimgs[0])
+	}
+
+	writeFiles(t, home, map[string]string{filepath.ToSlash(must(filepath.Rel(home, imagePath(kimgs[0])))): kimgs[0]})
+	if err := CheckOffline(cc, nil, nil); err != nil {
+		t.Errorf("CheckOffline() = %v, want nothing missing", err)
+	}
+}
+
+func must(s string, err error) string {
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
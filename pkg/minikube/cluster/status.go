@@ -459,7 +459,7 @@ func NodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*St
 		st.Kubeconfig = Misconfigured
 	}
 
-	sta, err := kverify.APIServerStatus(cr, hostname, port)
+	sta, err := kverify.APIServerStatus(cr, cc.Name, hostname, port)
 	klog.Infof("%s apiserver status = %s (err=%v)", name, stk, err)
 
 	if err != nil {
@@ -17,6 +17,7 @@ limitations under the License.
 package cni
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/minikube/pkg/minikube/config"
@@ -52,3 +53,72 @@ func TestChooseDefault(t *testing.T) {
 		}
 	}
 }
+
+func TestImages(t *testing.T) {
+	cc := config.ClusterConfig{
+		KubernetesConfig: config.KubernetesConfig{
+			ContainerRuntime:  "containerd",
+			KubernetesVersion: "v1.33.0",
+		},
+	}
+	tests := []struct {
+		cnm  Manager
+		want string
+	}{
+		{cnm: KindNet{cc: cc}, want: "kindnetd"},
+		{cnm: Calico{cc: cc}, want: "calico/node"},
+		{cnm: Flannel{cc: cc}, want: "flannel"},
+		{cnm: Cilium{cc: cc}, want: "cilium/cilium"},
+		{cnm: Bridge{cc: cc}},
+	}
+	for _, tc := range tests {
+		got, err := Images(tc.cnm)
+		if err != nil {
+			t.Fatalf("Images(%s) error: %v", tc.cnm, err)
+		}
+		if tc.want == "" {
+			if len(got) != 0 {
+				t.Errorf("Images(%s) = %v, want none", tc.cnm, got)
+			}
+			continue
+		}
+		found := false
+		for _, img := range got {
+			if strings.Contains(img, tc.want) {
+				found = true
+			}
+			if strings.ContainsAny(img, `"'{}`) {
+				t.Errorf("Images(%s) returned malformed image %q", tc.cnm, img)
+			}
+		}
+		if !found {
+			t.Errorf("Images(%s) = %v, want an image containing %q", tc.cnm, got, tc.want)
+		}
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	cc := config.ClusterConfig{
+		KubernetesConfig: config.KubernetesConfig{
+			ContainerRuntime:  "containerd",
+			KubernetesVersion: "v1.33.0",
+		},
+	}
+	tests := []struct {
+		cnm  Manager
+		want []string
+	}{
+		{cnm: KindNet{cc: cc}, want: []string{"kube-system"}},
+		{cnm: Flannel{cc: cc}, want: []string{"kube-flannel"}},
+		{cnm: Bridge{cc: cc}, want: []string{}},
+	}
+	for _, tc := range tests {
+		got, err := Namespaces(tc.cnm)
+		if err != nil {
+			t.Fatalf("Namespaces(%s) error: %v", tc.cnm, err)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("Namespaces(%s) = %v, want %v", tc.cnm, got, tc.want)
+		}
+	}
+}
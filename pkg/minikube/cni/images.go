@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+// imageRE matches the container images of a Kubernetes manifest
+var imageRE = regexp.MustCompile(`(?m)^\s*(?:-\s+)?image:\s*["']?([^"'\s]+)["']?\s*$`)
+
+// namespaceRE matches the namespaces of the objects of a Kubernetes manifest
+var namespaceRE = regexp.MustCompile(`(?m)^\s*namespace:\s*["']?([^"'\s]+)["']?\s*$`)
+
+// Images returns the container images the manifest of a CNI runs, so that they can be cached for offline use
+func Images(cnm Manager) ([]string, error) {
+	b, err := manifestOf(cnm)
+	if err != nil {
+		return nil, err
+	}
+	return manifestImages(b), nil
+}
+
+// Namespaces returns the namespaces the manifest of a CNI runs its pods in
+func Namespaces(cnm Manager) ([]string, error) {
+	b, err := manifestOf(cnm)
+	if err != nil {
+		return nil, err
+	}
+	nss := []string{}
+	seen := map[string]bool{}
+	for _, m := range namespaceRE.FindAllSubmatch(b, -1) {
+		ns := string(m[1])
+		if !seen[ns] {
+			seen[ns] = true
+			nss = append(nss, ns)
+		}
+	}
+	return nss, nil
+}
+
+// manifestOf returns the manifest of a CNI, or nothing for the CNIs without one
+func manifestOf(cnm Manager) ([]byte, error) {
+	var b []byte
+	var err error
+	switch c := cnm.(type) {
+	case KindNet:
+		b, err = readManifest(c.manifest())
+	case Calico:
+		b, err = readManifest(c.manifest())
+	case Flannel:
+		b, err = readManifest(c.manifest())
+	case Cilium:
+		b, err = c.GenerateCiliumYAML()
+	case Custom:
+		b, err = os.ReadFile(c.manifest)
+	default:
+		// bridge and disabled CNIs have no manifest
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s manifest", cnm)
+	}
+	return b, nil
+}
+
+func readManifest(f assets.CopyableFile, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// manifestImages returns the unique images referenced by a manifest, in order of appearance
+func manifestImages(b []byte) []string {
+	imgs := []string{}
+	seen := map[string]bool{}
+	for _, m := range imageRE.FindAllSubmatch(b, -1) {
+		img := string(m[1])
+		if !seen[img] {
+			seen[img] = true
+			imgs = append(imgs, img)
+		}
+	}
+	return imgs
+}
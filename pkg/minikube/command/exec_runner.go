@@ -184,6 +184,22 @@ func (e *execRunner) CopyFrom(f assets.CopyableFile) error {
 	src := path.Join(f.GetTargetDir(), f.GetTargetName())
 
 	dst := f.GetSourcePath()
+	if dst == assets.MemorySource {
+		// there is no file to copy into, stream the content instead
+		klog.Infof("cp: %s --> stream", src)
+		r, err := os.Open(src)
+		if err != nil {
+			if !e.sudo || !os.IsPermission(err) {
+				return err
+			}
+			cmd := exec.Command("sudo", "cat", src)
+			cmd.Stdout = f
+			return cmd.Run()
+		}
+		defer r.Close()
+		_, err = io.Copy(f, r)
+		return err
+	}
 	klog.Infof("cp: %s --> %s (%d bytes)", src, dst, f.GetLength())
 	if f.GetLength() == 0 {
 		klog.Warningf("0 byte asset: %+v", f)
@@ -213,6 +213,19 @@ func (k *kicRunner) CopyFrom(f assets.CopyableFile) error {
 	src := f.GetTargetPath()
 	dst := f.GetSourcePath()
 
+	if dst == assets.MemorySource {
+		// there is no file to copy into, stream the content instead
+		klog.Infof("%s (stream): %s", k.ociBin, src)
+		cmd := oci.PrefixCmd(exec.Command(k.ociBin, "exec", k.nameOrID, "sudo", "cat", src))
+		cmd.Stdout = f
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(err, "%s exec cat %s: %s", k.ociBin, src, stderr.String())
+		}
+		return nil
+	}
+
 	klog.Infof("%s (direct): %s --> %s", k.ociBin, src, dst)
 	return k.copyFrom(src, dst)
 }
@@ -48,6 +48,10 @@ const (
 	SkipAuditFlag = "skip-audit"
 	// Rootless is the key for the global rootless parameter (boolean)
 	Rootless = "rootless"
+	// ArtifactMirror is the key for the global artifact mirror parameter, the base URL all artifacts are downloaded from
+	ArtifactMirror = "artifact-mirror"
+	// DownloadSigningKey is the key for the global minisign public key downloads are verified against
+	DownloadSigningKey = "download-signing-key"
 	// AddonImages stores custom addon images config
 	AddonImages = "addon-images"
 	// AddonRegistries stores custom addon images config
@@ -58,6 +62,11 @@ const (
 	EmbedCerts = "EmbedCerts"
 	// MaxAuditEntries is the maximum number of audit entries to retain
 	MaxAuditEntries = "MaxAuditEntries"
+	// RegistryCacheMaxSize is the size cap of the host registry cache shared by all profiles
+	RegistryCacheMaxSize = "registry-cache-max-size"
+	// IssuerDomains is the comma separated list of domains the TLS secrets of Ingress objects are issued for, besides
+	// localhost and *.test
+	IssuerDomains = "issuer-domains"
 )
 
 var (
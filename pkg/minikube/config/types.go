@@ -109,6 +109,8 @@ type ClusterConfig struct {
 	SSHAgentPID             int
 	GPUs                    string
 	AutoPauseInterval       time.Duration // Specifies interval of time to wait before checking if cluster should be paused
+	RegistryCache           bool          // Pull Docker Hub images through the registry cache shared by all profiles
+	ImagePolicy             ImagePolicy
 }
 
 // KubernetesConfig contains the parameters used to configure the VM Kubernetes.
@@ -131,7 +133,14 @@ type KubernetesConfig struct {
 	LoadBalancerEndIP   string // currently only used by MetalLB addon
 	CustomIngressCert   string // used by Ingress addon
 	RegistryAliases     string // currently only used by registry-aliases addon
+	KubeadmPatches      string // host directory of kubeadm patches for the control-plane components and the kubelet
+	AuditPolicy         string // name of a built-in apiserver audit policy, or host path of an audit policy file
+	SecretsEncryption   string // provider encrypting secrets at rest, with the keys in the profile directory
+	CACert              string // host path of a CA certificate replacing the shared minikubeCA, optionally followed by its chain
+	CAKey               string // host path of the key of CACert
 	ExtraOptions        ExtraOptionSlice
+	// KubeletConfiguration holds fields merged into the generated KubeletConfiguration of every node
+	KubeletConfiguration map[string]interface{}
 
 	ShouldLoadCachedImages bool
 
@@ -175,6 +184,12 @@ type VersionedExtraOption struct {
 	GreaterThanOrEqual semver.Version
 }
 
+// ImagePolicy restricts the registries the images of the cluster may come from
+type ImagePolicy struct {
+	AllowedRegistries []string // If set, images may only come from these registries
+	DeniedRegistries  []string // Images may never come from these registries
+}
+
 // ScheduledStopConfig contains information around scheduled stop
 // not yet used, will be used to show status of scheduled stop
 type ScheduledStopConfig struct {
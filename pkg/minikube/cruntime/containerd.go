@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"net/url"
 	"os"
 	"os/exec"
@@ -64,6 +65,8 @@ type Containerd struct {
 	KubernetesVersion semver.Version
 	Init              sysinit.Manager
 	InsecureRegistry  []string
+	RegistryCache     string
+	ImagePolicy       ImagePolicy
 }
 
 // Name is a human readable name for containerd
@@ -249,6 +252,12 @@ func (r *Containerd) Enable(disOthers bool, cgroupDriver string, inUserNamespace
 	if err := generateContainerdConfig(r.Runner, r.ImageRepository, r.KubernetesVersion, cgroupDriver, r.InsecureRegistry, inUserNamespace); err != nil {
 		return err
 	}
+	if err := configureContainerdRegistryCache(r.Runner, r.RegistryCache); err != nil {
+		return err
+	}
+	if err := configureContainerdImagePolicy(r.Runner, r.ImagePolicy); err != nil {
+		return err
+	}
 	if err := enableIPForwarding(r.Runner); err != nil {
 		return err
 	}
@@ -290,6 +299,17 @@ func (r *Containerd) LoadImage(imagePath string) error {
 	return nil
 }
 
+// LoadImageStream loads an image archive read from rd into this runtime
+func (r *Containerd) LoadImageStream(rd io.Reader) error {
+	klog.Infof("Loading image from stream")
+	c := exec.Command("sudo", "ctr", "-n=k8s.io", "images", "import", "-")
+	c.Stdin = rd
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrapf(err, "ctr images import")
+	}
+	return nil
+}
+
 // PullImage pulls an image into this runtime
 func (r *Containerd) PullImage(name string) error {
 	return pullCRIImage(r.Runner, name)
@@ -372,7 +392,7 @@ func downloadRemote(cr CommandRunner, src string) (string, error) {
 }
 
 // BuildImage builds an image into this runtime
-func (r *Containerd) BuildImage(src string, file string, tag string, push bool, env []string, opts []string) error {
+func (r *Containerd) BuildImage(src string, file string, tag string, push bool, env []string, opts []string, platforms []string, cacheFrom string, cacheTo string) error {
 	// download url if not already present
 	dir, err := downloadRemote(r.Runner, src)
 	if err != nil {
@@ -408,6 +428,19 @@ func (r *Containerd) BuildImage(src string, file string, tag string, push bool,
 		"--local", fmt.Sprintf("context=%s", dir),
 		"--local", fmt.Sprintf("dockerfile=%s", dir),
 		"--output", fmt.Sprintf("type=image%s", extra)}
+	if len(platforms) > 0 {
+		if err := enableEmulation(r.Runner, platforms, ctrBinfmtCmds); err != nil {
+			return err
+		}
+		// buildkit produces a manifest list when given several platforms
+		args = append(args, "--opt", fmt.Sprintf("platform=%s", strings.Join(platforms, ",")))
+	}
+	if cacheFrom != "" {
+		args = append(args, "--import-cache", fmt.Sprintf("type=local,src=%s", cacheFrom))
+	}
+	if cacheTo != "" {
+		args = append(args, "--export-cache", fmt.Sprintf("type=local,dest=%s,mode=max", cacheTo))
+	}
 	for _, opt := range opts {
 		args = append(args, "--"+opt)
 	}
@@ -423,6 +456,15 @@ func (r *Containerd) BuildImage(src string, file string, tag string, push bool,
 	return nil
 }
 
+// ctrBinfmtCmds returns the commands running the binfmt installer with ctr. ctr takes everything after the
+// container ID as the full argv of the container, so the binary is passed explicitly.
+func ctrBinfmtCmds(image string, binfmtArgs ...string) []*exec.Cmd {
+	return []*exec.Cmd{
+		exec.Command("sudo", "ctr", "-n=k8s.io", "images", "pull", image),
+		exec.Command("sudo", append([]string{"ctr", "-n=k8s.io", "run", "--rm", "--privileged", image, "binfmt", "/usr/bin/binfmt"}, binfmtArgs...)...),
+	}
+}
+
 // PushImage pushes an image
 func (r *Containerd) PushImage(name string) error {
 	klog.Infof("Pushing image %s", name)
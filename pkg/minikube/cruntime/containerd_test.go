@@ -17,6 +17,7 @@ limitations under the License.
 package cruntime
 
 import (
+	"strings"
 	"testing"
 
 	"k8s.io/minikube/pkg/version"
@@ -62,3 +63,16 @@ func TestParseContainerdVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestCtrBinfmtCmds(t *testing.T) {
+	cmds := ctrBinfmtCmds(binfmtImage, "--install", "arm64")
+	if len(cmds) != 2 {
+		t.Fatalf("expected a pull and a run command, got %d", len(cmds))
+	}
+	got := strings.Join(cmds[1].Args, " ")
+	// everything after the container ID is the argv of the container
+	want := "sudo ctr -n=k8s.io run --rm --privileged " + binfmtImage + " binfmt /usr/bin/binfmt --install arm64"
+	if got != want {
+		t.Errorf("expected command %q but got %q", want, got)
+	}
+}
@@ -19,6 +19,7 @@ package cruntime
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path"
@@ -51,6 +52,8 @@ type CRIO struct {
 	ImageRepository   string
 	KubernetesVersion semver.Version
 	Init              sysinit.Manager
+	RegistryCache     string
+	ImagePolicy       ImagePolicy
 }
 
 // generateCRIOConfig sets up pause image and cgroup manager for cri-o in crioConfigFile
@@ -226,6 +229,12 @@ func (r *CRIO) Enable(disOthers bool, cgroupDriver string, inUserNamespace bool)
 	if err := generateCRIOConfig(r.Runner, r.ImageRepository, r.KubernetesVersion, cgroupDriver); err != nil {
 		return err
 	}
+	if err := configureCRIORegistryCache(r.Runner, r.RegistryCache); err != nil {
+		return err
+	}
+	if err := configureCRIOImagePolicy(r.Runner, r.ImagePolicy); err != nil {
+		return err
+	}
 	if err := enableIPForwarding(r.Runner); err != nil {
 		return err
 	}
@@ -280,6 +289,17 @@ func (r *CRIO) LoadImage(imgPath string) error {
 	return nil
 }
 
+// LoadImageStream loads an image archive read from rd into this runtime
+func (r *CRIO) LoadImageStream(rd io.Reader) error {
+	klog.Infof("Loading image from stream")
+	c := exec.Command("sudo", "podman", "load")
+	c.Stdin = rd
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "crio load image")
+	}
+	return nil
+}
+
 // PullImage pulls an image
 func (r *CRIO) PullImage(name string) error {
 	return pullCRIImage(r.Runner, name)
@@ -311,15 +331,31 @@ func (r *CRIO) TagImage(source string, target string) error {
 }
 
 // BuildImage builds an image into this runtime
-func (r *CRIO) BuildImage(src string, file string, tag string, push bool, env []string, opts []string) error {
+func (r *CRIO) BuildImage(src string, file string, tag string, push bool, env []string, opts []string, platforms []string, cacheFrom string, cacheTo string) error {
 	klog.Infof("Building image: %s", src)
+	if cacheFrom != "" || cacheTo != "" {
+		return fmt.Errorf("build cache import and export is not supported by the %s runtime", r.Name())
+	}
 	args := []string{"podman", "build"}
 	if file != "" {
 		args = append(args, "-f", file)
 	}
-	if tag != "" {
+	// podman stores the images of several platforms in a manifest list
+	manifest := tag != "" && len(platforms) > 1
+	if manifest {
+		args = append(args, "--manifest", tag)
+	} else if tag != "" {
 		args = append(args, "-t", tag)
 	}
+	if len(platforms) > 0 {
+		err := enableEmulation(r.Runner, platforms, func(image string, binfmtArgs ...string) []*exec.Cmd {
+			return []*exec.Cmd{exec.Command("sudo", append([]string{"podman", "run", "--rm", "--privileged", image}, binfmtArgs...)...)}
+		})
+		if err != nil {
+			return err
+		}
+		args = append(args, "--platform", strings.Join(platforms, ","))
+	}
 	args = append(args, src)
 	for _, opt := range opts {
 		args = append(args, "--"+opt)
@@ -336,6 +372,9 @@ func (r *CRIO) BuildImage(src string, file string, tag string, push bool, env []
 	}
 	if tag != "" && push {
 		c := exec.Command("sudo", "podman", "push", tag)
+		if manifest {
+			c = exec.Command("sudo", "podman", "manifest", "push", "--all", tag, "docker://"+tag)
+		}
 		c.Stdout = os.Stdout
 		c.Stderr = os.Stderr
 		if _, err := r.Runner.RunCmd(c); err != nil {
@@ -19,6 +19,7 @@ package cruntime
 
 import (
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 
@@ -100,10 +101,13 @@ type Manager interface {
 
 	// Load an image idempotently into the runtime on a host
 	LoadImage(string) error
+	// Load an image into the runtime on a host, reading the archive from a stream
+	LoadImageStream(io.Reader) error
 	// Pull an image to the runtime from the container registry
 	PullImage(string) error
-	// Build an image idempotently into the runtime on a host
-	BuildImage(string, string, string, bool, []string, []string) error
+	// Build an image idempotently into the runtime on a host, optionally for several platforms,
+	// importing and exporting the build cache from and to directories on the host
+	BuildImage(string, string, string, bool, []string, []string, []string, string, string) error
 	// Save an image from the runtime on a host
 	SaveImage(string, string) error
 	// Tag an image
@@ -157,6 +161,10 @@ type Config struct {
 	InsecureRegistry []string
 	// GPUs add GPU devices to the container
 	GPUs string
+	// RegistryCache is the address of the host registry cache to mirror docker.io to (optional)
+	RegistryCache string
+	// ImagePolicy restricts the registries images are pulled from (optional)
+	ImagePolicy ImagePolicy
 }
 
 // ListContainersOptions are the options to use for listing containers
@@ -230,6 +238,7 @@ func New(c Config) (Manager, error) {
 			UseCRI:            (sp != ""), // !dockershim
 			CRIService:        cs,
 			GPUs:              c.GPUs,
+			RegistryCache:     c.RegistryCache,
 		}, nil
 	case "crio", "cri-o":
 		return &CRIO{
@@ -238,6 +247,8 @@ func New(c Config) (Manager, error) {
 			ImageRepository:   c.ImageRepository,
 			KubernetesVersion: c.KubernetesVersion,
 			Init:              sm,
+			RegistryCache:     c.RegistryCache,
+			ImagePolicy:       c.ImagePolicy,
 		}, nil
 	case "containerd":
 		return &Containerd{
@@ -247,6 +258,8 @@ func New(c Config) (Manager, error) {
 			KubernetesVersion: c.KubernetesVersion,
 			Init:              sm,
 			InsecureRegistry:  c.InsecureRegistry,
+			RegistryCache:     c.RegistryCache,
+			ImagePolicy:       c.ImagePolicy,
 		}, nil
 	default:
 		return nil, fmt.Errorf("unknown runtime type: %q", c.Type)
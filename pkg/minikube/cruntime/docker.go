@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path"
@@ -50,6 +51,9 @@ const KubernetesContainerPrefix = "k8s_"
 const InternalDockerCRISocket = "/var/run/dockershim.sock"
 const ExternalDockerCRISocket = "/var/run/cri-dockerd.sock"
 
+// dockerCacheImage is the file in a build cache directory holding the image with inline cache metadata
+const dockerCacheImage = "image.tar"
+
 // ErrISOFeature is the error returned when disk image is missing features
 type ErrISOFeature struct {
 	missing string
@@ -77,6 +81,7 @@ type Docker struct {
 	UseCRI            bool
 	CRIService        string
 	GPUs              string
+	RegistryCache     string
 }
 
 // Name is a human readable name for Docker
@@ -310,6 +315,17 @@ func (r *Docker) LoadImage(imgPath string) error {
 	return nil
 }
 
+// LoadImageStream loads an image archive read from rd into this runtime
+func (r *Docker) LoadImageStream(rd io.Reader) error {
+	klog.Infof("Loading image from stream")
+	c := exec.Command("docker", "load")
+	c.Stdin = rd
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "loadimage docker")
+	}
+	return nil
+}
+
 // PullImage pulls an image
 func (r *Docker) PullImage(name string) error {
 	klog.Infof("Pulling image: %s", name)
@@ -357,9 +373,36 @@ func (r *Docker) TagImage(source string, target string) error {
 }
 
 // BuildImage builds an image into this runtime
-func (r *Docker) BuildImage(src string, file string, tag string, push bool, env []string, opts []string) error {
+func (r *Docker) BuildImage(src string, file string, tag string, push bool, env []string, opts []string, platforms []string, cacheFrom string, cacheTo string) error {
 	klog.Infof("Building image: %s", src)
+	if len(platforms) > 1 {
+		return fmt.Errorf("the docker runtime can only build for a single platform, use the containerd or cri-o runtime to build for %s", strings.Join(platforms, ","))
+	}
+	if (cacheFrom != "" || cacheTo != "") && tag == "" {
+		return fmt.Errorf("the docker runtime keeps the build cache in the image, a tag is required to import or export it")
+	}
 	args := []string{"build"}
+	if len(platforms) == 1 {
+		err := enableEmulation(r.Runner, platforms, func(image string, binfmtArgs ...string) []*exec.Cmd {
+			return []*exec.Cmd{exec.Command("docker", append([]string{"run", "--rm", "--privileged", image}, binfmtArgs...)...)}
+		})
+		if err != nil {
+			return err
+		}
+		args = append(args, "--platform", platforms[0])
+	}
+	if cacheFrom != "" {
+		// the cache is a previous build of the image, with inline cache metadata
+		img := path.Join(cacheFrom, dockerCacheImage)
+		c := exec.Command("/bin/bash", "-c", fmt.Sprintf("if sudo test -f %[1]s; then sudo cat %[1]s | docker load; fi", img))
+		if _, err := r.Runner.RunCmd(c); err != nil {
+			return errors.Wrap(err, "loading build cache")
+		}
+		args = append(args, "--cache-from", tag)
+	}
+	if cacheTo != "" {
+		args = append(args, "--build-arg", "BUILDKIT_INLINE_CACHE=1")
+	}
 	if file != "" {
 		args = append(args, "-f", file)
 	}
@@ -379,6 +422,13 @@ func (r *Docker) BuildImage(src string, file string, tag string, push bool, env
 	if _, err := r.Runner.RunCmd(c); err != nil {
 		return errors.Wrap(err, "buildimage docker")
 	}
+	if cacheTo != "" {
+		img := path.Join(cacheTo, dockerCacheImage)
+		c := exec.Command("/bin/bash", "-c", fmt.Sprintf("docker save %s | sudo tee %s >/dev/null", tag, img))
+		if _, err := r.Runner.RunCmd(c); err != nil {
+			return errors.Wrap(err, "saving build cache")
+		}
+	}
 	if tag != "" && push {
 		c := exec.Command("docker", "push", tag)
 		c.Stdout = os.Stdout
@@ -552,6 +602,7 @@ type dockerDaemonConfig struct {
 	LogDriver      string                `json:"log-driver"`
 	LogOpts        dockerDaemonLogOpts   `json:"log-opts"`
 	StorageDriver  string                `json:"storage-driver"`
+	Mirrors        []string              `json:"registry-mirrors,omitempty"`
 	DefaultRuntime string                `json:"default-runtime,omitempty"`
 	Runtimes       *dockerDaemonRuntimes `json:"runtimes,omitempty"`
 }
@@ -581,6 +632,10 @@ func (r *Docker) configureDocker(driver string) error {
 		},
 		StorageDriver: "overlay2",
 	}
+	if r.RegistryCache != "" {
+		klog.Infof("configuring docker to use the registry cache at %s", r.RegistryCache)
+		daemonConfig.Mirrors = []string{"http://" + r.RegistryCache}
+	}
 
 	switch r.GPUs {
 	case "all", "nvidia", "nvidia.com":
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cruntime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+// ImagePolicy restricts the registries a container runtime may pull images from
+type ImagePolicy struct {
+	// Allowed are the only registries images may be pulled from, unless empty
+	Allowed []string
+	// Denied are the registries images may never be pulled from
+	Denied []string
+}
+
+// IsEmpty returns whether the policy does not restrict anything
+func (p ImagePolicy) IsEmpty() bool {
+	return len(p.Allowed) == 0 && len(p.Denied) == 0
+}
+
+const (
+	// imagePolicyMarker marks the runtime config files written for the image policy
+	imagePolicyMarker = "# generated by minikube for the image policy"
+	// blockedRegistryDomain is the unresolvable domain blocked registries are redirected to
+	blockedRegistryDomain = "blocked-by-minikube-image-policy.invalid"
+
+	crioImagePolicyFile    = "/etc/crio/minikube-image-policy.json"
+	crioImagePolicyDropIn  = "/etc/crio/crio.conf.d/20-minikube-image-policy.conf"
+	crioImagePolicyConfTpl = `%s
+[crio.image]
+signature_policy = "%s"
+`
+)
+
+// registryServer returns the endpoint of a registry, as containerd expects it in hosts.toml
+func registryServer(registry string) string {
+	if registry == "docker.io" {
+		return "https://registry-1.docker.io"
+	}
+	return "https://" + registry
+}
+
+// containerdHostsFile returns the hosts.toml content for a registry under the image policy
+func containerdHostsFile(registry string, blocked bool) string {
+	server := registryServer(registry)
+	if blocked {
+		server = fmt.Sprintf("https://%s.%s", registry, blockedRegistryDomain)
+		if registry == "_default" {
+			server = "https://" + blockedRegistryDomain
+		}
+	}
+	return fmt.Sprintf("%s\nserver = %q\n", imagePolicyMarker, server)
+}
+
+// configureContainerdImagePolicy blocks registries by redirecting them to an unresolvable server in containerd's
+// hosts configuration. Registries that are configured otherwise (e.g. insecure registries or the registry cache)
+// are left alone when allowed, and the "_default" host applies to all registries without configuration.
+func configureContainerdImagePolicy(cr CommandRunner, p ImagePolicy) error {
+	// remove what a previous policy left behind
+	c := exec.Command("sh", "-c", fmt.Sprintf("sudo grep -rlsF %q %s | xargs -r sudo rm -f", imagePolicyMarker, containerdMirrorsRoot))
+	if _, err := cr.RunCmd(c); err != nil {
+		return errors.Wrap(err, "removing image policy config")
+	}
+	if p.IsEmpty() {
+		return nil
+	}
+	klog.Infof("configuring containerd image policy: allowed %v, denied %v", p.Allowed, p.Denied)
+
+	write := func(registry string, blocked bool, overwrite bool) error {
+		dir := path.Join(containerdMirrorsRoot, registry)
+		target := path.Join(dir, "hosts.toml")
+		if !overwrite {
+			if _, err := cr.RunCmd(exec.Command("sudo", "test", "-e", target)); err == nil {
+				return nil
+			}
+		}
+		if _, err := cr.RunCmd(exec.Command("sudo", "mkdir", "-p", dir)); err != nil {
+			return err
+		}
+		f := assets.NewMemoryAsset([]byte(containerdHostsFile(registry, blocked)), dir, "hosts.toml", "0644")
+		return errors.Wrapf(cr.Copy(f), "writing %s", target)
+	}
+
+	if len(p.Allowed) > 0 {
+		if err := write("_default", true, true); err != nil {
+			return err
+		}
+		for _, r := range p.Allowed {
+			if err := write(r, false, false); err != nil {
+				return err
+			}
+		}
+	}
+	for _, r := range p.Denied {
+		if err := write(r, true, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// signaturePolicy is the containers-policy.json(5) format used by cri-o
+type signaturePolicy struct {
+	Default    []policyRequirement                       `json:"default"`
+	Transports map[string]map[string][]policyRequirement `json:"transports"`
+}
+
+type policyRequirement struct {
+	Type string `json:"type"`
+}
+
+// crioSignaturePolicy returns the signature policy rejecting images from the registries not allowed by p
+func crioSignaturePolicy(p ImagePolicy) ([]byte, error) {
+	accept := []policyRequirement{{Type: "insecureAcceptAnything"}}
+	reject := []policyRequirement{{Type: "reject"}}
+
+	docker := map[string][]policyRequirement{}
+	sp := signaturePolicy{Default: accept, Transports: map[string]map[string][]policyRequirement{"docker": docker}}
+	if len(p.Allowed) > 0 {
+		// the default also covers other transports, which would otherwise be rejected
+		docker[""] = reject
+		for _, r := range p.Allowed {
+			docker[r] = accept
+		}
+	}
+	for _, r := range p.Denied {
+		docker[r] = reject
+	}
+	return json.MarshalIndent(sp, "", "  ")
+}
+
+// configureCRIOImagePolicy points cri-o to a signature policy which rejects the images of blocked registries
+func configureCRIOImagePolicy(cr CommandRunner, p ImagePolicy) error {
+	if p.IsEmpty() {
+		if _, err := cr.RunCmd(exec.Command("sudo", "rm", "-f", crioImagePolicyDropIn, crioImagePolicyFile)); err != nil {
+			return errors.Wrap(err, "removing image policy config")
+		}
+		return nil
+	}
+	klog.Infof("configuring cri-o image policy: allowed %v, denied %v", p.Allowed, p.Denied)
+
+	policy, err := crioSignaturePolicy(p)
+	if err != nil {
+		return err
+	}
+	for _, f := range []assets.CopyableFile{
+		assets.NewMemoryAssetTarget(policy, crioImagePolicyFile, "0644"),
+		assets.NewMemoryAssetTarget([]byte(fmt.Sprintf(crioImagePolicyConfTpl, imagePolicyMarker, crioImagePolicyFile)), crioImagePolicyDropIn, "0644"),
+	} {
+		if _, err := cr.RunCmd(exec.Command("sudo", "mkdir", "-p", f.GetTargetDir())); err != nil {
+			return err
+		}
+		if err := cr.Copy(f); err != nil {
+			return errors.Wrapf(err, "writing %s", path.Join(f.GetTargetDir(), f.GetTargetName()))
+		}
+	}
+	return nil
+}
+
+// ApplyImagePolicy reconfigures a running container runtime for its image policy, restarting it when required.
+// Docker has no way to block registries, so the policy is only enforced by admission there.
+func ApplyImagePolicy(cr Manager) error {
+	switch r := cr.(type) {
+	case *Containerd:
+		// containerd reads the hosts configuration on every pull
+		return configureContainerdImagePolicy(r.Runner, r.ImagePolicy)
+	case *CRIO:
+		if err := configureCRIOImagePolicy(r.Runner, r.ImagePolicy); err != nil {
+			return err
+		}
+		return r.Init.Restart("crio")
+	default:
+		klog.Infof("%s does not support blocking registries, the image policy is enforced by admission only", cr.Name())
+		return nil
+	}
+}
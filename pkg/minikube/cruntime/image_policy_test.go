@@ -0,0 +1,71 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cruntime
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCRIOSignaturePolicy(t *testing.T) {
+	data, err := crioSignaturePolicy(ImagePolicy{Allowed: []string{"registry.k8s.io", "docker.io"}, Denied: []string{"quay.io"}})
+	if err != nil {
+		t.Fatalf("crioSignaturePolicy: %v", err)
+	}
+	var got signaturePolicy
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid policy %s: %v", data, err)
+	}
+	accept := []policyRequirement{{Type: "insecureAcceptAnything"}}
+	reject := []policyRequirement{{Type: "reject"}}
+	want := signaturePolicy{
+		Default: accept,
+		Transports: map[string]map[string][]policyRequirement{
+			"docker": {
+				"":                reject,
+				"registry.k8s.io": accept,
+				"docker.io":       accept,
+				"quay.io":         reject,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("crioSignaturePolicy mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestContainerdHostsFile(t *testing.T) {
+	tests := []struct {
+		registry string
+		blocked  bool
+		server   string
+	}{
+		{"docker.io", false, `server = "https://registry-1.docker.io"`},
+		{"quay.io", false, `server = "https://quay.io"`},
+		{"quay.io", true, `server = "https://quay.io.blocked-by-minikube-image-policy.invalid"`},
+		{"_default", true, `server = "https://blocked-by-minikube-image-policy.invalid"`},
+	}
+	for _, tc := range tests {
+		got := containerdHostsFile(tc.registry, tc.blocked)
+		if !strings.HasPrefix(got, imagePolicyMarker) || !strings.Contains(got, tc.server) {
+			t.Errorf("containerdHostsFile(%q, %v) = %q, want %s", tc.registry, tc.blocked, got, tc.server)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cruntime
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// binfmtImage registers QEMU user emulators with the kernel of the node. It runs privileged, so it must be pinned
+// by digest: `make update-binfmt-version` bumps the tag and writes its digest.
+// TODO: run `make update-binfmt-version` to pin the digest of qemu-v8.1.5, which the tag is not pinned to yet.
+const binfmtImage = "docker.io/tonistiigi/binfmt:qemu-v8.1.5"
+
+// qemuArch maps the architecture of an OCI platform to the name of its QEMU emulator
+var qemuArch = map[string]string{
+	"amd64":   "x86_64",
+	"arm64":   "aarch64",
+	"arm":     "arm",
+	"ppc64le": "ppc64le",
+	"s390x":   "s390x",
+	"riscv64": "riscv64",
+	"386":     "i386",
+}
+
+// ParsePlatforms splits and validates a list of "os/arch[/variant]" platforms
+func ParsePlatforms(platforms []string) ([]string, error) {
+	result := []string{}
+	for _, p := range platforms {
+		for _, s := range strings.Split(p, ",") {
+			s = strings.TrimSpace(s)
+			if s == "" {
+				continue
+			}
+			parts := strings.Split(s, "/")
+			if len(parts) < 2 || len(parts) > 3 || parts[0] != "linux" {
+				return nil, fmt.Errorf("invalid platform %q, expected linux/ARCH[/VARIANT]", s)
+			}
+			if _, ok := qemuArch[parts[1]]; !ok {
+				return nil, fmt.Errorf("unsupported platform architecture %q", parts[1])
+			}
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// platformArch returns the architecture part of an "os/arch[/variant]" platform
+func platformArch(platform string) string {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+// foreignArchs returns the architectures of platforms which the node cannot run
+// natively and which do not have a QEMU emulator registered yet
+func foreignArchs(cr CommandRunner, platforms []string) ([]string, error) {
+	rr, err := cr.RunCmd(exec.Command("uname", "-m"))
+	if err != nil {
+		return nil, errors.Wrap(err, "uname")
+	}
+	native := strings.TrimSpace(rr.Stdout.String())
+
+	archs := []string{}
+	for _, p := range platforms {
+		arch := platformArch(p)
+		emulator := qemuArch[arch]
+		if emulator == native || (emulator == "i386" && native == "x86_64") || (emulator == "arm" && native == "aarch64") {
+			continue
+		}
+		binfmt := path.Join("/proc/sys/fs/binfmt_misc", "qemu-"+emulator)
+		if _, err := cr.RunCmd(exec.Command("test", "-f", binfmt)); err == nil {
+			klog.Infof("%s is already registered", binfmt)
+			continue
+		}
+		archs = append(archs, arch)
+	}
+	return archs, nil
+}
+
+// enableEmulation registers QEMU user emulation for the foreign platforms,
+// running the binfmt installer with the container runtime command given by run
+func enableEmulation(cr CommandRunner, platforms []string, run func(image string, args ...string) []*exec.Cmd) error {
+	archs, err := foreignArchs(cr, platforms)
+	if err != nil {
+		return err
+	}
+	if len(archs) == 0 {
+		return nil
+	}
+	klog.Infof("Installing QEMU emulation for: %v", archs)
+	for _, c := range run(binfmtImage, "--install", strings.Join(archs, ",")) {
+		if _, err := cr.RunCmd(c); err != nil {
+			return errors.Wrap(err, "installing QEMU emulation")
+		}
+	}
+	return nil
+}
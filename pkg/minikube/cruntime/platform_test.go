@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cruntime
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePlatforms(t *testing.T) {
+	var tests = []struct {
+		input   []string
+		want    []string
+		wantErr bool
+	}{
+		{nil, []string{}, false},
+		{[]string{"linux/amd64"}, []string{"linux/amd64"}, false},
+		{[]string{"linux/amd64,linux/arm64"}, []string{"linux/amd64", "linux/arm64"}, false},
+		{[]string{"linux/arm/v7", " linux/s390x "}, []string{"linux/arm/v7", "linux/s390x"}, false},
+		{[]string{"windows/amd64"}, nil, true},
+		{[]string{"linux"}, nil, true},
+		{[]string{"linux/mips"}, nil, true},
+	}
+	for _, tc := range tests {
+		got, err := ParsePlatforms(tc.input)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ParsePlatforms(%v) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			continue
+		}
+		if diff := cmp.Diff(tc.want, got); !tc.wantErr && diff != "" {
+			t.Errorf("ParsePlatforms(%v) mismatch (-want +got):\n%s", tc.input, diff)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cruntime
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+)
+
+const (
+	// registryCacheMirrored is the registry which the host registry cache pulls through from
+	registryCacheMirrored = "docker.io"
+	// registryCacheMarker marks the runtime config files written for the registry cache
+	registryCacheMarker = "# generated by minikube for the host registry cache"
+
+	containerdRegistryCacheTemplate = `%s
+server = "https://registry-1.docker.io"
+
+[host."http://%s"]
+  capabilities = ["pull", "resolve"]
+`
+	crioRegistriesDir         = "/etc/containers/registries.conf.d"
+	crioRegistryCacheFile     = "90-minikube-registry-cache.conf"
+	crioRegistryCacheTemplate = `%s
+[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+
+[[registry.mirror]]
+location = "%s"
+insecure = true
+`
+)
+
+// configureRegistryCache writes the runtime config file that mirrors docker.io to the registry cache at addr,
+// or removes a previously written one if addr is empty
+func configureRegistryCache(cr CommandRunner, dir string, name string, content string, addr string) error {
+	target := path.Join(dir, name)
+	if addr == "" {
+		// only remove the file if it was written by us
+		c := exec.Command("sh", "-c", fmt.Sprintf("if sudo grep -qs %q %s; then sudo rm -f %s; fi", registryCacheMarker, target, target))
+		if _, err := cr.RunCmd(c); err != nil {
+			return errors.Wrap(err, "removing registry cache config")
+		}
+		return nil
+	}
+	klog.Infof("configuring %s to use the registry cache at %s", registryCacheMirrored, addr)
+	if _, err := cr.RunCmd(exec.Command("sudo", "mkdir", "-p", dir)); err != nil {
+		return err
+	}
+	f := assets.NewMemoryAsset([]byte(content), dir, name, "0644")
+	if err := cr.Copy(f); err != nil {
+		return errors.Wrap(err, "writing registry cache config")
+	}
+	return nil
+}
+
+// configureContainerdRegistryCache configures containerd to pull docker.io images through the registry cache
+func configureContainerdRegistryCache(cr CommandRunner, addr string) error {
+	dir := path.Join(containerdMirrorsRoot, registryCacheMirrored)
+	return configureRegistryCache(cr, dir, "hosts.toml", fmt.Sprintf(containerdRegistryCacheTemplate, registryCacheMarker, addr), addr)
+}
+
+// configureCRIORegistryCache configures cri-o to pull docker.io images through the registry cache
+func configureCRIORegistryCache(cr CommandRunner, addr string) error {
+	return configureRegistryCache(cr, crioRegistriesDir, crioRegistryCacheFile, fmt.Sprintf(crioRegistryCacheTemplate, registryCacheMarker, addr), addr)
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/registrycache"
 	"k8s.io/minikube/pkg/minikube/style"
 )
 
@@ -64,6 +65,11 @@ func PossibleLeftOvers(ctx context.Context, cname string, driverName string) {
 		klog.Warningf("error deleting volumes (might be okay).\nTo see the list of volumes run: 'docker volume ls'\n:%v", errs)
 	}
 
+	// the registry cache container outlives the profiles it is attached to
+	if err := registrycache.DisconnectNetworks(bin, delLabel); err != nil {
+		klog.Warningf("error disconnecting the registry cache (might be okay): %v", err)
+	}
+
 	errs = oci.DeleteKICNetworksByLabel(bin, delLabel)
 	if errs != nil {
 		klog.Warningf("error deleting leftover networks (might be okay).\nTo see the list of networks: 'docker network ls'\n:%v", errs)
@@ -126,6 +126,11 @@ func ISOCacheDir() string {
 	return filepath.Join(localpath.MakeMiniPath("cache", "iso"), runtime.GOARCH)
 }
 
+// BuildCacheDir returns the path in the minikube home directory to the image build cache for the current architecture
+func BuildCacheDir() string {
+	return filepath.Join(localpath.MakeMiniPath("cache", "build"), runtime.GOARCH)
+}
+
 // SocketVMNetInstalled returns if socket_vmnet is installed
 func SocketVMNetInstalled() bool {
 	if runtime.GOOS != "darwin" {
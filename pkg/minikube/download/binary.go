@@ -27,10 +27,14 @@ import (
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mirror"
 )
 
 // DefaultKubeBinariesURL returns a URL to kube binaries
 func DefaultKubeBinariesURL() string {
+	if mirror.Enabled() {
+		return mirror.URL("release")
+	}
 	return fmt.Sprintf("https://%s%s/release", releaseHost, releasePath)
 }
 
@@ -0,0 +1,434 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// chunkedThreshold is the size from which an artifact is fetched in parallel chunks
+	chunkedThreshold int64 = 64 << 20
+	// chunkSize is the size of the chunks of an artifact fetched in parallel
+	chunkSize int64 = 16 << 20
+	// chunkWorkers is the number of chunks fetched concurrently
+	chunkWorkers = 4
+	// chunkRetries is the number of times a chunk is retried after a network error, before the download fails
+	chunkRetries = 3
+	// chunkRetryDelay is the delay before the first retry of a chunk, doubled on each retry
+	chunkRetryDelay = time.Second
+	// partialSaveInterval is how often the progress of a download is saved while it runs
+	partialSaveInterval = time.Second
+)
+
+// errChanged is returned when an artifact changed on the server while it was partially downloaded
+var errChanged = errors.New("the artifact changed on the server since it was partially downloaded")
+
+// httpGetter is a go-getter Getter for http and https, which resumes partial downloads with range requests
+// and fetches large artifacts in parallel chunks. Servers without range requests are handled by getter.HttpGetter.
+type httpGetter struct {
+	getter.HttpGetter
+	client *getter.Client
+}
+
+// SetClient keeps the client for its progress listener
+func (g *httpGetter) SetClient(c *getter.Client) {
+	g.client = c
+	g.HttpGetter.SetClient(c)
+}
+
+// chunk is a byte range of an artifact, of which the first Done bytes are downloaded
+type chunk struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+	Done  int64 `json:"done"`
+}
+
+// partial is the state of a partial download, saved next to it so that it can be resumed
+type partial struct {
+	URL string `json:"url"`
+	// Size is the size of the artifact
+	Size int64 `json:"size"`
+	// Validator is the ETag or Last-Modified header of the artifact, to only resume a download of the same artifact
+	Validator string  `json:"validator"`
+	Chunks    []chunk `json:"chunks"`
+
+	mu sync.Mutex
+}
+
+// partialPath returns the path of the state of a partial download
+func partialPath(dst string) string {
+	return dst + ".partial"
+}
+
+// newPartial splits an artifact into chunks
+func newPartial(src string, size int64, validator string) *partial {
+	p := &partial{URL: src, Size: size, Validator: validator}
+	if size < chunkedThreshold {
+		p.Chunks = []chunk{{Start: 0, End: size}}
+		return p
+	}
+	for start := int64(0); start < size; start += chunkSize {
+		p.Chunks = append(p.Chunks, chunk{Start: start, End: min(start+chunkSize, size)})
+	}
+	return p
+}
+
+// loadPartial returns the state of a partial download of the same artifact, or nil if there is none
+func loadPartial(dst, src string, size int64, validator string) *partial {
+	data, err := os.ReadFile(partialPath(dst))
+	if err != nil {
+		return nil
+	}
+	var p partial
+	if err := json.Unmarshal(data, &p); err != nil {
+		klog.Warningf("ignoring invalid partial download state of %s: %v", dst, err)
+		return nil
+	}
+	if validator == "" || p.URL != src || p.Size != size || p.Validator != validator {
+		klog.Infof("%s changed since it was partially downloaded, restarting", src)
+		return nil
+	}
+	if st, err := os.Stat(dst); err != nil || st.Size() != size {
+		return nil
+	}
+	return &p
+}
+
+// save saves the state of a partial download, replacing the previous one once it is complete
+func (p *partial) save(dst string) error {
+	p.mu.Lock()
+	data, err := json.Marshal(p)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	tmp := partialPath(dst) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, partialPath(dst))
+}
+
+// done returns the number of bytes downloaded
+func (p *partial) done() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var n int64
+	for _, c := range p.Chunks {
+		n += c.Done
+	}
+	return n
+}
+
+// next returns the byte range of a chunk which remains to be downloaded
+func (p *partial) next(i int) (int64, int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c := p.Chunks[i]
+	return c.Start + c.Done, c.End
+}
+
+func (p *partial) advance(i int, n int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Chunks[i].Done += n
+}
+
+// removePartial removes a partial download and its state
+func removePartial(dst string) {
+	for _, f := range []string{dst, partialPath(dst)} {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("failed to remove %s: %v", f, err)
+		}
+	}
+}
+
+// progressStream turns the bytes written by concurrent chunk fetches into a single stream,
+// so that any getter.ProgressTracker can track the progress of the whole download
+type progressStream struct {
+	written chan int
+	pending int
+}
+
+func newProgressStream() *progressStream {
+	return &progressStream{written: make(chan int, 64)}
+}
+
+// add reports that n bytes were written
+func (s *progressStream) add(n int) {
+	s.written <- n
+}
+
+// Read returns as many bytes as were written, with no meaningful content
+func (s *progressStream) Read(b []byte) (int, error) {
+	if s.pending == 0 {
+		n, ok := <-s.written
+		if !ok {
+			return 0, io.EOF
+		}
+		s.pending = n
+	}
+	n := min(len(b), s.pending)
+	s.pending -= n
+	return n, nil
+}
+
+// Close does nothing, the stream ends once all fetches are done
+func (s *progressStream) Close() error {
+	return nil
+}
+
+// track starts tracking the progress of a download, and returns a function which reports a number of written bytes,
+// and a function which stops tracking once all bytes are reported
+func (g *httpGetter) track(src *url.URL, current, total int64) (func(int), func()) {
+	if g.client == nil || g.client.ProgressListener == nil {
+		return func(int) {}, func() {}
+	}
+	stream := newProgressStream()
+	tracked := g.client.ProgressListener.TrackProgress(filepath.Base(src.EscapedPath()), current, total, stream)
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		if _, err := io.Copy(io.Discard, tracked); err != nil {
+			klog.Warningf("progress tracking failed: %v", err)
+		}
+	}()
+	return stream.add, func() {
+		close(stream.written)
+		<-drained
+		if err := tracked.Close(); err != nil {
+			klog.Warningf("failed to close progress tracker: %v", err)
+		}
+	}
+}
+
+// head returns the size and validator of an artifact, if its server supports range requests
+func (g *httpGetter) head(ctx context.Context, src *url.URL) (int64, string, bool) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, src.String(), nil)
+	if err != nil {
+		return 0, "", false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		klog.Infof("HEAD %s failed: %v", src.Redacted(), err)
+		return 0, "", false
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Accept-Ranges") != "bytes" || resp.ContentLength <= 0 {
+		return 0, "", false
+	}
+	// weak ETags cannot be used in If-Range
+	validator := resp.Header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = resp.Header.Get("Last-Modified")
+	}
+	return resp.ContentLength, validator, true
+}
+
+// GetFile downloads an artifact to dst, resuming a previous partial download of it
+func (g *httpGetter) GetFile(dst string, src *url.URL) error {
+	ctx := g.Context()
+	size, validator, ranges := g.head(ctx, src)
+	if !ranges {
+		klog.Infof("%s does not support range requests, downloading it in one piece", src.Redacted())
+		removePartial(dst)
+		return g.HttpGetter.GetFile(dst, src)
+	}
+
+	p := loadPartial(dst, src.String(), size, validator)
+	if p == nil {
+		removePartial(dst)
+		p = newPartial(src.String(), size, validator)
+	} else {
+		klog.Infof("Resuming download of %s from %d of %d bytes", src.Redacted(), p.done(), size)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(dst, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := f.Truncate(size); err != nil {
+		return err
+	}
+
+	err = g.fetch(ctx, src, f, p, dst)
+	if errors.Is(err, errChanged) {
+		f.Close()
+		removePartial(dst)
+		return err
+	}
+	if err != nil {
+		if serr := p.save(dst); serr != nil {
+			klog.Warningf("failed to save partial download of %s: %v", dst, serr)
+		}
+		return err
+	}
+	if err := os.Remove(partialPath(dst)); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("failed to remove %s: %v", partialPath(dst), err)
+	}
+	return nil
+}
+
+// fetch downloads the remaining chunks of a partial download concurrently, saving its progress as it goes
+func (g *httpGetter) fetch(ctx context.Context, src *url.URL, f *os.File, p *partial, dst string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	remaining := make(chan int, len(p.Chunks))
+	for i := range p.Chunks {
+		if start, end := p.next(i); start < end {
+			remaining <- i
+		}
+	}
+	close(remaining)
+	workers := min(chunkWorkers, len(remaining))
+	if len(p.Chunks) > 1 {
+		klog.Infof("Downloading %s in %d chunks with %d workers", src.Redacted(), len(remaining), workers)
+	}
+
+	report, stop := g.track(src, p.done(), p.Size)
+	saving := make(chan struct{})
+	savingDone := make(chan struct{})
+	go func() {
+		defer close(savingDone)
+		t := time.NewTicker(partialSaveInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-saving:
+				return
+			case <-t.C:
+				if err := p.save(dst); err != nil {
+					klog.Warningf("failed to save partial download of %s: %v", dst, err)
+				}
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var ferr error
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range remaining {
+				if err := g.fetchChunk(ctx, src, f, p, i, report); err != nil {
+					once.Do(func() {
+						ferr = err
+						cancel()
+					})
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(saving)
+	<-savingDone
+	stop()
+	return ferr
+}
+
+// fetchChunk downloads the rest of a chunk, retrying after network errors
+func (g *httpGetter) fetchChunk(ctx context.Context, src *url.URL, f *os.File, p *partial, i int, report func(int)) error {
+	delay := chunkRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := g.fetchRange(ctx, src, f, p, i, report)
+		if err == nil || errors.Is(err, errChanged) || ctx.Err() != nil || attempt >= chunkRetries {
+			return err
+		}
+		klog.Infof("retrying chunk %d of %s in %s: %v", i, src.Redacted(), delay, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// fetchRange downloads the rest of a chunk with a range request, writing it in place
+func (g *httpGetter) fetchRange(ctx context.Context, src *url.URL, f *os.File, p *partial, i int, report func(int)) error {
+	start, end := p.next(i)
+	if start >= end {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", start, end-1))
+	if p.Validator != "" {
+		req.Header.Set("If-Range", p.Validator)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// the server ignores the range if the validator does not match anymore
+		return errChanged
+	default:
+		return fmt.Errorf("bad response code: %d", resp.StatusCode)
+	}
+
+	buf := make([]byte, 32<<10)
+	for start < end {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			n = int(min(int64(n), end-start))
+			if _, werr := f.WriteAt(buf[:n], start); werr != nil {
+				return werr
+			}
+			start += int64(n)
+			p.advance(i, int64(n))
+			report(n)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if start < end {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// artifactServer serves an artifact with range requests, optionally cutting off the first responses
+type artifactServer struct {
+	content []byte
+	etag    atomic.Value
+	// failures is the number of responses to cut off after half of their body
+	failures atomic.Int32
+	served   atomic.Int64
+	ranges   atomic.Int32
+}
+
+func (s *artifactServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("ETag", s.etag.Load().(string))
+	if r.Header.Get("Range") != "" && r.Method == http.MethodGet {
+		s.ranges.Add(1)
+	}
+	if r.Method == http.MethodGet && s.failures.Add(-1) >= 0 {
+		// hijack the connection to cut off the response in the middle of its body
+		rec := httptest.NewRecorder()
+		rec.Header().Set("ETag", s.etag.Load().(string))
+		http.ServeContent(rec, r, "artifact", time.Time{}, bytes.NewReader(s.content))
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			panic("no hijacker")
+		}
+		conn, _, err := hj.Hijack()
+		if err != nil {
+			panic(err)
+		}
+		defer conn.Close()
+		body := rec.Body.Bytes()
+		_, _ = fmt.Fprintf(conn, "HTTP/1.1 %d %s\r\n", rec.Code, http.StatusText(rec.Code))
+		_ = rec.Header().Write(conn)
+		_, _ = io.WriteString(conn, "\r\n")
+		_, _ = conn.Write(body[:len(body)/2])
+		s.served.Add(int64(len(body) / 2))
+		return
+	}
+	cw := &countingWriter{ResponseWriter: w, n: &s.served}
+	http.ServeContent(cw, r, "artifact", time.Time{}, bytes.NewReader(s.content))
+}
+
+type countingWriter struct {
+	http.ResponseWriter
+	n *atomic.Int64
+}
+
+func (w *countingWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.n.Add(int64(n))
+	return n, err
+}
+
+// countingTracker counts the bytes a progress tracker sees
+type countingTracker struct {
+	mu             sync.Mutex
+	current, total int64
+	read           int64
+}
+
+func (t *countingTracker) TrackProgress(_ string, current, total int64, stream io.ReadCloser) io.ReadCloser {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.current, t.total, t.read = current, total, 0
+	return &readCloser{
+		Reader: readerFunc(func(b []byte) (int, error) {
+			n, err := stream.Read(b)
+			t.mu.Lock()
+			t.read += int64(n)
+			t.mu.Unlock()
+			return n, err
+		}),
+		close: stream.Close,
+	}
+}
+
+type readerFunc func([]byte) (int, error)
+
+func (f readerFunc) Read(b []byte) (int, error) { return f(b) }
+
+func setChunking(t *testing.T, threshold, size int64) {
+	t.Helper()
+	savedThreshold, savedSize, savedDelay := chunkedThreshold, chunkSize, chunkRetryDelay
+	chunkedThreshold, chunkSize, chunkRetryDelay = threshold, size, time.Millisecond
+	t.Cleanup(func() {
+		chunkedThreshold, chunkSize, chunkRetryDelay = savedThreshold, savedSize, savedDelay
+	})
+}
+
+func getFile(t *testing.T, src, dst string, tracker getter.ProgressTracker) error {
+	t.Helper()
+	client := &getter.Client{
+		Src:     src,
+		Dst:     dst,
+		Mode:    getter.ClientModeFile,
+		Getters: map[string]getter.Getter{"http": &httpGetter{}},
+	}
+	if tracker != nil {
+		client.Options = []getter.ClientOption{getter.WithProgress(tracker)}
+	}
+	return client.Get()
+}
+
+func TestChunkedDownload(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789abcdef"), 4096)
+
+	t.Run("Parallel", func(t *testing.T) {
+		setChunking(t, 1024, 8192)
+		s := &artifactServer{content: content}
+		s.etag.Store(`"v1"`)
+		srv := httptest.NewServer(s)
+		defer srv.Close()
+
+		dst := filepath.Join(t.TempDir(), "artifact")
+		tracker := &countingTracker{}
+		if err := getFile(t, srv.URL+"/artifact", dst, tracker); err != nil {
+			t.Fatalf("download: %v", err)
+		}
+		assertContent(t, dst, content)
+		if got, want := s.ranges.Load(), int32(len(content)/8192); got != want {
+			t.Errorf("got %d range requests, want %d", got, want)
+		}
+		if tracker.total != int64(len(content)) || tracker.read != int64(len(content)) {
+			t.Errorf("progress tracked %d of %d bytes, want %d", tracker.read, tracker.total, len(content))
+		}
+		if _, err := os.Stat(partialPath(dst)); !os.IsNotExist(err) {
+			t.Errorf("expected the partial download state to be removed, got %v", err)
+		}
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		setChunking(t, 1<<30, 8192)
+		s := &artifactServer{content: content}
+		s.etag.Store(`"v1"`)
+		s.failures.Store(int32(chunkRetries + 1))
+		srv := httptest.NewServer(s)
+		defer srv.Close()
+
+		dst := filepath.Join(t.TempDir(), "artifact")
+		if err := getFile(t, srv.URL+"/artifact", dst, nil); err == nil {
+			t.Fatalf("expected the download to fail after %d retries", chunkRetries)
+		}
+		if _, err := os.Stat(partialPath(dst)); err != nil {
+			t.Fatalf("expected the partial download state to be saved: %v", err)
+		}
+
+		tracker := &countingTracker{}
+		if err := getFile(t, srv.URL+"/artifact", dst, tracker); err != nil {
+			t.Fatalf("resumed download: %v", err)
+		}
+		assertContent(t, dst, content)
+		if tracker.current == 0 || tracker.current+tracker.read != int64(len(content)) {
+			t.Errorf("resumed from %d and read %d bytes, want a resume adding up to %d", tracker.current, tracker.read, len(content))
+		}
+		// every failed response served half of the rest of the artifact
+		if served := s.served.Load(); served >= 2*int64(len(content)) {
+			t.Errorf("served %d bytes for an artifact of %d, expected the download to resume", served, len(content))
+		}
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		setChunking(t, 1<<30, 8192)
+		s := &artifactServer{content: content}
+		s.etag.Store(`"v1"`)
+		s.failures.Store(int32(chunkRetries + 1))
+		srv := httptest.NewServer(s)
+		defer srv.Close()
+
+		dst := filepath.Join(t.TempDir(), "artifact")
+		if err := getFile(t, srv.URL+"/artifact", dst, nil); err == nil {
+			t.Fatalf("expected the download to fail")
+		}
+		changed := []byte(strings.ToUpper(string(content)))
+		s.content = changed
+		s.etag.Store(`"v2"`)
+		if err := getFile(t, srv.URL+"/artifact", dst, nil); err != nil {
+			t.Fatalf("download: %v", err)
+		}
+		assertContent(t, dst, changed)
+	})
+}
+
+func assertContent(t *testing.T, path string, want []byte) {
+	t.Helper()
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("downloaded %d bytes which do not match the %d bytes of the artifact", len(got), len(want))
+	}
+}
+
+func TestDownloadLocked(t *testing.T) {
+	savedMock, savedTimeout := DownloadMock, downloadLockTimeout
+	DownloadMock = nil
+	t.Cleanup(func() { DownloadMock, downloadLockTimeout = savedMock, savedTimeout })
+
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src")
+	if err := os.WriteFile(src, []byte("artifact"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("DownloadedWhileWaiting", func(t *testing.T) {
+		dst := filepath.Join(dir, "waiting")
+		releaser, err := lockDownload(dst + ".download.lock")
+		if err != nil {
+			t.Fatal(err)
+		}
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = os.WriteFile(dst, []byte("other"), 0644)
+			releaser.Release()
+		}()
+		if err := download("file://"+src, dst); err != nil {
+			t.Fatalf("download: %v", err)
+		}
+		assertContent(t, dst, []byte("other"))
+	})
+
+	t.Run("LockTimeout", func(t *testing.T) {
+		downloadLockTimeout = 200 * time.Millisecond
+		dst := filepath.Join(dir, "timeout")
+		releaser, err := lockDownload(dst + ".download.lock")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer releaser.Release()
+		if err := download("file://"+src, dst); err != nil {
+			t.Fatalf("download: %v", err)
+		}
+		assertContent(t, dst, []byte("artifact"))
+		if _, err := os.Lstat(fmt.Sprintf("%s.%d.download", dst, os.Getpid())); !os.IsNotExist(err) {
+			t.Errorf("expected the independent download to be renamed, got %v", err)
+		}
+	})
+}
@@ -44,8 +44,27 @@ var (
 
 	releaseHost = "dl.k8s.io"
 	releasePath = ""
+
+	offline = false
+
+	// imageRepository is the image repository of the cluster, which user-built preloads must have been built with
+	imageRepository = ""
 )
 
+// ErrOffline is returned when an artifact that is not cached would have to be downloaded in offline mode
+var ErrOffline = errors.New("not available offline, as it is not cached")
+
+// SetOffline disables all downloads, so that only cached artifacts are used
+func SetOffline() {
+	offline = true
+}
+
+// SetImageRepository sets the image repository of the cluster, so that only the user-built preloads built with it
+// are used
+func SetImageRepository(repository string) {
+	imageRepository = repository
+}
+
 // SetAliyunMirror set the download host for Aliyun mirror
 func SetAliyunMirror() {
 	downloadHost = aliyunMirror
@@ -64,7 +83,9 @@ func CreateDstDownloadMock(_, dst string) error {
 	return err
 }
 
-// download is a well-configured atomic download function
+// download is a well-configured atomic download function.
+// Partial downloads are resumed, and large artifacts are fetched in parallel chunks, see httpGetter.
+// Downloads are verified against their detached signature when a signing key is set, see SetSigningKey.
 func download(src, dst string, options ...getter.ClientOption) error {
 	var clientOptions []getter.ClientOption
 	if out.IsTerminal(os.Stdout) && !detect.GithubActionRunner() {
@@ -86,8 +107,8 @@ func download(src, dst string, options ...getter.ClientOption) error {
 		Options: clientOptions,
 		Getters: map[string]getter.Getter{
 			"file":  &getter.FileGetter{Copy: false},
-			"http":  &getter.HttpGetter{Netrc: false},
-			"https": &getter.HttpGetter{Netrc: false},
+			"http":  &httpGetter{HttpGetter: getter.HttpGetter{Netrc: false}},
+			"https": &httpGetter{HttpGetter: getter.HttpGetter{Netrc: false}},
 		},
 	}
 
@@ -95,20 +116,54 @@ func download(src, dst string, options ...getter.ClientOption) error {
 		return errors.Wrap(err, "mkdir")
 	}
 
+	if offline {
+		return errors.Wrap(ErrOffline, src)
+	}
+
 	if DownloadMock != nil {
 		klog.Infof("Mock download: %s -> %s", src, dst)
 		return DownloadMock(src, dst)
 	}
 
-	// Politely prevent tests from shooting themselves in the foot
-	if withinUnitTest() {
+	// Politely prevent tests from shooting themselves in the foot, local files are fine
+	if withinUnitTest() && !strings.HasPrefix(src, fileScheme+"://") {
 		return fmt.Errorf("unmocked download under test")
 	}
 
+	_, err := os.Stat(dst)
+	existed := err == nil
+
+	// the partial download is shared by all minikube processes downloading the same artifact
+	releaser, err := lockDownload(tmpDst + ".lock")
+	if err != nil {
+		// the process holding the lock may still be busy with a large download over a slow link,
+		// rather than failing, download an independent copy which does not touch the shared partial download
+		klog.Warningf("%v, downloading %s independently", err, src)
+		tmpDst = fmt.Sprintf("%s.%d.download", dst, os.Getpid())
+		client.Dst = tmpDst
+		removePartial(tmpDst)
+		defer removePartial(tmpDst)
+	} else {
+		defer releaser.Release()
+		if _, err := os.Stat(dst); err == nil && !existed {
+			klog.Infof("%s was downloaded by another minikube instance while waiting", dst)
+			return nil
+		}
+	}
+
 	klog.Infof("Downloading: %s -> %s", src, dst)
 	if err := client.Get(); err != nil {
+		var cerr *getter.ChecksumError
+		if errors.As(err, &cerr) {
+			// a corrupt download cannot be resumed
+			removePartial(tmpDst)
+		}
 		return errors.Wrapf(err, "getter: %+v", client)
 	}
+	if err := verifySignature(src, tmpDst); err != nil {
+		removePartial(tmpDst)
+		return err
+	}
 	return os.Rename(tmpDst, dst)
 }
 
@@ -122,6 +177,10 @@ func withinUnitTest() bool {
 	return flag.Lookup("test.v") != nil || strings.HasSuffix(os.Args[0], "test")
 }
 
+// downloadLockTimeout is how long to wait for another minikube instance downloading the same artifact
+// before downloading it independently, this is a variable so it can be overridden in tests
+var downloadLockTimeout = 5 * time.Minute
+
 // lockDownload locks `file` if possible and returns a releaser that must be called to release the lock.
 func lockDownload(file string) (mutex.Releaser, error) {
 	type retPair struct {
@@ -132,7 +191,7 @@ func lockDownload(file string) (mutex.Releaser, error) {
 
 	go func() {
 		spec := lock.PathMutexSpec(file)
-		spec.Timeout = 5 * time.Minute
+		spec.Timeout = downloadLockTimeout
 		releaser, err := mutex.Acquire(spec)
 		if err != nil {
 			lockChannel <- retPair{nil, errors.Wrapf(err, "failed to acquire lock \"%s\": %+v", file, spec)}
@@ -148,7 +207,7 @@ func lockDownload(file string) (mutex.Releaser, error) {
 		out.Step(style.WaitingWithSpinner, "Another minikube instance is downloading dependencies... ")
 	}
 
-	// lock.PathMutexSpec returns a spec including a 60s timeout. Therefore, this
+	// the spec has a timeout of downloadLockTimeout. Therefore, this
 	// will not block indefinitely.
 	r := <-lockChannel
 	return r.Releaser, r.error
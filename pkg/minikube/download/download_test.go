@@ -20,12 +20,14 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mirror"
 )
 
 // Force download tests to run in serial.
@@ -36,6 +38,8 @@ func TestDownload(t *testing.T) {
 	t.Run("PreloadNotExists", testPreloadNotExists)
 	t.Run("PreloadExistsCaching", testPreloadExistsCaching)
 	t.Run("PreloadWithCachedSizeZero", testPreloadWithCachedSizeZero)
+	t.Run("PreloadExistsPrefersLocal", testPreloadExistsPrefersLocal)
+	t.Run("PreloadFromMirror", testPreloadFromMirror)
 }
 
 // Returns a mock function that sleeps before incrementing `downloadsCounter` and creates the requested file.
@@ -275,3 +279,115 @@ func testPreloadWithCachedSizeZero(t *testing.T) {
 		t.Errorf("Expected only 1 download attempt but got %v!", downloadNum)
 	}
 }
+
+// testPreloadExistsPrefersLocal verifies that a user-built preload is used without any remote check,
+// even if the remote check was cached before it was built.
+func testPreloadExistsPrefersLocal(t *testing.T) {
+	setupTestMiniHome(t)
+	checkCache = func(_ string) (fs.FileInfo, error) {
+		return nil, fmt.Errorf("cache not found")
+	}
+	remoteChecks := 0
+	savedGCSCheck := checkRemotePreloadExistsGCS
+	savedGHCheck := checkRemotePreloadExistsGitHub
+	preloadStates = make(map[string]map[string]preloadState)
+	checkRemotePreloadExistsGCS = func(_, _ string) bool {
+		remoteChecks++
+		return false
+	}
+	checkRemotePreloadExistsGitHub = func(_, _ string) bool {
+		remoteChecks++
+		return false
+	}
+	t.Cleanup(func() {
+		checkRemotePreloadExistsGCS = savedGCSCheck
+		checkRemotePreloadExistsGitHub = savedGHCheck
+		preloadStates = make(map[string]map[string]preloadState)
+	})
+
+	if PreloadExists("v1", "containerd", "docker", true) {
+		t.Fatalf("Expected preload not to exist")
+	}
+
+	local := LocalTarballPath("v1", "containerd")
+	if err := os.MkdirAll(filepath.Dir(local), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local, []byte("preload"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	remoteChecks = 0
+	if !PreloadExists("v1", "containerd", "docker", true) || remoteChecks != 0 {
+		t.Errorf("Expected user-built preload to exist without remote checks, got %d remote checks", remoteChecks)
+	}
+	if got := TarballPath("v1", "containerd"); got != local {
+		t.Errorf("TarballPath() = %s, want %s", got, local)
+	}
+	if got := TarballPath("v1", "docker"); got == LocalTarballPath("v1", "docker") {
+		t.Errorf("TarballPath() = %s, want the official preload", got)
+	}
+
+	// a user-built preload is only used with the image repository it was built with
+	if err := SaveLocalPreloadRepository("v1", "containerd", "registry.example.com"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetImageRepository("") })
+	if LocalPreloadExists("v1", "containerd") {
+		t.Errorf("Expected the user-built preload of another image repository to be ignored")
+	}
+	if got := TarballPath("v1", "containerd"); got == local {
+		t.Errorf("TarballPath() = %s, want the official preload", got)
+	}
+	SetImageRepository("registry.example.com")
+	if !LocalPreloadExists("v1", "containerd") {
+		t.Errorf("Expected the user-built preload of the image repository to be used")
+	}
+}
+
+// testPreloadFromMirror verifies that the artifact mirror replaces the other preload sources,
+// and that the preload is verified against the checksum the mirror publishes.
+func testPreloadFromMirror(t *testing.T) {
+	setupTestMiniHome(t)
+	if err := mirror.Set("https://mirror.example.com/minikube"); err != nil {
+		t.Fatal(err)
+	}
+	checkCache = func(_ string) (fs.FileInfo, error) {
+		return nil, fmt.Errorf("cache not found")
+	}
+	remoteChecks := 0
+	savedGCSCheck := checkRemotePreloadExistsGCS
+	savedMirrorCheck := checkRemotePreloadExistsMirror
+	savedMirrorChecksum := getChecksumMirror
+	preloadStates = make(map[string]map[string]preloadState)
+	checkRemotePreloadExistsGCS = func(_, _ string) bool {
+		remoteChecks++
+		return true
+	}
+	checkRemotePreloadExistsMirror = func(_, _ string) bool { return true }
+	getChecksumMirror = func(_, _ string) ([]byte, error) { return []byte("abc123"), nil }
+	t.Cleanup(func() {
+		_ = mirror.Set("")
+		checkRemotePreloadExistsGCS = savedGCSCheck
+		checkRemotePreloadExistsMirror = savedMirrorCheck
+		getChecksumMirror = savedMirrorChecksum
+		preloadStates = make(map[string]map[string]preloadState)
+	})
+
+	if !PreloadExists("v1.33.0", "containerd", "docker", true) || remoteChecks != 0 {
+		t.Fatalf("Expected preload to exist on the mirror only, got %d other remote checks", remoteChecks)
+	}
+
+	checkPreloadExists = func(_, _, _ string, _ ...bool) bool { return true }
+	var src string
+	DownloadMock = func(s, dst string) error {
+		src = s
+		return CreateDstDownloadMock(s, dst)
+	}
+	if err := Preload("v1.33.0", "containerd", "docker"); err != nil {
+		t.Fatalf("Preload() = %v", err)
+	}
+	want := "https://mirror.example.com/minikube/preload/" + PreloadVersion + "/v1.33.0/" + TarballName("v1.33.0", "containerd") + "?checksum=sha256:abc123"
+	if src != want {
+		t.Errorf("downloaded %s, want %s", src, want)
+	}
+}
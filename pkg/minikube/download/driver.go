@@ -24,16 +24,23 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
 )
 
 func driverWithChecksumURL(name string, v semver.Version) string {
 	base := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/%s", v, name)
+	if mirror.Enabled() {
+		base = mirror.URL("minikube", "releases", "v"+v.String(), name)
+	}
 	return fmt.Sprintf("%s?checksum=file:%s.sha256", base, base)
 }
 func driverWithArchAndChecksumURL(name string, v semver.Version) string {
 	base := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/%s-%s", v, name, runtime.GOARCH)
+	if mirror.Enabled() {
+		base = mirror.URL("minikube", "releases", "v"+v.String(), name+"-"+runtime.GOARCH)
+	}
 	return fmt.Sprintf("%s?checksum=file:%s.sha256", base, base)
 }
 
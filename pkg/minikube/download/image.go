@@ -38,6 +38,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/image"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/version"
@@ -50,8 +51,8 @@ var (
 	}
 )
 
-// imagePathInCache returns path in local cache directory
-func imagePathInCache(img string) string {
+// ImagePathInCache returns the path of a kic base image in the local cache directory
+func ImagePathInCache(img string) string {
 	f := filepath.Join(detect.KICCacheDir(), path.Base(img)+".tar")
 	f = localpath.SanitizeCacheDir(f)
 	return f
@@ -59,7 +60,7 @@ func imagePathInCache(img string) string {
 
 // ImageExistsInCache if img exist in local cache directory
 func ImageExistsInCache(img string) bool {
-	f := imagePathInCache(img)
+	f := ImagePathInCache(img)
 
 	// Check if image exists locally
 	klog.Infof("Checking for %s in local cache directory", img)
@@ -122,7 +123,10 @@ func isImageCorrectArch(img string) (bool, error) {
 
 // ImageToCache downloads img (if not present in cache) and writes it to the local cache directory
 func ImageToCache(img string) error {
-	f := imagePathInCache(img)
+	if err := VerifyImagePinned(img); err != nil {
+		return err
+	}
+	f := ImagePathInCache(img)
 	fileLock := f + ".lock"
 
 	releaser, err := lockDownload(fileLock)
@@ -138,6 +142,10 @@ func ImageToCache(img string) error {
 		return nil
 	}
 
+	if offline {
+		return errors.Wrap(ErrOffline, img)
+	}
+
 	if err := os.MkdirAll(filepath.Dir(f), 0777); err != nil {
 		return errors.Wrapf(err, "making cache image directory: %s", f)
 	}
@@ -226,11 +234,11 @@ func ImageToCache(img string) error {
 	}
 }
 
-// GHKicbaseTarballToCache try to download the tarball of kicbase from github release.
+// GHKicbaseTarballToCache try to download the tarball of kicbase from github release, or from the artifact mirror if one is set.
 // This is the last resort, in case of all docker registry is not available.
 func GHKicbaseTarballToCache(kicBaseVersion string) (string, error) {
 	imageName := fmt.Sprintf("kicbase/stable:%s", kicBaseVersion)
-	f := imagePathInCache(imageName)
+	f := ImagePathInCache(imageName)
 	fileLock := f + ".lock"
 
 	kicbaseArch := runtime.GOARCH
@@ -248,10 +256,20 @@ func GHKicbaseTarballToCache(kicBaseVersion string) (string, error) {
 	downloadURL := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/%s/kicbase-%s-%s.tar",
 		version.GetVersion(),
 		kicBaseVersion, kicbaseArch)
+	src := downloadURL
+	if mirror.Enabled() {
+		downloadURL = mirror.URL("kicbase", fmt.Sprintf("kicbase-%s-%s.tar", kicBaseVersion, kicbaseArch))
+		src = mirror.WithChecksum(downloadURL)
+	}
+
+	if st, err := checkCache(f); err == nil && st.Size() > 0 {
+		klog.Infof("%s exists in cache, skipping download", imageName)
+		return downloadURL, nil
+	}
 
 	// we don't want the tarball to be decompressed
 	// so we pass client options to suppress this behavior
-	if err := download(downloadURL, f, getter.WithDecompressors(map[string]getter.Decompressor{})); err != nil {
+	if err := download(src, f, getter.WithDecompressors(map[string]getter.Decompressor{})); err != nil {
 		return "", err
 	}
 	return downloadURL, nil
@@ -285,7 +303,7 @@ func parseImage(img string) (*name.Tag, name.Reference, error) {
 // If online it will be: image:tag@sha256
 // If offline it will be: image:tag
 func CacheToDaemon(img string) (string, error) {
-	p := imagePathInCache(img)
+	p := ImagePathInCache(img)
 
 	tag, ref, err := parseImage(img)
 	if err != nil {
@@ -30,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
 	"k8s.io/minikube/pkg/util/lock"
@@ -41,6 +42,9 @@ const fileScheme = "file"
 // DefaultISOURLs returns a list of ISO URL's to consult by default, in priority order
 func DefaultISOURLs() []string {
 	v := version.GetISOVersion()
+	if mirror.Enabled() {
+		return []string{mirror.URL("iso", fmt.Sprintf("minikube-%s-%s.iso", v, runtime.GOARCH))}
+	}
 	isoBucket := "minikube-builds/iso/21834"
 
 	return []string{
@@ -24,12 +24,16 @@ import (
 	"os/exec"
 
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/version"
 )
 
 // licensesTarballURL returns the URL for the licenses tarball,
 // with a fallback to Google Cloud Storage.
 func licensesTarballURL() string {
+	if mirror.Enabled() {
+		return mirror.URL("minikube", "releases", version.GetVersion(), "licenses.tar.gz")
+	}
 	githubURL := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/%s/licenses.tar.gz", version.GetVersion())
 	gcsURL := fmt.Sprintf("https://storage.googleapis.com/minikube/releases/%s/licenses.tar.gz", version.GetVersion())
 
@@ -48,20 +52,16 @@ func licensesTarballURL() string {
 func Licenses(dir string) error {
 	url := licensesTarballURL()
 
-	resp, err := http.Get(url)
+	body, err := mirror.Open(url)
 	if err != nil {
 		return fmt.Errorf("failed to download licenses from %s: %v", url, err)
 	}
 	defer func() {
-		if err := resp.Body.Close(); err != nil {
+		if err := body.Close(); err != nil {
 			klog.Warningf("Failed to close response body: %v", err)
 		}
 	}()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("download request to %s did not return a 200, received: %d", url, resp.StatusCode)
-	}
-
 	tempFile, err := os.CreateTemp("", "licenses-*.tar.gz")
 	if err != nil {
 		return fmt.Errorf("failed to create temporary file: %v", err)
@@ -77,9 +77,14 @@ func Licenses(dir string) error {
 		}
 	}()
 
-	if _, err := io.Copy(tempFile, resp.Body); err != nil {
+	if _, err := io.Copy(tempFile, body); err != nil {
 		return fmt.Errorf("failed to copy downloaded content from %s: %v", url, err)
 	}
+	if mirror.Enabled() {
+		if err := mirror.Verify(tempFile.Name(), url); err != nil {
+			return fmt.Errorf("failed to verify licenses: %v", err)
+		}
+	}
 
 	// Create the directory if it doesn't exist
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -36,6 +36,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/download/gh"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
 )
@@ -58,6 +59,7 @@ const (
 	preloadSourceLocal  preloadSource = "local"
 	preloadSourceGCS    preloadSource = "gcs"
 	preloadSourceGitHub preloadSource = "github"
+	preloadSourceMirror preloadSource = "mirror"
 )
 
 type preloadState struct {
@@ -88,11 +90,66 @@ func targetDir() string {
 	return localpath.MakeMiniPath("cache", "preloaded-tarball")
 }
 
-// TarballPath returns the local path to the cached preload tarball
+// TarballPath returns the local path to the cached preload tarball, preferring a user-built one
 func TarballPath(k8sVersion, containerRuntime string) string {
+	if LocalPreloadExists(k8sVersion, containerRuntime) {
+		return LocalTarballPath(k8sVersion, containerRuntime)
+	}
+	return RemoteTarballPath(k8sVersion, containerRuntime)
+}
+
+// RemoteTarballPath returns the local path to the downloaded preload tarball
+func RemoteTarballPath(k8sVersion, containerRuntime string) string {
 	return filepath.Join(targetDir(), TarballName(k8sVersion, containerRuntime))
 }
 
+// returns target dir for preload tarballs built from a running cluster
+func localTargetDir() string {
+	return filepath.Join(targetDir(), "local")
+}
+
+// LocalTarballPath returns the path of the preload tarball built from a running cluster by `minikube cache preload create`
+func LocalTarballPath(k8sVersion, containerRuntime string) string {
+	return filepath.Join(localTargetDir(), TarballName(k8sVersion, containerRuntime))
+}
+
+// LocalPreloadExists returns true if there is a user-built preload tarball for the Kubernetes version and runtime,
+// built with the image repository of the cluster
+func LocalPreloadExists(k8sVersion, containerRuntime string) bool {
+	f, err := os.Stat(LocalTarballPath(k8sVersion, containerRuntime))
+	if err != nil || f.Size() == 0 {
+		return false
+	}
+	if repo := LocalPreloadRepository(k8sVersion, containerRuntime); repo != imageRepository {
+		klog.Infof("Ignoring user-built preload %s, built with image repository %q instead of %q", f.Name(), repo, imageRepository)
+		return false
+	}
+	return true
+}
+
+// localRepositoryPath returns the path of the file recording the image repository a user-built preload was built with
+func localRepositoryPath(k8sVersion, containerRuntime string) string {
+	return LocalTarballPath(k8sVersion, containerRuntime) + ".repository"
+}
+
+// LocalPreloadRepository returns the image repository a user-built preload was built with, empty for the default one
+func LocalPreloadRepository(k8sVersion, containerRuntime string) string {
+	data, err := os.ReadFile(localRepositoryPath(k8sVersion, containerRuntime))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// SaveLocalPreloadRepository records the image repository a user-built preload was built with
+func SaveLocalPreloadRepository(k8sVersion, containerRuntime, repository string) error {
+	p := localRepositoryPath(k8sVersion, containerRuntime)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(p, []byte(repository+"\n"), 0644), "writing preload image repository")
+}
+
 // remoteTarballURLGCS returns the URL for the remote tarball in GCS
 func remoteTarballURLGCS(k8sVersion, containerRuntime string) string {
 	return fmt.Sprintf("https://%s/%s/%s/%s/%s", downloadHost, PreloadBucket, PreloadVersion, k8sVersion, TarballName(k8sVersion, containerRuntime))
@@ -103,8 +160,15 @@ func remoteTarballURLGitHub(k8sVersion, containerRuntime string) string {
 	return fmt.Sprintf("https://github.com/%s/%s/releases/download/%s/%s", PreloadGitHubOrg, PreloadGitHubRepo, PreloadVersion, TarballName(k8sVersion, containerRuntime))
 }
 
+// remoteTarballURLMirror returns the URL for the remote tarball on the artifact mirror
+func remoteTarballURLMirror(k8sVersion, containerRuntime string) string {
+	return mirror.URL("preload", PreloadVersion, k8sVersion, TarballName(k8sVersion, containerRuntime))
+}
+
 func remoteTarballURL(k8sVersion, containerRuntime string, source preloadSource) string {
 	switch source {
+	case preloadSourceMirror:
+		return remoteTarballURLMirror(k8sVersion, containerRuntime)
 	case preloadSourceGitHub:
 		return remoteTarballURLGitHub(k8sVersion, containerRuntime)
 	case preloadSourceGCS:
@@ -161,6 +225,17 @@ var checkRemotePreloadExistsGitHub = func(k8sVersion, containerRuntime string) b
 	return remotePreloadExists(url)
 }
 
+// this is a function variable so it can be overridden in tests
+var checkRemotePreloadExistsMirror = func(k8sVersion, containerRuntime string) bool {
+	url := remoteTarballURLMirror(k8sVersion, containerRuntime)
+	if err := mirror.Head(url); err != nil {
+		klog.Warningf("%s: %v", url, err)
+		return false
+	}
+	klog.Infof("Found remote preload: %s", url)
+	return true
+}
+
 // PreloadExistsGCS returns true if there is a preloaded tarball in GCS that can be used
 func PreloadExistsGCS(k8sVersion, containerRuntime string) bool {
 	return checkRemotePreloadExistsGCS(k8sVersion, containerRuntime)
@@ -192,6 +267,13 @@ func PreloadExists(k8sVersion, containerRuntime, driverName string, forcePreload
 		return false
 	}
 
+	// User-built preloads take precedence over the official ones, and over any cached remote check
+	if LocalPreloadExists(k8sVersion, containerRuntime) {
+		klog.Infof("Found user-built preload: %s", LocalTarballPath(k8sVersion, containerRuntime))
+		setPreloadState(k8sVersion, containerRuntime, preloadState{exists: true, source: preloadSourceLocal})
+		return true
+	}
+
 	// If the preload existence is cached, just return that value.
 	if state, ok := getPreloadState(k8sVersion, containerRuntime); ok {
 		return state.exists
@@ -205,6 +287,23 @@ func PreloadExists(k8sVersion, containerRuntime, driverName string, forcePreload
 		return true
 	}
 
+	if offline {
+		klog.Infof("Not checking for a remote preload in offline mode")
+		setPreloadState(k8sVersion, containerRuntime, preloadState{exists: false, source: preloadSourceNone})
+		return false
+	}
+
+	// The artifact mirror replaces all other sources
+	if mirror.Enabled() {
+		exists := checkRemotePreloadExistsMirror(k8sVersion, containerRuntime)
+		source := preloadSourceNone
+		if exists {
+			source = preloadSourceMirror
+		}
+		setPreloadState(k8sVersion, containerRuntime, preloadState{exists: exists, source: source})
+		return exists
+	}
+
 	if PreloadExistsGCS(k8sVersion, containerRuntime) {
 		setPreloadState(k8sVersion, containerRuntime, preloadState{exists: true, source: preloadSourceGCS})
 		return true
@@ -257,6 +356,10 @@ func Preload(k8sVersion, containerRuntime, driverName string) error {
 	checksum, chksErr = getChecksum(source, k8sVersion, containerRuntime)
 
 	var realPath string
+	if chksErr != nil && source == preloadSourceMirror {
+		// the artifact mirror must publish the checksums of its artifacts
+		return errors.Wrap(chksErr, "checksum")
+	}
 	if chksErr != nil {
 		klog.Warningf("No checksum for preloaded tarball for k8s version %s: %v", k8sVersion, chksErr)
 		realPath = targetPath
@@ -296,6 +399,9 @@ func addChecksumToURL(url string, ps preloadSource, checksum []byte) string {
 	case preloadSourceGitHub: // GCS API gives us sha256
 		url += fmt.Sprintf("?checksum=sha256:%s", checksum)
 		klog.Infof("Got checksum from Github API %q", checksum)
+	case preloadSourceMirror:
+		url += fmt.Sprintf("?checksum=sha256:%s", checksum)
+		klog.Infof("Got checksum from artifact mirror %q", checksum)
 	}
 	return url
 }
@@ -334,8 +440,20 @@ var getChecksumGithub = func(k8sVersion, containerRuntime string) ([]byte, error
 	return gh.AssetSHA256(TarballName(k8sVersion, containerRuntime), assets)
 }
 
+// getChecksumMirror returns the SHA256 checksum of the preload tarball, as published on the artifact mirror
+var getChecksumMirror = func(k8sVersion, containerRuntime string) ([]byte, error) {
+	klog.Infof("getting checksum for %s from artifact mirror...", TarballName(k8sVersion, containerRuntime))
+	sum, err := mirror.Checksum(remoteTarballURLMirror(k8sVersion, containerRuntime))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(sum), nil
+}
+
 func getChecksum(ps preloadSource, k8sVersion, containerRuntime string) ([]byte, error) {
 	switch ps {
+	case preloadSourceMirror:
+		return getChecksumMirror(k8sVersion, containerRuntime)
 	case preloadSourceGCS:
 		return getChecksumGCS(k8sVersion, containerRuntime)
 	case preloadSourceGitHub:
@@ -349,23 +467,28 @@ func getChecksum(ps preloadSource, k8sVersion, containerRuntime string) ([]byte,
 // checks the current preload version and then if the saved tar file is belongs to older minikube it will delete it
 // in case of failure only logs to the user
 func CleanUpOlderPreloads() {
-	files, err := os.ReadDir(targetDir())
-	if err != nil {
-		klog.Warningf("Failed to list preload files: %v", err)
-	}
-
-	for _, file := range files {
-		split := strings.Split(file.Name(), "-")
-		if len(split) < 4 {
+	for _, dir := range []string{targetDir(), localTargetDir()} {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			if !os.IsNotExist(err) || dir == targetDir() {
+				klog.Warningf("Failed to list preload files: %v", err)
+			}
 			continue
 		}
-		ver := split[3]
-		if ver != PreloadVersion {
-			fn := path.Join(targetDir(), file.Name())
-			klog.Infof("deleting older generation preload %s", fn)
-			err := os.Remove(fn)
-			if err != nil {
-				klog.Warningf("Failed to clean up older preload files, consider running `minikube delete --all --purge`")
+
+		for _, file := range files {
+			split := strings.Split(file.Name(), "-")
+			if len(split) < 4 {
+				continue
+			}
+			ver := split[3]
+			if ver != PreloadVersion {
+				fn := path.Join(dir, file.Name())
+				klog.Infof("deleting older generation preload %s", fn)
+				err := os.Remove(fn)
+				if err != nil {
+					klog.Warningf("Failed to clean up older preload files, consider running `minikube delete --all --purge`")
+				}
 			}
 		}
 	}
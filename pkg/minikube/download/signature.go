@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+	"k8s.io/klog/v2"
+)
+
+// signingPublicKey is the minisign public key downloads are verified against unless one is configured,
+// set at build time with -ldflags="-X k8s.io/minikube/pkg/minikube/download.signingPublicKey=<key>"
+var signingPublicKey = ""
+
+// SignatureSuffix is the suffix of the detached signature of an artifact, published next to it
+const SignatureSuffix = ".minisig"
+
+// signingKey is the key downloads are verified against, nil if signatures are not verified
+var signingKey *publicKey
+
+func init() {
+	if err := SetSigningKey(""); err != nil {
+		klog.Errorf("invalid signing key: %v", err)
+	}
+}
+
+// ErrSignature is wrapped by the errors of downloads whose signature does not verify
+var ErrSignature = errors.New("signature verification failed")
+
+// publicKey is a minisign public key
+type publicKey struct {
+	id  [8]byte
+	key ed25519.PublicKey
+}
+
+// SetSigningKey sets the minisign public key downloads are verified against, either the key itself or the path of a file containing it.
+// An empty key restores the key built into minikube, if any.
+func SetSigningKey(key string) error {
+	if key == "" {
+		signingKey = nil
+		if signingPublicKey != "" {
+			k, err := parsePublicKey(signingPublicKey)
+			if err != nil {
+				return errors.Wrap(err, "built-in signing key")
+			}
+			signingKey = k
+		}
+		return nil
+	}
+	if data, err := os.ReadFile(key); err == nil {
+		key = string(data)
+	}
+	k, err := parsePublicKey(key)
+	if err != nil {
+		return err
+	}
+	signingKey = k
+	return nil
+}
+
+// ValidateSigningKey returns an error if a key, or the file it is in, is not a minisign public key
+func ValidateSigningKey(key string) error {
+	if data, err := os.ReadFile(key); err == nil {
+		key = string(data)
+	}
+	_, err := parsePublicKey(key)
+	return err
+}
+
+// lastLine returns the last non-empty line of s, skipping the untrusted comment of a minisign key file
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// parsePublicKey parses a minisign public key, alone or in the format of a minisign key file
+func parsePublicKey(s string) (*publicKey, error) {
+	b, err := base64.StdEncoding.DecodeString(lastLine(s))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding minisign public key")
+	}
+	if len(b) != 2+8+ed25519.PublicKeySize || string(b[:2]) != "Ed" {
+		return nil, fmt.Errorf("not a minisign public key")
+	}
+	k := &publicKey{key: ed25519.PublicKey(b[10:])}
+	copy(k.id[:], b[2:10])
+	return k, nil
+}
+
+// signature is a minisign signature
+type signature struct {
+	algorithm      string
+	keyID          [8]byte
+	sig            []byte
+	trustedComment string
+	globalSig      []byte
+}
+
+// parseSignature parses a minisign signature file
+func parseSignature(data []byte) (*signature, error) {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 {
+		return nil, fmt.Errorf("not a minisign signature: %d lines", len(lines))
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding signature")
+	}
+	if len(b) != 2+8+ed25519.SignatureSize {
+		return nil, fmt.Errorf("not a minisign signature")
+	}
+	tc, ok := strings.CutPrefix(strings.TrimSpace(lines[2]), "trusted comment: ")
+	if !ok {
+		return nil, fmt.Errorf("not a minisign signature: missing trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding global signature")
+	}
+	s := &signature{algorithm: string(b[:2]), sig: b[10:], trustedComment: tc, globalSig: global}
+	copy(s.keyID[:], b[2:10])
+	return s, nil
+}
+
+// verify verifies a file against a minisign signature
+func (k *publicKey) verify(file string, s *signature) error {
+	if s.keyID != k.id {
+		return fmt.Errorf("signed with key %X, not %X", s.keyID, k.id)
+	}
+	// legacy signatures of the whole content are not supported, as they need the artifact in memory
+	if s.algorithm != "ED" {
+		return fmt.Errorf("unsupported signature algorithm %q, sign with a pre-hashed signature", s.algorithm)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h, err := blake2b.New512(nil)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Wrapf(err, "hashing %s", file)
+	}
+	if !ed25519.Verify(k.key, h.Sum(nil), s.sig) {
+		return fmt.Errorf("invalid signature")
+	}
+	if !ed25519.Verify(k.key, append(bytes.Clone(s.sig), s.trustedComment...), s.globalSig) {
+		return fmt.Errorf("invalid signature of the trusted comment")
+	}
+	return nil
+}
+
+// signatureURL returns the URL of the detached signature of the artifact at src, without go-getter parameters
+func signatureURL(src string) (*url.URL, error) {
+	u, err := url.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Del("checksum")
+	u.RawQuery = q.Encode()
+	u.Path += SignatureSuffix
+	u.RawPath = ""
+	return u, nil
+}
+
+// fetchSignature returns the detached signature of the artifact at src
+func fetchSignature(src string) ([]byte, error) {
+	u, err := signatureURL(src)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "", fileScheme:
+		return os.ReadFile(u.Path)
+	case "http", "https":
+		resp, err := http.Get(u.String())
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("getting %s: status code %d", u, resp.StatusCode)
+		}
+		return io.ReadAll(io.LimitReader(resp.Body, 4096))
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+}
+
+// VerifyImagePinned returns an error wrapping ErrSignature if a signing key is set and img is not pinned by digest.
+// Images pulled from a registry have no detached signature, and are only verified against the digest they are pinned by.
+func VerifyImagePinned(img string) error {
+	if signingKey == nil {
+		return nil
+	}
+	if _, err := name.NewDigest(img); err != nil {
+		return errors.Wrapf(ErrSignature, "%s is not pinned by digest, which the signing key requires", img)
+	}
+	return nil
+}
+
+// verifySignature verifies a file downloaded from src against the detached signature published next to it,
+// if a signing key is set
+func verifySignature(src string, file string) error {
+	if signingKey == nil {
+		return nil
+	}
+	data, err := fetchSignature(src)
+	if err != nil {
+		return errors.Wrapf(ErrSignature, "%s: fetching signature: %v", src, err)
+	}
+	s, err := parseSignature(data)
+	if err != nil {
+		return errors.Wrapf(ErrSignature, "%s: %v", src, err)
+	}
+	if err := signingKey.verify(file, s); err != nil {
+		return errors.Wrapf(ErrSignature, "%s: %v", src, err)
+	}
+	klog.Infof("verified the signature of %s: %s", src, s.trustedComment)
+	return nil
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package download
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// testSigningKey is a minisign key pair generated for a test
+type testSigningKey struct {
+	id   [8]byte
+	priv ed25519.PrivateKey
+	pub  ed25519.PublicKey
+}
+
+func newTestSigningKey(t *testing.T) *testSigningKey {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &testSigningKey{priv: priv, pub: pub}
+	if _, err := rand.Read(k.id[:]); err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+// publicKeyFile returns the public key in the format of a minisign key file
+func (k *testSigningKey) publicKeyFile() string {
+	b := append(append([]byte("Ed"), k.id[:]...), k.pub...)
+	return fmt.Sprintf("untrusted comment: minisign public key %X\n%s\n", k.id, base64.StdEncoding.EncodeToString(b))
+}
+
+// sign returns a pre-hashed minisign signature of content
+func (k *testSigningKey) sign(content []byte) []byte {
+	h := blake2b.Sum512(content)
+	sig := ed25519.Sign(k.priv, h[:])
+	trusted := "timestamp:1700000000\tfile:artifact\thashed"
+	global := ed25519.Sign(k.priv, append(append([]byte{}, sig...), trusted...))
+	b := append(append([]byte("ED"), k.id[:]...), sig...)
+	return []byte(fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(b), trusted, base64.StdEncoding.EncodeToString(global)))
+}
+
+func TestDownloadSignature(t *testing.T) {
+	key := newTestSigningKey(t)
+	other := newTestSigningKey(t)
+	content := []byte("minikube.iso")
+
+	tests := []struct {
+		name      string
+		key       *testSigningKey
+		signature []byte
+		wantErr   bool
+	}{
+		{name: "Valid", key: key, signature: key.sign(content)},
+		{name: "Tampered", key: key, signature: key.sign([]byte("something else")), wantErr: true},
+		{name: "OtherKey", key: key, signature: other.sign(content), wantErr: true},
+		{name: "Missing", key: key, wantErr: true},
+		{name: "Garbage", key: key, signature: []byte("not a signature"), wantErr: true},
+		{name: "NoKey"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			src := filepath.Join(dir, "artifact")
+			if err := os.WriteFile(src, content, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if tc.signature != nil {
+				if err := os.WriteFile(src+SignatureSuffix, tc.signature, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			setKey := ""
+			if tc.key != nil {
+				setKey = filepath.Join(dir, "minisign.pub")
+				if err := os.WriteFile(setKey, []byte(tc.key.publicKeyFile()), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := SetSigningKey(setKey); err != nil {
+				t.Fatalf("SetSigningKey() = %v", err)
+			}
+			defer func() { _ = SetSigningKey("") }()
+
+			dst := filepath.Join(dir, "cache", "artifact")
+			err := download("file://"+filepath.ToSlash(src), dst)
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("download() = %v", err)
+				}
+				if _, err := os.Stat(dst); err != nil {
+					t.Errorf("expected the artifact to be downloaded: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrSignature) {
+				t.Fatalf("download() = %v, want a signature error", err)
+			}
+			for _, f := range []string{dst, dst + ".download"} {
+				if _, err := os.Lstat(f); !os.IsNotExist(err) {
+					t.Errorf("expected %s to be removed after a failed verification, got %v", f, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSetSigningKey(t *testing.T) {
+	key := newTestSigningKey(t)
+	defer func() { _ = SetSigningKey("") }()
+
+	if err := SetSigningKey(lastLine(key.publicKeyFile())); err != nil {
+		t.Errorf("expected a bare public key to be accepted: %v", err)
+	}
+	if signingKey == nil || signingKey.id != key.id {
+		t.Errorf("expected the signing key to be set")
+	}
+	if err := SetSigningKey("RWQ="); err == nil {
+		t.Errorf("expected an invalid public key to be rejected")
+	}
+	if err := SetSigningKey(""); err != nil || signingKey != nil {
+		t.Errorf("expected an empty key to disable verification, got %v", err)
+	}
+}
+
+func TestVerifyImagePinned(t *testing.T) {
+	pinned := "gcr.io/k8s-minikube/kicbase:v0.0.48@sha256:" + strings.Repeat("ab", 32)
+	unpinned := "gcr.io/k8s-minikube/kicbase:v0.0.48"
+	if err := VerifyImagePinned(unpinned); err != nil {
+		t.Errorf("expected any image to be accepted without a signing key: %v", err)
+	}
+
+	key := newTestSigningKey(t)
+	if err := SetSigningKey(key.publicKeyFile()); err != nil {
+		t.Fatalf("SetSigningKey() = %v", err)
+	}
+	defer func() { _ = SetSigningKey("") }()
+	if err := VerifyImagePinned(pinned); err != nil {
+		t.Errorf("VerifyImagePinned(%s) = %v", pinned, err)
+	}
+	if err := VerifyImagePinned(unpinned); !errors.Is(err, ErrSignature) {
+		t.Errorf("VerifyImagePinned(%s) = %v, want %v", unpinned, err, ErrSignature)
+	}
+	if err := ImageToCache(unpinned); !errors.Is(err, ErrSignature) {
+		t.Errorf("ImageToCache(%s) = %v, want %v", unpinned, err, ErrSignature)
+	}
+}
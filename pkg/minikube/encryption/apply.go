@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"fmt"
+	"os/exec"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/util/retry"
+)
+
+// apiServerRestartTimeout is how long an apiserver may take to be ready again after a restart
+const apiServerRestartTimeout = 3 * time.Minute
+
+// Asset returns the encryption configuration as a file to copy to the control-plane nodes
+func (c *Config) Asset() (assets.CopyableFile, error) {
+	data, err := c.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return assets.NewMemoryAssetTarget(data, GuestConfig, "0600"), nil
+}
+
+// kubectl returns the command running the kubectl of a Kubernetes version on a node
+func kubectl(k8sVersion string, args string) string {
+	return fmt.Sprintf("sudo %s --kubeconfig=%s %s",
+		path.Join(vmpath.GuestPersistentDir, "binaries", k8sVersion, "kubectl"), path.Join(vmpath.GuestPersistentDir, "kubeconfig"), args)
+}
+
+// Apply copies an encryption configuration to a control-plane node, and restarts its apiserver to load it
+func Apply(r command.Runner, cr cruntime.Manager, c *Config, k8sVersion string, apiServerPort int) error {
+	f, err := c.Asset()
+	if err != nil {
+		return errors.Wrap(err, "encryption configuration")
+	}
+	if err := r.Copy(f); err != nil {
+		return errors.Wrap(err, "copying encryption configuration")
+	}
+
+	// the apiserver only reads its encryption configuration when it starts, the kubelet restarts it once stopped
+	ids, err := cr.ListContainers(cruntime.ListContainersOptions{State: cruntime.Running, Name: "kube-apiserver"})
+	if err != nil {
+		return errors.Wrap(err, "listing apiserver containers")
+	}
+	if len(ids) == 0 {
+		return fmt.Errorf("the apiserver is not running")
+	}
+	if err := cr.StopContainers(ids); err != nil {
+		return errors.Wrap(err, "stopping apiserver")
+	}
+
+	ready := func() error {
+		_, err := r.RunCmd(exec.Command("/bin/bash", "-c", kubectl(k8sVersion, fmt.Sprintf("--server=https://localhost:%d get --raw=/readyz", apiServerPort))))
+		return err
+	}
+	if err := retry.Expo(ready, time.Second, apiServerRestartTimeout); err != nil {
+		return errors.Wrap(err, "waiting for the apiserver to restart")
+	}
+	klog.Infof("apiserver restarted with encryption keys %v", c.keyNames())
+	return nil
+}
+
+// Reencrypt rewrites every secret of a cluster, so that they are encrypted with the first key of the apiservers
+func Reencrypt(r command.Runner, k8sVersion string) error {
+	c := fmt.Sprintf("%s | %s", kubectl(k8sVersion, "get secrets --all-namespaces -o json"), kubectl(k8sVersion, "replace -f -"))
+	if _, err := r.RunCmd(exec.Command("/bin/bash", "-c", c)); err != nil {
+		return errors.Wrap(err, "re-encrypting secrets")
+	}
+	return nil
+}
+
+func (c *Config) keyNames() []string {
+	names := []string{}
+	for _, k := range c.Keys {
+		names = append(names, k.Provider+"/"+k.Name)
+	}
+	return names
+}
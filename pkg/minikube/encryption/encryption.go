@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encryption manages the encryption at rest of the Secrets of a cluster.
+//
+// The EncryptionConfiguration of the apiserver is generated in the profile directory, which is
+// the source of truth copied to the control-plane nodes on every start. The first key encrypts,
+// the others only decrypt, and the identity provider comes last so that Secrets written before
+// the encryption was enabled can still be read.
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+const (
+	// AESCBC encrypts with AES-CBC and PKCS#7 padding
+	AESCBC = "aescbc"
+	// Secretbox encrypts with XSalsa20 and Poly1305
+	Secretbox = "secretbox"
+)
+
+// Providers are the supported encryption providers
+var Providers = []string{AESCBC, Secretbox}
+
+var (
+	// GuestDir is where the encryption configuration is copied to on the control-plane nodes
+	GuestDir = "/etc/kubernetes/encryption"
+	// GuestConfig is the encryption configuration of the apiserver on the control-plane nodes
+	GuestConfig = path.Join(GuestDir, "config.yaml")
+)
+
+// Key is an encryption key
+type Key struct {
+	Provider string
+	Name     string
+	Secret   string
+}
+
+// Config is the encryption configuration of the Secrets of a cluster
+type Config struct {
+	// Keys are the encryption keys, the first one encrypts
+	Keys []Key
+}
+
+// Path returns the path of the encryption configuration of a profile
+func Path(profile string) string {
+	return filepath.Join(localpath.Profile(profile), "encryption-config.yaml")
+}
+
+// ValidateProvider returns an error if a provider is not supported
+func ValidateProvider(provider string) error {
+	for _, p := range Providers {
+		if p == provider {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported encryption provider %q, supported providers are %s", provider, strings.Join(Providers, ", "))
+}
+
+// NewKey returns a new random 32 bytes key for a provider
+func NewKey(provider string) (Key, error) {
+	if err := ValidateProvider(provider); err != nil {
+		return Key{}, err
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return Key{}, errors.Wrap(err, "generating key")
+	}
+	return Key{
+		Provider: provider,
+		Name:     fmt.Sprintf("key-%d", time.Now().UnixNano()),
+		Secret:   base64.StdEncoding.EncodeToString(b),
+	}, nil
+}
+
+// encryptionConfiguration is the apiserver.config.k8s.io/v1 EncryptionConfiguration
+type encryptionConfiguration struct {
+	APIVersion string                  `yaml:"apiVersion"`
+	Kind       string                  `yaml:"kind"`
+	Resources  []resourceConfiguration `yaml:"resources"`
+}
+
+type resourceConfiguration struct {
+	Resources []string                           `yaml:"resources"`
+	Providers []map[string]providerConfiguration `yaml:"providers"`
+}
+
+type providerConfiguration struct {
+	Keys []keyConfiguration `yaml:"keys,omitempty"`
+}
+
+type keyConfiguration struct {
+	Name   string `yaml:"name"`
+	Secret string `yaml:"secret"`
+}
+
+// Marshal returns the EncryptionConfiguration of the apiserver
+func (c *Config) Marshal() ([]byte, error) {
+	providers := []map[string]providerConfiguration{}
+	for _, k := range c.Keys {
+		kc := keyConfiguration{Name: k.Name, Secret: k.Secret}
+		// consecutive keys of a provider are listed in the same provider
+		if last := len(providers) - 1; last >= 0 {
+			if p, ok := providers[last][k.Provider]; ok {
+				p.Keys = append(p.Keys, kc)
+				providers[last][k.Provider] = p
+				continue
+			}
+		}
+		providers = append(providers, map[string]providerConfiguration{k.Provider: {Keys: []keyConfiguration{kc}}})
+	}
+	providers = append(providers, map[string]providerConfiguration{"identity": {}})
+	return yaml.Marshal(encryptionConfiguration{
+		APIVersion: "apiserver.config.k8s.io/v1",
+		Kind:       "EncryptionConfiguration",
+		Resources:  []resourceConfiguration{{Resources: []string{"secrets"}, Providers: providers}},
+	})
+}
+
+// Parse parses an EncryptionConfiguration generated by Marshal
+func Parse(data []byte) (*Config, error) {
+	ec := encryptionConfiguration{}
+	if err := yaml.Unmarshal(data, &ec); err != nil {
+		return nil, errors.Wrap(err, "parsing encryption configuration")
+	}
+	if ec.Kind != "EncryptionConfiguration" || len(ec.Resources) != 1 {
+		return nil, fmt.Errorf("not an encryption configuration of secrets")
+	}
+	c := &Config{}
+	for _, p := range ec.Resources[0].Providers {
+		for provider, pc := range p {
+			if provider == "identity" {
+				continue
+			}
+			if err := ValidateProvider(provider); err != nil {
+				return nil, err
+			}
+			for _, k := range pc.Keys {
+				c.Keys = append(c.Keys, Key{Provider: provider, Name: k.Name, Secret: k.Secret})
+			}
+		}
+	}
+	if len(c.Keys) == 0 {
+		return nil, fmt.Errorf("no encryption key")
+	}
+	return c, nil
+}
+
+// Load returns the encryption configuration of a profile
+func Load(profile string) (*Config, error) {
+	data, err := os.ReadFile(Path(profile))
+	if err != nil {
+		return nil, errors.Wrap(err, "reading encryption configuration")
+	}
+	return Parse(data)
+}
+
+// Save writes the encryption configuration of a profile
+func Save(profile string, c *Config) error {
+	data, err := c.Marshal()
+	if err != nil {
+		return errors.Wrap(err, "marshalling encryption configuration")
+	}
+	p := Path(profile)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "writing encryption configuration")
+	}
+	return os.Rename(tmp, p)
+}
+
+// Ensure returns the encryption configuration of a profile, generating one with a new key of a provider if there is none
+func Ensure(profile string, provider string) (*Config, error) {
+	if err := ValidateProvider(provider); err != nil {
+		return nil, err
+	}
+	c, err := Load(profile)
+	if err == nil {
+		// the rotation is resumed once the cluster is running, whichever provider it was started with
+		if k, ok := c.Pending(); ok {
+			klog.Infof("resuming the rotation of the encryption key to %s key %s", k.Provider, k.Name)
+			return c, nil
+		}
+		if c.Keys[0].Provider != provider {
+			return nil, fmt.Errorf("secrets are encrypted with %s, run 'minikube secrets rotate-key --provider=%s' to change the provider", c.Keys[0].Provider, provider)
+		}
+		return c, nil
+	}
+	if _, statErr := os.Stat(Path(profile)); statErr == nil {
+		return nil, err
+	}
+	k, err := NewKey(provider)
+	if err != nil {
+		return nil, err
+	}
+	c = &Config{Keys: []Key{k}}
+	if err := Save(profile, c); err != nil {
+		return nil, err
+	}
+	klog.Infof("generated %s encryption key %s for profile %s", provider, k.Name, profile)
+	return c, nil
+}
+
+// Pending returns the new key of an interrupted rotation, the most recent of the keys of a configuration
+// left with several keys
+func (c *Config) Pending() (Key, bool) {
+	if len(c.Keys) < 2 {
+		return Key{}, false
+	}
+	newest := c.Keys[0]
+	for _, k := range c.Keys[1:] {
+		if keyTime(k) > keyTime(newest) {
+			newest = k
+		}
+	}
+	return newest, true
+}
+
+// keyTime returns when a key was generated by NewKey, 0 if it was not
+func keyTime(k Key) int64 {
+	t, err := strconv.ParseInt(strings.TrimPrefix(k.Name, "key-"), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return t
+}
+
+// RotationStep is a step of a key rotation
+type RotationStep struct {
+	// Description describes the step
+	Description string
+	// Config is the encryption configuration to apply, nil to re-encrypt the secrets
+	Config *Config
+}
+
+// RotationSteps returns the steps rotating the encryption key of a configuration to a new key:
+// every apiserver learns the new key before any encrypts with it, and the old keys are only removed
+// once the secrets are re-encrypted with it.
+func RotationSteps(c *Config, newKey Key) []RotationStep {
+	return []RotationStep{
+		{Description: "Adding the new key", Config: &Config{Keys: append(append([]Key{}, c.Keys...), newKey)}},
+		{Description: "Encrypting with the new key", Config: &Config{Keys: append([]Key{newKey}, c.Keys...)}},
+		{Description: "Re-encrypting the secrets"},
+		{Description: "Removing the old keys", Config: &Config{Keys: []Key{newKey}}},
+	}
+}
+
+// ResumeSteps returns the steps completing an interrupted rotation of a configuration: every apiserver
+// already knows the new key, so the rotation resumes by encrypting with it.
+func ResumeSteps(c *Config) []RotationStep {
+	newKey, ok := c.Pending()
+	if !ok {
+		return nil
+	}
+	old := &Config{}
+	for _, k := range c.Keys {
+		if k != newKey {
+			old.Keys = append(old.Keys, k)
+		}
+	}
+	return RotationSteps(old, newKey)[1:]
+}
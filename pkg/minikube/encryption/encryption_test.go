@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encryption
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestMarshal(t *testing.T) {
+	c := &Config{Keys: []Key{
+		{Provider: Secretbox, Name: "key-3", Secret: "c2VjcmV0Mw=="},
+		{Provider: AESCBC, Name: "key-2", Secret: "c2VjcmV0Mg=="},
+		{Provider: AESCBC, Name: "key-1", Secret: "c2VjcmV0MQ=="},
+	}}
+	data, err := c.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+	want := `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- resources:
+  - secrets
+  providers:
+  - secretbox:
+      keys:
+      - name: key-3
+        secret: c2VjcmV0Mw==
+  - aescbc:
+      keys:
+      - name: key-2
+        secret: c2VjcmV0Mg==
+      - name: key-1
+        secret: c2VjcmV0MQ==
+  - identity: {}
+`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse() = %v", err)
+	}
+	if !reflect.DeepEqual(got, c) {
+		t.Errorf("Parse() = %+v, want %+v", got, c)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"not yaml":         "{",
+		"other kind":       "kind: Pod",
+		"unknown provider": "kind: EncryptionConfiguration\nresources:\n- resources: [secrets]\n  providers:\n  - kms: {keys: [{name: k, secret: s}]}\n",
+		"no key":           "kind: EncryptionConfiguration\nresources:\n- resources: [secrets]\n  providers:\n  - identity: {}\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(data)); err == nil {
+				t.Errorf("Parse(%q) did not fail", data)
+			}
+		})
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+
+	if _, err := Ensure("p1", "kms"); err == nil {
+		t.Errorf("Ensure() with an unsupported provider did not fail")
+	}
+	c, err := Ensure("p1", AESCBC)
+	if err != nil {
+		t.Fatalf("Ensure() = %v", err)
+	}
+	if len(c.Keys) != 1 || c.Keys[0].Provider != AESCBC {
+		t.Fatalf("Ensure() = %+v, want one aescbc key", c)
+	}
+	info, err := os.Stat(Path("p1"))
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	again, err := Ensure("p1", AESCBC)
+	if err != nil {
+		t.Fatalf("Ensure() = %v", err)
+	}
+	if !reflect.DeepEqual(again, c) {
+		t.Errorf("Ensure() regenerated the key: %+v, want %+v", again, c)
+	}
+
+	if _, err := Ensure("p1", Secretbox); err == nil || !strings.Contains(err.Error(), "rotate-key --provider=secretbox") {
+		t.Errorf("Ensure() with another provider = %v, want a rotate-key suggestion", err)
+	}
+
+	// a rotation to secretbox interrupted once the apiservers encrypt with the new key
+	newKey, err := NewKey(Secretbox)
+	if err != nil {
+		t.Fatalf("NewKey() = %v", err)
+	}
+	rotated := &Config{Keys: []Key{newKey, c.Keys[0]}}
+	if err := Save("p1", rotated); err != nil {
+		t.Fatalf("Save() = %v", err)
+	}
+	resumed, err := Ensure("p1", AESCBC)
+	if err != nil {
+		t.Fatalf("Ensure() of an interrupted rotation = %v", err)
+	}
+	if !reflect.DeepEqual(resumed, rotated) {
+		t.Errorf("Ensure() of an interrupted rotation = %+v, want %+v", resumed, rotated)
+	}
+	if k, ok := resumed.Pending(); !ok || k != newKey {
+		t.Errorf("Pending() = %+v, %v, want %+v", k, ok, newKey)
+	}
+}
+
+func TestResumeSteps(t *testing.T) {
+	old := Key{Provider: AESCBC, Name: "key-1"}
+	newKey := Key{Provider: Secretbox, Name: "key-2"}
+
+	if steps := ResumeSteps(&Config{Keys: []Key{old}}); steps != nil {
+		t.Errorf("ResumeSteps() of a single key = %+v, want none", steps)
+	}
+
+	// the rotation is resumed the same way after the new key was added or made first
+	for _, c := range []*Config{{Keys: []Key{old, newKey}}, {Keys: []Key{newKey, old}}} {
+		steps := ResumeSteps(c)
+		want := [][]Key{{newKey, old}, nil, {newKey}}
+		if len(steps) != len(want) {
+			t.Fatalf("ResumeSteps(%+v) returned %d steps, want %d", c.Keys, len(steps), len(want))
+		}
+		for i, s := range steps {
+			if want[i] == nil {
+				if s.Config != nil {
+					t.Errorf("step %d: %+v, want a re-encryption", i, s.Config)
+				}
+				continue
+			}
+			if s.Config == nil || !reflect.DeepEqual(s.Config.Keys, want[i]) {
+				t.Errorf("step %d: %+v, want keys %+v", i, s.Config, want[i])
+			}
+		}
+	}
+}
+
+func TestRotationSteps(t *testing.T) {
+	old := Key{Provider: AESCBC, Name: "old"}
+	newKey := Key{Provider: Secretbox, Name: "new"}
+	steps := RotationSteps(&Config{Keys: []Key{old}}, newKey)
+
+	want := [][]Key{{old, newKey}, {newKey, old}, nil, {newKey}}
+	if len(steps) != len(want) {
+		t.Fatalf("RotationSteps() returned %d steps, want %d", len(steps), len(want))
+	}
+	for i, s := range steps {
+		if want[i] == nil {
+			if s.Config != nil {
+				t.Errorf("step %d: %+v, want a re-encryption", i, s.Config)
+			}
+			continue
+		}
+		if s.Config == nil || !reflect.DeepEqual(s.Config.Keys, want[i]) {
+			t.Errorf("step %d: %+v, want keys %+v", i, s.Config, want[i])
+		}
+	}
+}
@@ -0,0 +1,382 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hostcache inspects and garbage collects the artifacts minikube caches on the host,
+// below the cache directory of the minikube home directory.
+//
+// Artifacts referenced by an existing profile, or still being downloaded, are never evicted. The others are evicted
+// least recently used first, where an artifact is used when it is downloaded or when a
+// profile referencing it is started.
+package hostcache
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/mutex/v2"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/util/lock"
+)
+
+// Category is the kind of a cached artifact
+type Category string
+
+const (
+	// ISO is a virtual machine image
+	ISO Category = "iso"
+	// KicBase is a tarball of the base image of the docker and podman drivers
+	KicBase Category = "kicbase"
+	// Preload is a tarball of preloaded images
+	Preload Category = "preload"
+	// Binaries are the Kubernetes binaries of a version, for an os and architecture
+	Binaries Category = "binaries"
+	// Image is a container image added with 'minikube cache add', or cached for a cluster without a preload
+	Image Category = "image"
+	// Build is an image build context
+	Build Category = "build"
+	// Registry is the pull-through registry cache, which is pruned with 'minikube cache registry prune'
+	Registry Category = "registry"
+	// Other is any other file in the cache directory
+	Other Category = "other"
+)
+
+var (
+	// sidecarSuffixes are the suffixes of the files kept next to an artifact
+	sidecarSuffixes = []string{".lock", ".checksum", ".repository"}
+	// incompleteSuffixes are the suffixes of the files an artifact is written to while it is downloaded, innermost first
+	incompleteSuffixes = []string{".partial", ".download"}
+	// independentDownloadRE matches the suffix of the download of a process which gave up waiting for the download lock
+	independentDownloadRE = regexp.MustCompile(`\.\d+\.download$`)
+)
+
+// incompleteGracePeriod is how long after its last write an incomplete download may still be in progress
+const incompleteGracePeriod = time.Hour
+
+// ErrDownloading is returned when removing an artifact another minikube instance is downloading
+var ErrDownloading = errors.New("the artifact is being downloaded")
+
+// Artifact is a cached artifact, made of one or more files
+type Artifact struct {
+	Path     string    `json:"path"`
+	Category Category  `json:"category"`
+	Size     int64     `json:"size"`
+	LastUsed time.Time `json:"lastUsed"`
+	// UsedBy are the profiles referencing the artifact
+	UsedBy []string `json:"usedBy,omitempty"`
+	// Incomplete is true if the artifact was not fully downloaded
+	Incomplete bool `json:"incomplete,omitempty"`
+
+	files []string
+}
+
+// Dir returns the cache directory
+func Dir() string {
+	return localpath.MakeMiniPath("cache")
+}
+
+// category returns the category of a path relative to the cache directory, and the path of the artifact it belongs to
+func category(rel string) (Category, string) {
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	switch parts[0] {
+	case "iso":
+		return ISO, rel
+	case "kic":
+		return KicBase, rel
+	case "preloaded-tarball":
+		return Preload, rel
+	case "images":
+		return Image, rel
+	case "build":
+		return Build, rel
+	case "registry":
+		return Registry, "registry"
+	case "linux", "darwin", "windows":
+		// <os>/<arch>/<version>/<binary>
+		if len(parts) >= 4 {
+			return Binaries, filepath.Join(parts[:3]...)
+		}
+	}
+	return Other, rel
+}
+
+// artifactPath strips the suffixes of the sidecar files and incomplete downloads of an artifact from a path,
+// and returns whether the path is the artifact itself, and not a sidecar file or an incomplete download
+func artifactPath(p string) (string, bool) {
+	complete := true
+	for _, s := range sidecarSuffixes {
+		if strings.HasSuffix(p, s) {
+			p = strings.TrimSuffix(p, s)
+			complete = false
+			break
+		}
+	}
+	if independentDownloadRE.MatchString(p) {
+		p = independentDownloadRE.ReplaceAllString(p, "")
+		return p, false
+	}
+	for _, s := range incompleteSuffixes {
+		if strings.HasSuffix(p, s) {
+			p = strings.TrimSuffix(p, s)
+			complete = false
+		}
+	}
+	return p, complete
+}
+
+// List returns the artifacts cached in dir
+func List(dir string) ([]*Artifact, error) {
+	byPath := map[string]*Artifact{}
+	complete := map[string]bool{}
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		cat, rel := category(rel)
+		rel, isArtifact := artifactPath(rel)
+		ap := filepath.Join(dir, rel)
+		a, ok := byPath[ap]
+		if !ok {
+			a = &Artifact{Path: ap, Category: cat}
+			byPath[ap] = a
+		}
+		a.Size += info.Size()
+		if info.ModTime().After(a.LastUsed) {
+			a.LastUsed = info.ModTime()
+		}
+		a.files = append(a.files, p)
+		if isArtifact {
+			complete[ap] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing %s", dir)
+	}
+
+	artifacts := []*Artifact{}
+	for p, a := range byPath {
+		a.Incomplete = !complete[p]
+		artifacts = append(artifacts, a)
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Path < artifacts[j].Path })
+	return artifacts, nil
+}
+
+// References returns the paths of the cached artifacts a cluster uses
+func References(cc *config.ClusterConfig) []string {
+	refs := []string{}
+	if cc.MinikubeISO != "" {
+		refs = append(refs, filepath.FromSlash(strings.TrimPrefix(download.LocalISOResource(cc.MinikubeISO), "file://")))
+	}
+	if driver.IsKIC(cc.Driver) {
+		for _, img := range append([]string{cc.KicBaseImage, "kicbase/stable:" + strings.Split(kic.Version, "-")[0]}, kic.FallbackImages...) {
+			refs = append(refs, download.ImagePathInCache(img))
+		}
+	}
+
+	k8s := cc.KubernetesConfig
+	versions := map[string]bool{k8s.KubernetesVersion: true}
+	for _, n := range cc.Nodes {
+		if n.KubernetesVersion != "" {
+			versions[n.KubernetesVersion] = true
+		}
+	}
+	for v := range versions {
+		if v == "" || v == constants.NoKubernetesVersion {
+			continue
+		}
+		// not TarballPath, which depends on the image repository set by start only
+		refs = append(refs, download.RemoteTarballPath(v, k8s.ContainerRuntime))
+		// the user-built preload is only used by the clusters with the image repository it was built with
+		if download.LocalPreloadRepository(v, k8s.ContainerRuntime) == k8s.ImageRepository {
+			refs = append(refs, download.LocalTarballPath(v, k8s.ContainerRuntime))
+		}
+		refs = append(refs, localpath.MakeMiniPath("cache", "linux", runtime.GOARCH, v))
+		// kubectl for 'minikube kubectl'
+		refs = append(refs, localpath.MakeMiniPath("cache", runtime.GOOS, runtime.GOARCH, v))
+		imgs, err := images.Kubeadm(k8s.ImageRepository, v)
+		if err != nil {
+			klog.Warningf("failed to list the images of Kubernetes %s: %v", v, err)
+			continue
+		}
+		refs = append(refs, ImagePaths(imgs)...)
+	}
+	return refs
+}
+
+// ImagePaths returns the paths of images in the image cache
+func ImagePaths(imgs []string) []string {
+	paths := []string{}
+	for _, img := range imgs {
+		paths = append(paths, localpath.SanitizeCacheDir(filepath.Join(detect.ImageCacheDir(), img)))
+	}
+	return paths
+}
+
+// MarkUsed marks the cached artifacts a cluster uses as recently used
+func MarkUsed(cc *config.ClusterConfig) {
+	now := time.Now()
+	for _, ref := range References(cc) {
+		err := filepath.Walk(ref, func(p string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			return os.Chtimes(p, now, now)
+		})
+		if err != nil && !os.IsNotExist(err) {
+			klog.Warningf("failed to mark %s as used: %v", ref, err)
+		}
+	}
+}
+
+// Load returns the artifacts in the cache directory, with the profiles using them.
+// The images added with 'minikube cache add' are used by every profile.
+func Load(cachedImages []string) ([]*Artifact, error) {
+	artifacts, err := List(Dir())
+	if err != nil {
+		return nil, err
+	}
+	valid, _, err := config.ListProfiles()
+	if err != nil {
+		klog.Warningf("failed to list profiles: %v", err)
+	}
+
+	usedBy := map[string][]string{}
+	for _, p := range valid {
+		if p.Config == nil {
+			continue
+		}
+		refs := append(References(p.Config), ImagePaths(cachedImages)...)
+		for _, ref := range refs {
+			usedBy[ref] = append(usedBy[ref], p.Name)
+		}
+		if p.Config.RegistryCache {
+			usedBy[filepath.Join(Dir(), "registry")] = append(usedBy[filepath.Join(Dir(), "registry")], p.Name)
+		}
+	}
+	for _, a := range artifacts {
+		a.UsedBy = uniqueSorted(usedBy[a.Path])
+	}
+	return artifacts, nil
+}
+
+func uniqueSorted(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	sort.Strings(s)
+	u := s[:1]
+	for _, v := range s[1:] {
+		if v != u[len(u)-1] {
+			u = append(u, v)
+		}
+	}
+	return u
+}
+
+// Evictable returns true if an artifact may be evicted by the garbage collection.
+// Incomplete downloads written to recently may still be in progress, and are left alone.
+func (a *Artifact) Evictable() bool {
+	if a.Incomplete && time.Since(a.LastUsed) < incompleteGracePeriod {
+		return false
+	}
+	return len(a.UsedBy) == 0 && a.Category != Registry
+}
+
+// Plan returns the artifacts to evict so that the evictable artifacts fit in maxSize bytes,
+// least recently used first. The registry cache is not accounted for, as it has its own size cap.
+func Plan(artifacts []*Artifact, maxSize int64) []*Artifact {
+	var total int64
+	candidates := []*Artifact{}
+	for _, a := range artifacts {
+		if a.Category == Registry {
+			continue
+		}
+		total += a.Size
+		if a.Evictable() {
+			candidates = append(candidates, a)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].LastUsed.Before(candidates[j].LastUsed) })
+
+	evict := []*Artifact{}
+	for _, a := range candidates {
+		if total <= maxSize {
+			break
+		}
+		evict = append(evict, a)
+		total -= a.Size
+	}
+	return evict
+}
+
+// Remove removes the files of an artifact.
+// The artifact is only removed if the locks minikube takes while downloading it are free, otherwise ErrDownloading is returned.
+func Remove(a *Artifact) error {
+	locks := []string{a.Path + ".lock"}
+	if a.Incomplete {
+		locks = append(locks, a.Path+".download.lock")
+	}
+	for _, l := range locks {
+		spec := lock.PathMutexSpec(l)
+		spec.Timeout = time.Second
+		releaser, err := mutex.Acquire(spec)
+		if err != nil {
+			klog.Infof("unable to acquire lock for %+v: %v", spec, err)
+			return errors.Wrap(ErrDownloading, a.Path)
+		}
+		defer releaser.Release()
+	}
+	for _, f := range a.files {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "removing %s", f)
+		}
+	}
+	if a.Category == Binaries {
+		if err := os.RemoveAll(a.Path); err != nil {
+			return errors.Wrapf(err, "removing %s", a.Path)
+		}
+	}
+	klog.Infof("evicted %s (%d bytes) from the cache", a.Path, a.Size)
+	return nil
+}
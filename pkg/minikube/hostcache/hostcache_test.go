@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostcache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/juju/mutex/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/util/lock"
+)
+
+func writeCached(t *testing.T, dir string, rel string, size int, age time.Duration) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	mtime := time.Now().Add(-age)
+	if err := os.Chtimes(p, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListAndPlan(t *testing.T) {
+	dir := t.TempDir()
+	writeCached(t, dir, "iso/amd64/minikube-v1.0.0-amd64.iso", 400, 3*time.Hour)
+	writeCached(t, dir, "kic/amd64/kicbase_v0.0.1.tar", 300, time.Hour)
+	writeCached(t, dir, "kic/amd64/kicbase_v0.0.1.tar.lock", 0, time.Hour)
+	writeCached(t, dir, "preloaded-tarball/preloaded-images-k8s-v18-v1.30.0-docker-overlay2-amd64.tar.lz4.download", 50, 5*time.Hour)
+	writeCached(t, dir, "preloaded-tarball/preloaded-images-k8s-v18-v1.30.0-docker-overlay2-amd64.tar.lz4.checksum", 0, 5*time.Hour)
+	writeCached(t, dir, "linux/amd64/v1.30.0/kubelet", 100, 2*time.Hour)
+	writeCached(t, dir, "linux/amd64/v1.30.0/kubeadm", 100, 4*time.Hour)
+	writeCached(t, dir, "registry/blobs/sha256/ab", 1000, 10*time.Hour)
+
+	artifacts, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	byPath := map[string]*Artifact{}
+	for _, a := range artifacts {
+		rel, _ := filepath.Rel(dir, a.Path)
+		byPath[filepath.ToSlash(rel)] = a
+	}
+	want := map[string]Category{
+		"iso/amd64/minikube-v1.0.0-amd64.iso": ISO,
+		"kic/amd64/kicbase_v0.0.1.tar":        KicBase,
+		"preloaded-tarball/preloaded-images-k8s-v18-v1.30.0-docker-overlay2-amd64.tar.lz4": Preload,
+		"linux/amd64/v1.30.0": Binaries,
+		"registry":            Registry,
+	}
+	if len(byPath) != len(want) {
+		t.Fatalf("List() returned %d artifacts, want %d: %v", len(byPath), len(want), byPath)
+	}
+	for p, c := range want {
+		a, ok := byPath[p]
+		if !ok {
+			t.Fatalf("missing artifact %s", p)
+		}
+		if a.Category != c {
+			t.Errorf("%s: category %s, want %s", p, a.Category, c)
+		}
+	}
+	if a := byPath["linux/amd64/v1.30.0"]; a.Size != 200 || time.Since(a.LastUsed) > 3*time.Hour {
+		t.Errorf("binaries: size %d last used %s, want the size of all binaries and the most recent use", a.Size, a.LastUsed)
+	}
+	if !byPath["preloaded-tarball/preloaded-images-k8s-v18-v1.30.0-docker-overlay2-amd64.tar.lz4"].Incomplete {
+		t.Errorf("expected a download in progress to be incomplete")
+	}
+	if byPath["kic/amd64/kicbase_v0.0.1.tar"].Incomplete {
+		t.Errorf("expected a downloaded artifact with a lock file to be complete")
+	}
+
+	// the ISO is used by a profile, the rest of the cache is 750 bytes without the registry
+	byPath["iso/amd64/minikube-v1.0.0-amd64.iso"].UsedBy = []string{"p1"}
+	evict := Plan(artifacts, 500)
+	got := []string{}
+	for _, a := range evict {
+		rel, _ := filepath.Rel(dir, a.Path)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	wantEvict := []string{"preloaded-tarball/preloaded-images-k8s-v18-v1.30.0-docker-overlay2-amd64.tar.lz4", "linux/amd64/v1.30.0", "kic/amd64/kicbase_v0.0.1.tar"}
+	if len(got) != len(wantEvict) {
+		t.Fatalf("Plan() = %v, want %v", got, wantEvict)
+	}
+	for i := range got {
+		if got[i] != wantEvict[i] {
+			t.Errorf("Plan()[%d] = %s, want %s", i, got[i], wantEvict[i])
+		}
+	}
+	if len(Plan(artifacts, 1<<20)) != 0 {
+		t.Errorf("expected nothing to be evicted from a cache under its size")
+	}
+
+	for _, a := range evict {
+		if err := Remove(a); err != nil {
+			t.Fatalf("Remove(%s) = %v", a.Path, err)
+		}
+	}
+	left, err := List(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(left) != 2 {
+		t.Errorf("expected the ISO and the registry cache to be left, got %d artifacts", len(left))
+	}
+}
+
+func TestReferences(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	cc := &config.ClusterConfig{
+		Driver: "kvm2",
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.30.0",
+			ContainerRuntime:  "containerd",
+		},
+	}
+	refs := map[string]bool{}
+	for _, r := range References(cc) {
+		refs[r] = true
+	}
+	for _, want := range []string{
+		download.RemoteTarballPath("v1.30.0", "containerd"),
+		localpath.MakeMiniPath("cache", "linux", runtime.GOARCH, "v1.30.0"),
+		localpath.SanitizeCacheDir(filepath.Join(detect.ImageCacheDir(), "registry.k8s.io/pause:3.9")),
+	} {
+		if !refs[want] {
+			t.Errorf("References() does not contain %s", want)
+		}
+	}
+}
+
+func TestReferencesCustomRepository(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	// a user-built preload of the default image repository
+	local := download.LocalTarballPath("v1.30.0", "containerd")
+	if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(local, []byte("preload"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := download.SaveLocalPreloadRepository("v1.30.0", "containerd", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	refs := func(repo string) map[string]bool {
+		cc := &config.ClusterConfig{
+			Driver: "kvm2",
+			KubernetesConfig: config.KubernetesConfig{
+				KubernetesVersion: "v1.30.0",
+				ContainerRuntime:  "containerd",
+				ImageRepository:   repo,
+			},
+		}
+		m := map[string]bool{}
+		for _, r := range References(cc) {
+			m[r] = true
+		}
+		return m
+	}
+	remote := download.RemoteTarballPath("v1.30.0", "containerd")
+	if r := refs("registry.example.com"); !r[remote] || r[local] {
+		t.Errorf("References() of a custom image repository: downloaded preload %v, user-built preload %v; want true, false", r[remote], r[local])
+	}
+	if r := refs(""); !r[remote] || !r[local] {
+		t.Errorf("References() of the default image repository: downloaded preload %v, user-built preload %v; want true, true", r[remote], r[local])
+	}
+}
+
+func TestInProgressDownloads(t *testing.T) {
+	dir := t.TempDir()
+	writeCached(t, dir, "iso/amd64/minikube-v1.0.0-amd64.iso.download", 100, time.Minute)
+	writeCached(t, dir, "iso/amd64/minikube-v1.0.0-amd64.iso.download.partial", 1, time.Minute)
+	writeCached(t, dir, "kic/amd64/kicbase_v0.0.1.tar.4242.download", 100, time.Minute)
+	writeCached(t, dir, "kic/amd64/kicbase_v0.0.2.tar.download", 100, 2*time.Hour)
+
+	artifacts, err := List(dir)
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	byPath := map[string]*Artifact{}
+	for _, a := range artifacts {
+		rel, _ := filepath.Rel(dir, a.Path)
+		byPath[filepath.ToSlash(rel)] = a
+	}
+	for _, p := range []string{"iso/amd64/minikube-v1.0.0-amd64.iso", "kic/amd64/kicbase_v0.0.1.tar"} {
+		a, ok := byPath[p]
+		if !ok {
+			t.Fatalf("missing artifact %s in %v", p, byPath)
+		}
+		if !a.Incomplete || a.Evictable() {
+			t.Errorf("%s: incomplete %v evictable %v, want a recent download to be kept", p, a.Incomplete, a.Evictable())
+		}
+	}
+	stale := byPath["kic/amd64/kicbase_v0.0.2.tar"]
+	if stale == nil || !stale.Evictable() {
+		t.Fatalf("expected a stale incomplete download to be evictable")
+	}
+
+	spec := lock.PathMutexSpec(stale.Path + ".download.lock")
+	releaser, err := mutex.Acquire(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Remove(stale); !errors.Is(err, ErrDownloading) {
+		t.Errorf("Remove() = %v, want %v while the download lock is held", err, ErrDownloading)
+	}
+	releaser.Release()
+	if err := Remove(stale); err != nil {
+		t.Errorf("Remove() = %v", err)
+	}
+}
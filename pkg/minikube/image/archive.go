@@ -0,0 +1,248 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/util"
+)
+
+const (
+	// FormatDocker is a docker-archive tarball, as written by `docker save`
+	FormatDocker = "docker"
+	// FormatOCI is an OCI image layout, either as a directory or a tar archive
+	FormatOCI = "oci"
+
+	// ociRefNameAnnotation is the OCI annotation for the tag of an image in a layout
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+	// containerdImageNameAnnotation is the annotation containerd uses for the full image name
+	containerdImageNameAnnotation = "io.containerd.image.name"
+)
+
+// ArchiveFormats returns the supported image archive formats
+func ArchiveFormats() []string {
+	return []string{FormatDocker, FormatOCI}
+}
+
+// WriteArchive writes images, given as docker-archive tarballs keyed by image name,
+// into a single archive at dst. Layers shared between images are only written once.
+// For the OCI format, dst is written as a layout directory unless it ends with ".tar".
+func WriteArchive(format string, tarballs map[string]string, dst string) error {
+	refToImage := map[name.Reference]v1.Image{}
+	for imgName, src := range tarballs {
+		tag, err := name.NewTag(imgName, name.WeakValidation)
+		if err != nil {
+			return errors.Wrapf(err, "parsing image name %s", imgName)
+		}
+		img, err := tarball.ImageFromPath(src, nil)
+		if err != nil {
+			// the tarball may contain several tags, pick the requested one
+			img, err = tarball.ImageFromPath(src, &tag)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "reading %s", src)
+		}
+		refToImage[tag] = img
+	}
+
+	switch format {
+	case FormatDocker:
+		klog.Infof("writing %d images to docker archive %s", len(refToImage), dst)
+		return tarball.MultiRefWriteToFile(dst, refToImage)
+	case FormatOCI:
+		if !strings.HasSuffix(dst, ".tar") {
+			return writeLayout(refToImage, dst)
+		}
+		tmp, err := os.MkdirTemp("", "oci-layout")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		if err := writeLayout(refToImage, tmp); err != nil {
+			return err
+		}
+		return util.TarDir(tmp, dst)
+	default:
+		return fmt.Errorf("unknown archive format %q, valid values: %s", format, strings.Join(ArchiveFormats(), ", "))
+	}
+}
+
+// writeLayout writes images into an OCI image layout directory
+func writeLayout(refToImage map[name.Reference]v1.Image, dir string) error {
+	klog.Infof("writing %d images to OCI layout %s", len(refToImage), dir)
+	p, err := layout.FromPath(dir)
+	if err != nil {
+		p, err = layout.Write(dir, empty.Index)
+		if err != nil {
+			return errors.Wrap(err, "creating layout")
+		}
+	}
+	for ref, img := range refToImage {
+		annotations := map[string]string{
+			containerdImageNameAnnotation: canonicalName(ref),
+			ociRefNameAnnotation:          ref.Identifier(),
+		}
+		if err := p.AppendImage(img, layout.WithAnnotations(annotations)); err != nil {
+			return errors.Wrapf(err, "writing %s", ref.Name())
+		}
+	}
+	return nil
+}
+
+// IsOCILayout returns whether path is an OCI image layout directory or archive
+func IsOCILayout(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if info.IsDir() {
+		_, err := os.Stat(filepath.Join(path, "oci-layout"))
+		return err == nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return false
+		}
+		if filepath.Clean(hdr.Name) == "oci-layout" {
+			return true
+		}
+	}
+}
+
+// ConvertOCILayout converts an OCI image layout directory or archive into a
+// docker-archive tarball containing all named images, and returns its path.
+// The caller is responsible for removing the returned file.
+func ConvertOCILayout(src string) (string, error) {
+	dir := src
+	if info, err := os.Stat(src); err != nil {
+		return "", err
+	} else if !info.IsDir() {
+		tmp, err := os.MkdirTemp("", "oci-layout")
+		if err != nil {
+			return "", err
+		}
+		defer os.RemoveAll(tmp)
+		if err := util.Untar(src, tmp); err != nil {
+			return "", errors.Wrapf(err, "extracting %s", src)
+		}
+		dir = tmp
+	}
+
+	p, err := layout.FromPath(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading layout %s", src)
+	}
+	ii, err := p.ImageIndex()
+	if err != nil {
+		return "", errors.Wrap(err, "image index")
+	}
+	refToImage, err := namedImages(ii)
+	if err != nil {
+		return "", err
+	}
+	if len(refToImage) == 0 {
+		return "", fmt.Errorf("no named images found in %s", src)
+	}
+
+	f, err := os.CreateTemp("", "oci.*.tar")
+	if err != nil {
+		return "", err
+	}
+	f.Close()
+	if err := tarball.MultiRefWriteToFile(f.Name(), refToImage); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "writing docker archive")
+	}
+	return f.Name(), nil
+}
+
+// namedImages returns the images of an index that carry a usable image name
+func namedImages(ii v1.ImageIndex) (map[name.Reference]v1.Image, error) {
+	im, err := ii.IndexManifest()
+	if err != nil {
+		return nil, errors.Wrap(err, "index manifest")
+	}
+	refToImage := map[name.Reference]v1.Image{}
+	for _, desc := range im.Manifests {
+		n := desc.Annotations[containerdImageNameAnnotation]
+		if n == "" {
+			// a bare tag like "v1" is not enough to name the image
+			if rn := desc.Annotations[ociRefNameAnnotation]; strings.ContainsAny(rn, "/:") {
+				n = rn
+			}
+		}
+		if n == "" {
+			klog.Warningf("skipping unnamed manifest %s", desc.Digest)
+			continue
+		}
+		ref, err := name.NewTag(n, name.WeakValidation)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing image name %s", n)
+		}
+		var img v1.Image
+		if desc.MediaType.IsIndex() {
+			child, err := ii.ImageIndex(desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+			img, err = platformImage(child, defaultPlatform)
+			if err != nil {
+				return nil, errors.Wrapf(err, "selecting platform for %s", n)
+			}
+		} else {
+			img, err = ii.Image(desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+		}
+		refToImage[ref] = img
+	}
+	return refToImage, nil
+}
+
+// platformImage returns the image of a multi-platform index matching the platform
+func platformImage(ii v1.ImageIndex, p v1.Platform) (v1.Image, error) {
+	im, err := ii.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, desc := range im.Manifests {
+		if desc.Platform != nil && desc.Platform.Satisfies(p) {
+			return ii.Image(desc.Digest)
+		}
+	}
+	return nil, fmt.Errorf("no image for platform %s", p.String())
+}
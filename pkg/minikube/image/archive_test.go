@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package image
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+func writeRandomImages(t *testing.T, dir string, names ...string) map[string]string {
+	t.Helper()
+	tarballs := map[string]string{}
+	for i, n := range names {
+		img, err := random.Image(64, 2)
+		if err != nil {
+			t.Fatalf("random image: %v", err)
+		}
+		tag, err := name.NewTag(n)
+		if err != nil {
+			t.Fatalf("tag: %v", err)
+		}
+		p := filepath.Join(dir, string(rune('a'+i))+".tar")
+		if err := tarball.WriteToFile(p, tag, img); err != nil {
+			t.Fatalf("write tarball: %v", err)
+		}
+		tarballs[n] = p
+	}
+	return tarballs
+}
+
+func TestOCILayoutRoundTrip(t *testing.T) {
+	for _, dst := range []string{"layout", "layout.tar"} {
+		t.Run(dst, func(t *testing.T) {
+			dir := t.TempDir()
+			tarballs := writeRandomImages(t, dir, "example.com/one:v1", "example.com/two:v2")
+
+			out := filepath.Join(dir, dst)
+			if err := WriteArchive(FormatOCI, tarballs, out); err != nil {
+				t.Fatalf("WriteArchive: %v", err)
+			}
+			if !IsOCILayout(out) {
+				t.Fatalf("expected %s to be an OCI layout", out)
+			}
+
+			converted, err := ConvertOCILayout(out)
+			if err != nil {
+				t.Fatalf("ConvertOCILayout: %v", err)
+			}
+			defer os.Remove(converted)
+			manifest, err := tarball.LoadManifest(func() (io.ReadCloser, error) { return os.Open(converted) })
+			if err != nil {
+				t.Fatalf("LoadManifest: %v", err)
+			}
+			var tags []string
+			for _, d := range manifest {
+				tags = append(tags, d.RepoTags...)
+			}
+			sort.Strings(tags)
+			want := []string{"example.com/one:v1", "example.com/two:v2"}
+			if len(tags) != len(want) || tags[0] != want[0] || tags[1] != want[1] {
+				t.Errorf("converted tags = %v, want %v", tags, want)
+			}
+		})
+	}
+}
+
+func TestWriteDockerArchive(t *testing.T) {
+	dir := t.TempDir()
+	tarballs := writeRandomImages(t, dir, "example.com/one:v1", "example.com/two:v2")
+
+	out := filepath.Join(dir, "images.tar")
+	if err := WriteArchive(FormatDocker, tarballs, out); err != nil {
+		t.Fatalf("WriteArchive: %v", err)
+	}
+	if IsOCILayout(out) {
+		t.Errorf("docker archive detected as OCI layout")
+	}
+	manifest, err := tarball.LoadManifest(func() (io.ReadCloser, error) { return os.Open(out) })
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if len(manifest) != 2 {
+		t.Errorf("expected 2 images in archive, got %d", len(manifest))
+	}
+
+	if err := WriteArchive("bogus", tarballs, out); err == nil {
+		t.Errorf("expected error for unknown format")
+	}
+}
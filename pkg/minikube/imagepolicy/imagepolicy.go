@@ -0,0 +1,393 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagepolicy restricts the registries the images of a cluster may come from. The policy is
+// enforced by the container runtimes of the nodes, and by a validating admission policy for pods.
+package imagepolicy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/blang/semver/v4"
+	dockerref "github.com/distribution/reference"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/cni"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/run"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+const (
+	// PolicyName is the name of the validating admission policy and its binding
+	PolicyName = "minikube-image-policy"
+	// dockerHub is the canonical name of the Docker Hub registry
+	dockerHub = "docker.io"
+)
+
+// ErrAdmissionUnsupported is returned when the Kubernetes version has no validating admission policies
+var ErrAdmissionUnsupported = errors.New("validating admission policies require Kubernetes v1.30 or later")
+
+// minAdmissionVersion is the first Kubernetes version with admissionregistration.k8s.io/v1 ValidatingAdmissionPolicy
+var minAdmissionVersion = semver.MustParse("1.30.0")
+
+// registryRE matches a registry host, with an optional port
+var registryRE = regexp.MustCompile(`^[a-z0-9]([a-z0-9.-]*[a-z0-9])?(:[0-9]+)?$`)
+
+// dockerHubAliases are the other names Docker Hub images are referenced by
+var dockerHubAliases = []string{"index.docker.io", "registry-1.docker.io"}
+
+// Normalize validates registry names, and returns them lower-cased, without scheme, deduplicated and sorted
+func Normalize(registries []string) ([]string, error) {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for _, r := range registries {
+		r = strings.ToLower(strings.TrimSpace(r))
+		r = strings.TrimPrefix(strings.TrimPrefix(r, "https://"), "http://")
+		r = strings.TrimSuffix(r, "/")
+		if r == "" {
+			continue
+		}
+		for _, alias := range dockerHubAliases {
+			if r == alias {
+				r = dockerHub
+			}
+		}
+		if !registryRE.MatchString(r) {
+			return nil, fmt.Errorf("invalid registry %q: expected a host name with an optional port, such as quay.io or localhost:5000", r)
+		}
+		if !seen[r] {
+			seen[r] = true
+			normalized = append(normalized, r)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// IsEmpty returns whether the policy does not restrict anything
+func IsEmpty(p config.ImagePolicy) bool {
+	return len(p.AllowedRegistries) == 0 && len(p.DeniedRegistries) == 0
+}
+
+// Registry returns the registry an image reference pulls from
+func Registry(image string) string {
+	named, err := dockerref.ParseNormalizedNamed(image)
+	if err != nil {
+		// mirror what the admission check does for references the parser rejects
+		first, _, found := strings.Cut(image, "/")
+		if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+			return first
+		}
+		return dockerHub
+	}
+	return dockerref.Domain(named)
+}
+
+// Allows returns whether the policy permits an image
+func Allows(p config.ImagePolicy, image string) bool {
+	r := Registry(image)
+	for _, d := range p.DeniedRegistries {
+		if d == r {
+			return false
+		}
+	}
+	if len(p.AllowedRegistries) == 0 {
+		return true
+	}
+	for _, a := range p.AllowedRegistries {
+		if a == r {
+			return true
+		}
+	}
+	return false
+}
+
+// SystemRegistries returns the registries of the images minikube needs to run Kubernetes and the CNI of the cluster
+func SystemRegistries(cc config.ClusterConfig) []string {
+	imgs, err := images.Kubeadm(cc.KubernetesConfig.ImageRepository, cc.KubernetesConfig.KubernetesVersion)
+	if err != nil {
+		klog.Warningf("unable to list the system images: %v", err)
+		return nil
+	}
+	if cnm, err := cni.New(&cc); err != nil {
+		klog.Warningf("unable to get the CNI: %v", err)
+	} else if cniImages, err := cni.Images(cnm); err != nil {
+		klog.Warningf("unable to list the CNI images: %v", err)
+	} else {
+		imgs = append(imgs, cniImages...)
+	}
+	registries := []string{}
+	for _, img := range imgs {
+		registries = append(registries, Registry(img))
+	}
+	registries, _ = Normalize(registries)
+	return registries
+}
+
+// RuntimePolicy returns the policy the container runtimes enforce. The registries of the system images
+// are always allowed, or the nodes could not start Kubernetes.
+func RuntimePolicy(cc config.ClusterConfig) cruntime.ImagePolicy {
+	p := cc.ImagePolicy
+	if IsEmpty(p) {
+		return cruntime.ImagePolicy{}
+	}
+	system := SystemRegistries(cc)
+	isSystem := func(r string) bool {
+		for _, s := range system {
+			if s == r {
+				return true
+			}
+		}
+		return false
+	}
+
+	rp := cruntime.ImagePolicy{}
+	if len(p.AllowedRegistries) > 0 {
+		rp.Allowed, _ = Normalize(append(append([]string{}, p.AllowedRegistries...), system...))
+	}
+	for _, r := range p.DeniedRegistries {
+		if isSystem(r) {
+			klog.Warningf("not blocking %s in the container runtime, as system images come from it", r)
+			continue
+		}
+		rp.Denied = append(rp.Denied, r)
+	}
+	return rp
+}
+
+// celList returns registries as a CEL list literal, including the aliases of Docker Hub
+func celList(registries []string) string {
+	quoted := []string{}
+	for _, r := range registries {
+		quoted = append(quoted, fmt.Sprintf("'%s'", r))
+		if r == dockerHub {
+			for _, alias := range dockerHubAliases {
+				quoted = append(quoted, fmt.Sprintf("'%s'", alias))
+			}
+		}
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+var admissionTmpl = template.Must(template.New("admission").Parse(`apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicy
+metadata:
+  name: {{.Name}}
+  labels:
+    app.kubernetes.io/managed-by: minikube
+spec:
+  failurePolicy: Fail
+  matchConstraints:
+    resourceRules:
+    - apiGroups: [""]
+      apiVersions: ["v1"]
+      operations: ["CREATE", "UPDATE"]
+      resources: ["pods", "pods/ephemeralcontainers"]
+  variables:
+  - name: images
+    expression: >-
+      object.spec.containers.map(c, c.image)
+      + (has(object.spec.initContainers) ? object.spec.initContainers.map(c, c.image) : [])
+      + (has(object.spec.ephemeralContainers) ? object.spec.ephemeralContainers.map(c, c.image) : [])
+  - name: registries
+    expression: >-
+      variables.images.map(i, i.contains('/') && i.split('/')[0].matches('[.:]|^localhost$') ? i.split('/')[0] : 'docker.io')
+  validations:
+{{- if .Allowed}}
+  - expression: "variables.registries.all(r, r in {{.Allowed}})"
+    message: "images may only come from the registries {{.AllowedText}} (minikube image policy)"
+    reason: Forbidden
+{{- end}}
+{{- if .Denied}}
+  - expression: "variables.registries.all(r, !(r in {{.Denied}}))"
+    message: "images may not come from the registries {{.DeniedText}} (minikube image policy)"
+    reason: Forbidden
+{{- end}}
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingAdmissionPolicyBinding
+metadata:
+  name: {{.Name}}
+  labels:
+    app.kubernetes.io/managed-by: minikube
+spec:
+  policyName: {{.Name}}
+  validationActions: [Deny]
+  matchResources:
+    namespaceSelector:
+      matchExpressions:
+      - key: kubernetes.io/metadata.name
+        operator: NotIn
+        values: {{.Exempt}}
+`))
+
+// SystemNamespaces returns the namespaces of the control plane and of the CNI of the cluster
+func SystemNamespaces(cc config.ClusterConfig) []string {
+	nss := []string{"kube-system"}
+	cnm, err := cni.New(&cc)
+	if err != nil {
+		klog.Warningf("unable to get the CNI: %v", err)
+		return nss
+	}
+	cniNamespaces, err := cni.Namespaces(cnm)
+	if err != nil {
+		klog.Warningf("unable to list the CNI namespaces: %v", err)
+		return nss
+	}
+	for _, ns := range cniNamespaces {
+		if !slices.Contains(nss, ns) {
+			nss = append(nss, ns)
+		}
+	}
+	return nss
+}
+
+// AdmissionManifest returns the validating admission policy which rejects pods with images the policy does not allow.
+// Pods in the exempt namespaces are not checked, so that the control plane and the CNI keep working whatever the policy.
+func AdmissionManifest(p config.ImagePolicy, exempt []string) ([]byte, error) {
+	var b bytes.Buffer
+	quoted := []string{}
+	for _, ns := range exempt {
+		quoted = append(quoted, fmt.Sprintf("%q", ns))
+	}
+	opts := struct {
+		Name        string
+		Allowed     string
+		AllowedText string
+		Denied      string
+		DeniedText  string
+		Exempt      string
+	}{Name: PolicyName, Exempt: "[" + strings.Join(quoted, ", ") + "]"}
+	if len(p.AllowedRegistries) > 0 {
+		opts.Allowed = celList(p.AllowedRegistries)
+		opts.AllowedText = strings.Join(p.AllowedRegistries, ", ")
+	}
+	if len(p.DeniedRegistries) > 0 {
+		opts.Denied = celList(p.DeniedRegistries)
+		opts.DeniedText = strings.Join(p.DeniedRegistries, ", ")
+	}
+	if err := admissionTmpl.Execute(&b, opts); err != nil {
+		return nil, errors.Wrap(err, "executing admission policy template")
+	}
+	return b.Bytes(), nil
+}
+
+// ApplyAdmission creates, updates or deletes the validating admission policy of the cluster, using the
+// kubectl of the control plane node runner
+func ApplyAdmission(cc config.ClusterConfig, runner command.Runner) error {
+	v, err := util.ParseKubernetesVersion(cc.KubernetesConfig.KubernetesVersion)
+	if err != nil {
+		return errors.Wrap(err, "parsing Kubernetes version")
+	}
+	if v.LT(minAdmissionVersion) {
+		if IsEmpty(cc.ImagePolicy) {
+			return nil
+		}
+		return ErrAdmissionUnsupported
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	kubectl := []string{"sudo", kapi.KubectlBinaryPath(cc.KubernetesConfig.KubernetesVersion), fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig"))}
+
+	if IsEmpty(cc.ImagePolicy) {
+		args := append(kubectl, "delete", "--ignore-not-found",
+			"validatingadmissionpolicybinding.admissionregistration.k8s.io/"+PolicyName,
+			"validatingadmissionpolicy.admissionregistration.k8s.io/"+PolicyName)
+		if rr, err := runner.RunCmd(exec.CommandContext(ctx, args[0], args[1:]...)); err != nil {
+			return errors.Wrapf(err, "cmd: %s output: %s", rr.Command(), rr.Output())
+		}
+		return nil
+	}
+
+	manifest, err := AdmissionManifest(cc.ImagePolicy, SystemNamespaces(cc))
+	if err != nil {
+		return err
+	}
+	target := path.Join(vmpath.GuestAddonsDir, PolicyName+".yaml")
+	if err := runner.Copy(assets.NewMemoryAssetTarget(manifest, target, "0640")); err != nil {
+		return errors.Wrap(err, "copy")
+	}
+	args := append(kubectl, "apply", "-f", target)
+	if rr, err := runner.RunCmd(exec.CommandContext(ctx, args[0], args[1:]...)); err != nil {
+		return errors.Wrapf(err, "cmd: %s output: %s", rr.Command(), rr.Output())
+	}
+	return nil
+}
+
+// Apply enforces the image policy of a cluster live: it reconfigures the container runtimes of the running
+// nodes, and updates the admission policy through the primary control plane
+func Apply(cc *config.ClusterConfig, options *run.CommandOptions) error {
+	api, err := machine.NewAPIClient(options)
+	if err != nil {
+		return errors.Wrap(err, "error creating api client")
+	}
+	defer api.Close()
+
+	var admissionErr error
+	for _, n := range cc.Nodes {
+		m := config.MachineName(*cc, n)
+
+		status, err := machine.Status(api, m)
+		if err != nil {
+			klog.Warningf("error getting status for %s: %v", m, err)
+			continue
+		}
+		if status != state.Running.String() {
+			klog.Infof("%s is not running, its runtime will be configured on start", m)
+			continue
+		}
+		h, err := api.Load(m)
+		if err != nil {
+			return errors.Wrapf(err, "loading machine %s", m)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			return err
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: runner, ImagePolicy: RuntimePolicy(*cc)})
+		if err != nil {
+			return errors.Wrap(err, "error creating container runtime")
+		}
+		if err := cruntime.ApplyImagePolicy(cr); err != nil {
+			return errors.Wrapf(err, "configuring the image policy of %s", m)
+		}
+		if config.IsPrimaryControlPlane(*cc, n) && n.KubernetesVersion != constants.NoKubernetesVersion {
+			admissionErr = ApplyAdmission(*cc, runner)
+		}
+	}
+	return admissionErr
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepolicy
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestNormalize(t *testing.T) {
+	got, err := Normalize([]string{"Quay.io", "https://ghcr.io/", "index.docker.io", "docker.io", "localhost:5000", ""})
+	if err != nil {
+		t.Fatalf("Normalize: %v", err)
+	}
+	want := []string{"docker.io", "ghcr.io", "localhost:5000", "quay.io"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Normalize mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, invalid := range []string{"quay.io/org", "-bad.io", "bad_host"} {
+		if _, err := Normalize([]string{invalid}); err == nil {
+			t.Errorf("Normalize(%q) succeeded, want error", invalid)
+		}
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		policy config.ImagePolicy
+		image  string
+		want   bool
+	}{
+		{config.ImagePolicy{}, "nginx", true},
+		{config.ImagePolicy{AllowedRegistries: []string{"docker.io"}}, "nginx:1.25", true},
+		{config.ImagePolicy{AllowedRegistries: []string{"docker.io"}}, "library/nginx", true},
+		{config.ImagePolicy{AllowedRegistries: []string{"docker.io"}}, "quay.io/prometheus/node-exporter", false},
+		{config.ImagePolicy{AllowedRegistries: []string{"localhost:5000"}}, "localhost:5000/app", true},
+		{config.ImagePolicy{DeniedRegistries: []string{"docker.io"}}, "busybox@sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", false},
+		{config.ImagePolicy{DeniedRegistries: []string{"docker.io"}}, "registry.k8s.io/pause:3.10", true},
+		{config.ImagePolicy{AllowedRegistries: []string{"quay.io"}, DeniedRegistries: []string{"quay.io"}}, "quay.io/app", false},
+	}
+	for _, tc := range tests {
+		if got := Allows(tc.policy, tc.image); got != tc.want {
+			t.Errorf("Allows(%+v, %q) = %v, want %v", tc.policy, tc.image, got, tc.want)
+		}
+	}
+}
+
+func TestRuntimePolicy(t *testing.T) {
+	cc := config.ClusterConfig{
+		KubernetesConfig: config.KubernetesConfig{KubernetesVersion: "v1.33.0"},
+		ImagePolicy: config.ImagePolicy{
+			AllowedRegistries: []string{"quay.io"},
+			DeniedRegistries:  []string{"registry.k8s.io", "docker.io"},
+		},
+	}
+	got := RuntimePolicy(cc)
+	// the system images come from registry.k8s.io and gcr.io
+	if diff := cmp.Diff([]string{"gcr.io", "quay.io", "registry.k8s.io"}, got.Allowed); diff != "" {
+		t.Errorf("allowed mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"docker.io"}, got.Denied); diff != "" {
+		t.Errorf("denied mismatch (-want +got):\n%s", diff)
+	}
+
+	// the CNI images are never blocked either
+	cc.KubernetesConfig.CNI = "flannel"
+	got = RuntimePolicy(cc)
+	if diff := cmp.Diff([]string{"gcr.io", "ghcr.io", "quay.io", "registry.k8s.io"}, got.Allowed); diff != "" {
+		t.Errorf("allowed mismatch with flannel (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"kube-system", "kube-flannel"}, SystemNamespaces(cc)); diff != "" {
+		t.Errorf("system namespaces mismatch with flannel (-want +got):\n%s", diff)
+	}
+
+	if p := RuntimePolicy(config.ClusterConfig{}); !p.IsEmpty() {
+		t.Errorf("RuntimePolicy of an empty policy = %+v", p)
+	}
+}
+
+func TestAdmissionManifest(t *testing.T) {
+	manifest, err := AdmissionManifest(config.ImagePolicy{AllowedRegistries: []string{"docker.io", "quay.io"}, DeniedRegistries: []string{"ghcr.io"}}, []string{"kube-system", "kube-flannel"})
+	if err != nil {
+		t.Fatalf("AdmissionManifest: %v", err)
+	}
+
+	dec := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), 4096)
+	var policy admissionv1.ValidatingAdmissionPolicy
+	if err := dec.Decode(&policy); err != nil {
+		t.Fatalf("decoding policy: %v\n%s", err, manifest)
+	}
+	var binding admissionv1.ValidatingAdmissionPolicyBinding
+	if err := dec.Decode(&binding); err != nil {
+		t.Fatalf("decoding binding: %v\n%s", err, manifest)
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		t.Errorf("expected two documents, got %v", err)
+	}
+
+	if policy.Name != PolicyName || binding.Spec.PolicyName != PolicyName {
+		t.Errorf("unexpected names %q, %q", policy.Name, binding.Spec.PolicyName)
+	}
+	if len(policy.Spec.Validations) != 2 {
+		t.Fatalf("expected 2 validations, got %+v", policy.Spec.Validations)
+	}
+	allowed := policy.Spec.Validations[0].Expression
+	if !strings.Contains(allowed, "'docker.io', 'index.docker.io', 'registry-1.docker.io', 'quay.io'") {
+		t.Errorf("unexpected allow expression %q", allowed)
+	}
+	if denied := policy.Spec.Validations[1].Expression; !strings.Contains(denied, "!(r in ['ghcr.io'])") {
+		t.Errorf("unexpected deny expression %q", denied)
+	}
+	exempt := binding.Spec.MatchResources.NamespaceSelector.MatchExpressions[0].Values
+	if diff := cmp.Diff([]string{"kube-system", "kube-flannel"}, exempt); diff != "" {
+		t.Errorf("exempt namespaces mismatch (-want +got):\n%s", diff)
+	}
+	if len(binding.Spec.ValidationActions) != 1 || binding.Spec.ValidationActions[0] != admissionv1.Deny {
+		t.Errorf("unexpected validation actions %v", binding.Spec.ValidationActions)
+	}
+}
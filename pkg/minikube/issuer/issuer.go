@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package issuer issues the TLS secrets of the Ingress objects of a cluster, signed by the minikube CA, so that
+// their hosts are served over HTTPS without installing cert-manager.
+//
+// The secret named by each TLS entry of an Ingress is created for the hosts of the entry. Only localhost, the *.test
+// domains and the domains of the issuer-domains config are issued, as the CA is trusted by the host: the other hosts
+// are skipped. Secrets are labeled as issued by minikube, and renewed when their hosts change, when they are about
+// to expire or when the CA changed. Secrets not issued by minikube are never modified.
+package issuer
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/util"
+)
+
+const (
+	// IssuedByLabel labels the secrets issued by minikube
+	IssuedByLabel = "minikube.sigs.k8s.io/issued-by"
+	// HostsAnnotation lists the hosts of the certificate of an issued secret
+	HostsAnnotation = "minikube.sigs.k8s.io/hosts"
+	// CertDuration is how long the issued certificates are valid
+	CertDuration = 90 * 24 * time.Hour
+
+	issuedBy = "minikube"
+	// renewBefore is how long before their expiration the certificates are renewed
+	renewBefore = 30 * 24 * time.Hour
+	// resyncPeriod is how often all the Ingress objects are checked again, to renew their certificates
+	resyncPeriod = 10 * time.Minute
+)
+
+// Issued is a secret issued for a TLS entry of an Ingress
+type Issued struct {
+	Namespace string
+	Ingress   string
+	Secret    string
+	Hosts     []string
+	Renewed   bool
+}
+
+// Issuer issues the TLS secrets of the Ingress objects of a cluster
+type Issuer struct {
+	client  kubernetes.Interface
+	caCert  string
+	caKey   string
+	domains []string
+	// skipped are the hosts already warned about, so that the resyncs do not warn again
+	skipped map[string]bool
+}
+
+// New returns an issuer of TLS secrets signed by the CA of the cluster of a profile, for localhost, the *.test
+// domains and the given domains
+func New(profile string, client kubernetes.Interface, domains []string) *Issuer {
+	return &Issuer{
+		client:  client,
+		caCert:  localpath.ProfileCACert(profile),
+		caKey:   localpath.ProfileCAKey(profile),
+		domains: append([]string{"test"}, domains...),
+		skipped: map[string]bool{},
+	}
+}
+
+// ParseDomains parses a comma separated list of the domains the issuer may issue certificates for
+func ParseDomains(s string) ([]string, error) {
+	domains := []string{}
+	for _, d := range strings.Split(s, ",") {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "*.")
+		if d == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(d); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid domain %q: %s", d, strings.Join(errs, ", "))
+		}
+		domains = append(domains, d)
+	}
+	return domains, nil
+}
+
+// allowed returns whether the issuer may issue a certificate for a host: localhost, or a host of its domains
+func (i *Issuer) allowed(host string) bool {
+	host = strings.TrimPrefix(strings.ToLower(host), "*.")
+	if host == "localhost" {
+		return true
+	}
+	for _, d := range i.domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sync creates or renews the secrets of the TLS entries of an Ingress
+func (i *Issuer) Sync(ctx context.Context, ing *networkingv1.Ingress) ([]Issued, error) {
+	// the CA is read every time, as it may be rotated while the issuer runs
+	caData, err := os.ReadFile(i.caCert)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading ca cert")
+	}
+	ca, err := parseCert(caData)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing ca cert")
+	}
+
+	issued := []Issued{}
+	secrets := i.client.CoreV1().Secrets(ing.Namespace)
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" || len(tls.Hosts) == 0 {
+			continue
+		}
+		hosts := []string{}
+		for _, h := range tls.Hosts {
+			if i.allowed(h) {
+				hosts = append(hosts, h)
+				continue
+			}
+			if !i.skipped[h] {
+				out.WarningT("Skipping {{.host}} of the {{.namespace}}/{{.ingress}} Ingress, which is not localhost, a *.test host or a host of the issuer-domains config", out.V{"host": h, "namespace": ing.Namespace, "ingress": ing.Name})
+				i.skipped[h] = true
+			}
+		}
+		if len(hosts) == 0 {
+			continue
+		}
+		slices.Sort(hosts)
+		hosts = slices.Compact(hosts)
+
+		existing, err := secrets.Get(ctx, tls.SecretName, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			existing = nil
+		case err != nil:
+			return issued, errors.Wrapf(err, "getting secret %s/%s", ing.Namespace, tls.SecretName)
+		case existing.Labels[IssuedByLabel] != issuedBy:
+			klog.Infof("skipping secret %s/%s of ingress %s, which was not issued by minikube", ing.Namespace, tls.SecretName, ing.Name)
+			continue
+		case !needsRenewal(existing, hosts, ca):
+			continue
+		}
+
+		cert, key, err := util.GenerateServingCert(hosts, i.caCert, i.caKey, CertDuration)
+		if err != nil {
+			return issued, errors.Wrapf(err, "generating the certificate of %s/%s", ing.Namespace, tls.SecretName)
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        tls.SecretName,
+				Namespace:   ing.Namespace,
+				Labels:      map[string]string{IssuedByLabel: issuedBy},
+				Annotations: map[string]string{HostsAnnotation: strings.Join(hosts, ",")},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       cert,
+				corev1.TLSPrivateKeyKey: key,
+				"ca.crt":                caData,
+			},
+		}
+		if existing == nil {
+			_, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+		} else {
+			secret.ResourceVersion = existing.ResourceVersion
+			_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return issued, errors.Wrapf(err, "writing secret %s/%s", ing.Namespace, tls.SecretName)
+		}
+		klog.Infof("issued secret %s/%s of ingress %s for %v", ing.Namespace, tls.SecretName, ing.Name, hosts)
+		issued = append(issued, Issued{Namespace: ing.Namespace, Ingress: ing.Name, Secret: tls.SecretName, Hosts: hosts, Renewed: existing != nil})
+	}
+	return issued, nil
+}
+
+// needsRenewal returns whether an issued secret is for other hosts, expires soon or was signed by another CA
+func needsRenewal(secret *corev1.Secret, hosts []string, ca *x509.Certificate) bool {
+	if secret.Annotations[HostsAnnotation] != strings.Join(hosts, ",") {
+		return true
+	}
+	cert, err := parseCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		klog.Infof("renewing secret %s/%s with an invalid certificate: %v", secret.Namespace, secret.Name, err)
+		return true
+	}
+	if time.Until(cert.NotAfter) < renewBefore {
+		return true
+	}
+	return cert.CheckSignatureFrom(ca) != nil
+}
+
+// parseCert parses the first certificate of PEM data
+func parseCert(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// SyncAll creates or renews the secrets of the TLS entries of every Ingress of the cluster
+func (i *Issuer) SyncAll(ctx context.Context) ([]Issued, error) {
+	ings, err := i.client.NetworkingV1().Ingresses(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing ingresses")
+	}
+	issued := []Issued{}
+	for _, ing := range ings.Items {
+		is, err := i.Sync(ctx, &ing)
+		issued = append(issued, is...)
+		if err != nil {
+			return issued, err
+		}
+	}
+	return issued, nil
+}
+
+// Run watches the Ingress objects of the cluster and syncs their secrets until the context is done, reporting the
+// issued secrets and the errors of each Ingress
+func (i *Issuer) Run(ctx context.Context, report func(ing *networkingv1.Ingress, issued []Issued, err error)) error {
+	factory := informers.NewSharedInformerFactory(i.client, resyncPeriod)
+	informer := factory.Networking().V1().Ingresses().Informer()
+	sync := func(obj interface{}) {
+		ing, ok := obj.(*networkingv1.Ingress)
+		if !ok {
+			return
+		}
+		issued, err := i.Sync(ctx, ing)
+		if len(issued) > 0 || err != nil {
+			report(ing, issued, err)
+		}
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    sync,
+		UpdateFunc: func(_, obj interface{}) { sync(obj) },
+	}); err != nil {
+		return errors.Wrap(err, "watching ingresses")
+	}
+
+	factory.Start(ctx.Done())
+	defer factory.Shutdown()
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return ctx.Err()
+	}
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/tests"
+	"k8s.io/minikube/pkg/util"
+)
+
+func TestSync(t *testing.T) {
+	tests.MakeTempDir(t)
+	generateCA := func() {
+		if err := util.GenerateCACert(localpath.CACert(), filepath.Join(localpath.MiniPath(), "ca.key"), "minikubeCA"); err != nil {
+			t.Fatalf("GenerateCACert: %v", err)
+		}
+	}
+	generateCA()
+
+	foreign := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mkcert", Namespace: "default"}, Type: corev1.SecretTypeTLS}
+	ing := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{
+			{Hosts: []string{"hello.test", "www.hello.test", "hello.test"}, SecretName: "hello-tls"},
+			{Hosts: []string{"other.test"}, SecretName: "mkcert"},
+			{SecretName: "no-hosts"},
+			{Hosts: []string{"bank.com"}, SecretName: "bank-tls"},
+		}},
+	}
+	client := fake.NewSimpleClientset(foreign, ing)
+	i := New("p", client, nil)
+	ctx := context.Background()
+
+	issued, err := i.SyncAll(ctx)
+	if err != nil {
+		t.Fatalf("SyncAll: %v", err)
+	}
+	want := []Issued{{Namespace: "default", Ingress: "hello", Secret: "hello-tls", Hosts: []string{"hello.test", "www.hello.test"}}}
+	if !reflect.DeepEqual(issued, want) {
+		t.Fatalf("SyncAll = %+v, want %+v", issued, want)
+	}
+	secret, err := client.CoreV1().Secrets("default").Get(ctx, "hello-tls", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting the issued secret: %v", err)
+	}
+	cert, err := parseCert(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		t.Fatalf("parsing the issued cert: %v", err)
+	}
+	if err := cert.VerifyHostname("www.hello.test"); err != nil {
+		t.Errorf("issued cert: %v", err)
+	}
+	if secret.Type != corev1.SecretTypeTLS || secret.Labels[IssuedByLabel] != "minikube" {
+		t.Errorf("issued secret = %s %v, want a TLS secret issued by minikube", secret.Type, secret.Labels)
+	}
+	if _, err := client.CoreV1().Secrets("default").Get(ctx, "bank-tls", metav1.GetOptions{}); err == nil {
+		t.Errorf("a secret was issued for a host outside of the domains")
+	}
+	if s, _ := client.CoreV1().Secrets("default").Get(ctx, "mkcert", metav1.GetOptions{}); len(s.Data) != 0 {
+		t.Errorf("the secret not issued by minikube was modified")
+	}
+
+	// nothing changed
+	if issued, err := i.Sync(ctx, ing); err != nil || len(issued) != 0 {
+		t.Errorf("Sync of an issued ingress = %+v, %v, want nothing issued", issued, err)
+	}
+
+	// the hosts changed
+	ing.Spec.TLS[0].Hosts = []string{"hello.test"}
+	issued, err = i.Sync(ctx, ing)
+	if err != nil || len(issued) != 1 || !issued[0].Renewed || !reflect.DeepEqual(issued[0].Hosts, []string{"hello.test"}) {
+		t.Errorf("Sync with other hosts = %+v, %v, want hello-tls renewed for hello.test", issued, err)
+	}
+
+	// the CA was rotated
+	generateCA()
+	issued, err = i.Sync(ctx, ing)
+	if err != nil || len(issued) != 1 || !issued[0].Renewed {
+		t.Errorf("Sync with a rotated CA = %+v, %v, want hello-tls renewed", issued, err)
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	domains, err := ParseDomains(" Example.internal, *.corp.internal,")
+	if err != nil {
+		t.Fatalf("ParseDomains: %v", err)
+	}
+	if want := []string{"example.internal", "corp.internal"}; !reflect.DeepEqual(domains, want) {
+		t.Errorf("ParseDomains = %v, want %v", domains, want)
+	}
+	if _, err := ParseDomains("example.internal,not a domain"); err == nil {
+		t.Errorf("ParseDomains of an invalid domain succeeded")
+	}
+
+	i := New("p", fake.NewSimpleClientset(), domains)
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"localhost", true},
+		{"hello.test", true},
+		{"*.hello.test", true},
+		{"example.internal", true},
+		{"www.example.internal", true},
+		{"app.corp.internal", true},
+		{"test", true},
+		{"bank.com", false},
+		{"hellotest", false},
+		{"www.notexample.internal", false},
+		{"example.internal.com", false},
+		{"localhost.com", false},
+	}
+	for _, tc := range tests {
+		if got := i.allowed(tc.host); got != tc.want {
+			t.Errorf("allowed(%q) = %v, want %v", tc.host, got, tc.want)
+		}
+	}
+}
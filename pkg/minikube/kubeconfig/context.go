@@ -102,3 +102,32 @@ func DeleteContext(machineName string, configPath ...string) error {
 	}
 	return nil
 }
+
+// DeleteUserContext deletes the user and context of a client user, keeping the cluster of the context
+func DeleteUserContext(name string, configPath ...string) error {
+	fPath := PathFromEnv()
+	if configPath != nil {
+		fPath = configPath[0]
+	}
+	kcfg, err := readOrNew(fPath)
+	if err != nil {
+		return errors.Wrap(err, "Error getting kubeconfig status")
+	}
+
+	if kcfg == nil || api.IsConfigEmpty(kcfg) {
+		klog.V(2).Info("kubeconfig is empty")
+		return nil
+	}
+
+	delete(kcfg.AuthInfos, name)
+	delete(kcfg.Contexts, name)
+
+	if kcfg.CurrentContext == name {
+		kcfg.CurrentContext = ""
+	}
+
+	if err := writeToFile(kcfg, fPath); err != nil {
+		return errors.Wrap(err, "writing kubeconfig")
+	}
+	return nil
+}
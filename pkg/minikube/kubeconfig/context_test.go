@@ -146,3 +146,25 @@ func TestUnsetCurrentContextOnlyChangesIfProfileIsTheCurrentContext(t *testing.T
 		t.Errorf("Expected context name %s but got %s", contextName, cfg.CurrentContext)
 	}
 }
+
+func TestDeleteUserContext(t *testing.T) {
+	// See kubeconfig_test
+	fn := tempFile(t, kubeConfigWithoutHTTPS)
+	defer os.Remove(fn)
+	if err := DeleteUserContext("la-croix", fn); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := readOrNew(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cfg.AuthInfos) != 0 || len(cfg.Contexts) != 0 {
+		t.Errorf("user and context were not deleted: %v, %v", cfg.AuthInfos, cfg.Contexts)
+	}
+
+	if len(cfg.Clusters) != 1 {
+		t.Errorf("cluster was deleted")
+	}
+}
@@ -158,9 +158,8 @@ func configIssues(cfg *api.Config, contextName string, address string) []error {
 // populateCerts retains certs already defined in kubeconfig or sets default ones for those missing.
 func populateCerts(kcs *Settings, cfg api.Config, contextName string) {
 	lp := localpath.Profile(contextName)
-	gp := localpath.MiniPath()
 
-	kcs.CertificateAuthority = path.Join(gp, "ca.crt")
+	kcs.CertificateAuthority = localpath.ProfileCACert(contextName)
 	if cluster, ok := cfg.Clusters[contextName]; ok {
 		kcs.CertificateAuthority = cluster.CertificateAuthority
 	}
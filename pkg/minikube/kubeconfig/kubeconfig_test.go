@@ -324,6 +324,39 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateUser(t *testing.T) {
+	cfg := &Settings{
+		ClusterName:          "test",
+		User:                 "alice@test",
+		ClusterServerAddress: "192.168.1.1:8080",
+		ClientCertificate:    "/home/alice.crt",
+		ClientKey:            "/home/alice.key",
+		CertificateAuthority: "/home/ca.crt",
+		KeepContext:          true,
+	}
+	cfg.SetPath(filepath.Join(t.TempDir(), "kubeconfig"))
+	if err := os.WriteFile(cfg.filePath(), kubeConfigWithoutHTTPS, 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := Update(cfg); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	config, err := readOrNew(cfg.filePath())
+	if err != nil {
+		t.Fatalf("Error reading kubeconfig file: %v", err)
+	}
+	context, ok := config.Contexts["alice@test"]
+	if !ok || context.Cluster != "test" || context.AuthInfo != "alice@test" {
+		t.Errorf("context = %+v, want the alice@test user in the test cluster", context)
+	}
+	if user, ok := config.AuthInfos["alice@test"]; !ok || user.ClientCertificate != "/home/alice.crt" {
+		t.Errorf("user = %+v, want the alice certificate", user)
+	}
+	if _, ok := config.Contexts["la-croix"]; !ok || config.CurrentContext != "la-croix" {
+		t.Errorf("the existing context was changed")
+	}
+}
+
 func TestVerifyEndpoint(t *testing.T) {
 
 	var tests = []struct {
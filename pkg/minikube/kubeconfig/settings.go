@@ -34,6 +34,9 @@ type Settings struct {
 	// The name of the cluster for this context
 	ClusterName string
 
+	// The name of the user and context, the name of the cluster if empty
+	User string
+
 	// The name of the namespace for this context
 	Namespace string
 
@@ -98,6 +101,9 @@ func PopulateFromSettings(cfg *Settings, apiCfg *api.Config) error {
 
 	// user
 	userName := cfg.ClusterName
+	if cfg.User != "" {
+		userName = cfg.User
+	}
 	user := api.NewAuthInfo()
 	if cfg.EmbedCerts {
 		user.ClientCertificateData, err = os.ReadFile(cfg.ClientCertificate)
@@ -115,7 +121,7 @@ func PopulateFromSettings(cfg *Settings, apiCfg *api.Config) error {
 	apiCfg.AuthInfos[userName] = user
 
 	// context
-	contextName := cfg.ClusterName
+	contextName := userName
 	context := api.NewContext()
 	context.Cluster = cfg.ClusterName
 	context.Namespace = cfg.Namespace
@@ -128,7 +134,7 @@ func PopulateFromSettings(cfg *Settings, apiCfg *api.Config) error {
 
 	// Only set current context to minikube if the user has not used the keepContext flag
 	if !cfg.KeepContext {
-		apiCfg.CurrentContext = cfg.ClusterName
+		apiCfg.CurrentContext = contextName
 	}
 
 	return nil
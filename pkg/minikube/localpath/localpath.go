@@ -131,6 +131,28 @@ func CACert() string {
 	return filepath.Join(MiniPath(), "ca.crt")
 }
 
+// ProfileCACert returns the CA certificate of the cluster of a profile: the CA supplied with --ca-cert,
+// which is kept in the profile directory, or else the minikube CA shared between profiles
+func ProfileCACert(profile string) string {
+	if p := filepath.Join(Profile(profile), "ca.crt"); fileExists(p) {
+		return p
+	}
+	return CACert()
+}
+
+// ProfileCAKey returns the key of ProfileCACert
+func ProfileCAKey(profile string) string {
+	if fileExists(filepath.Join(Profile(profile), "ca.crt")) {
+		return filepath.Join(Profile(profile), "ca.key")
+	}
+	return filepath.Join(MiniPath(), "ca.key")
+}
+
+func fileExists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
+
 // MachinePath returns the minikube machine path of a machine
 func MachinePath(machine string, miniHome ...string) string {
 	miniPath := MiniPath()
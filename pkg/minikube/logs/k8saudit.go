@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
+)
+
+// AuditEvent holds the fields of a Kubernetes audit event shown by 'minikube logs --k8s-audit'
+type AuditEvent struct {
+	Stage                    string `json:"stage"`
+	RequestURI               string `json:"requestURI"`
+	Verb                     string `json:"verb"`
+	RequestReceivedTimestamp string `json:"requestReceivedTimestamp"`
+	User                     struct {
+		Username string   `json:"username"`
+		Groups   []string `json:"groups"`
+	} `json:"user"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Namespace   string `json:"namespace"`
+		Name        string `json:"name"`
+		APIGroup    string `json:"apiGroup"`
+		Subresource string `json:"subresource"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code int `json:"code"`
+	} `json:"responseStatus"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// AuditFilter selects Kubernetes audit events, an empty field matches every event
+type AuditFilter struct {
+	// User is a user name
+	User string
+	// Verbs are API verbs such as get, list or create
+	Verbs []string
+	// Resources are resources such as pods, pods/log or deployments.apps
+	Resources []string
+}
+
+// Match returns true if an audit event is selected by the filter
+func (f AuditFilter) Match(e *AuditEvent) bool {
+	if f.User != "" && e.User.Username != f.User {
+		return false
+	}
+	if len(f.Verbs) > 0 && !containsFold(f.Verbs, e.Verb) {
+		return false
+	}
+	if len(f.Resources) == 0 {
+		return true
+	}
+	if e.ObjectRef == nil {
+		return false
+	}
+	names := []string{e.ObjectRef.Resource}
+	if e.ObjectRef.Subresource != "" {
+		names = append(names, e.ObjectRef.Resource+"/"+e.ObjectRef.Subresource)
+	}
+	if e.ObjectRef.APIGroup != "" {
+		for _, n := range names {
+			names = append(names, n+"."+e.ObjectRef.APIGroup)
+		}
+	}
+	for _, n := range names {
+		if containsFold(f.Resources, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(l []string, s string) bool {
+	for _, v := range l {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a one-line summary of an audit event
+func (e *AuditEvent) String() string {
+	target := e.RequestURI
+	if e.ObjectRef != nil {
+		target = e.ObjectRef.Resource
+		if e.ObjectRef.APIGroup != "" {
+			target += "." + e.ObjectRef.APIGroup
+		}
+		if e.ObjectRef.Subresource != "" {
+			target += "/" + e.ObjectRef.Subresource
+		}
+		if e.ObjectRef.Namespace != "" {
+			target += " -n " + e.ObjectRef.Namespace
+		}
+		if e.ObjectRef.Name != "" {
+			target += " " + e.ObjectRef.Name
+		}
+	}
+	code := "-"
+	if e.ResponseStatus != nil {
+		code = fmt.Sprint(e.ResponseStatus.Code)
+	}
+	s := fmt.Sprintf("%s %s %s %s %s", e.RequestReceivedTimestamp, code, e.User.Username, e.Verb, target)
+	if d := e.Annotations["authorization.k8s.io/decision"]; d != "" && d != "allow" {
+		s += fmt.Sprintf(" (%s: %s)", d, e.Annotations["authorization.k8s.io/reason"])
+	}
+	return s
+}
+
+// FilterAudit returns the last events of a Kubernetes audit log matching a filter, all of them if lines is 0
+func FilterAudit(r io.Reader, f AuditFilter, lines int) ([]*AuditEvent, error) {
+	events := []*AuditEvent{}
+	s := bufio.NewScanner(r)
+	// events logged at the RequestResponse level hold whole objects
+	s.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for s.Scan() {
+		if len(bytes.TrimSpace(s.Bytes())) == 0 {
+			continue
+		}
+		e := &AuditEvent{}
+		if err := json.Unmarshal(s.Bytes(), e); err != nil {
+			klog.Warningf("skipping audit event: %v", err)
+			continue
+		}
+		if !f.Match(e) {
+			continue
+		}
+		events = append(events, e)
+		if lines > 0 && len(events) > lines {
+			events = events[1:]
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "reading audit log")
+	}
+	return events, nil
+}
+
+// OutputK8sAudit writes the last events of the apiserver audit log matching a filter
+func OutputK8sAudit(cr logRunner, f AuditFilter, lines int, logOutput io.Writer) error {
+	rr, err := cr.RunCmd(exec.Command("sudo", "cat", bsutil.AuditLogPath))
+	if err != nil {
+		return errors.Wrap(err, "reading the apiserver audit log, was the cluster started with --apiserver-audit-policy?")
+	}
+	events, err := FilterAudit(&rr.Stdout, f, lines)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		fmt.Fprintln(logOutput, e.String())
+	}
+	return nil
+}
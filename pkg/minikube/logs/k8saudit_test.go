@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"strings"
+	"testing"
+)
+
+const auditLog = `{"kind":"Event","stage":"ResponseComplete","requestURI":"/api/v1/namespaces/default/pods","verb":"list","user":{"username":"minikube-user"},"objectRef":{"resource":"pods","namespace":"default","apiVersion":"v1"},"responseStatus":{"code":200},"requestReceivedTimestamp":"2026-01-01T00:00:01Z"}
+{"kind":"Event","stage":"ResponseComplete","requestURI":"/apis/apps/v1/namespaces/default/deployments/web","verb":"delete","user":{"username":"system:serviceaccount:default:ci"},"objectRef":{"resource":"deployments","namespace":"default","name":"web","apiGroup":"apps"},"responseStatus":{"code":403},"annotations":{"authorization.k8s.io/decision":"forbid","authorization.k8s.io/reason":""},"requestReceivedTimestamp":"2026-01-01T00:00:02Z"}
+not json
+
+{"kind":"Event","stage":"ResponseComplete","requestURI":"/api/v1/namespaces/default/pods/web-1/log","verb":"get","user":{"username":"minikube-user"},"objectRef":{"resource":"pods","namespace":"default","name":"web-1","subresource":"log"},"responseStatus":{"code":200},"requestReceivedTimestamp":"2026-01-01T00:00:03Z"}
+`
+
+func TestFilterAudit(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter AuditFilter
+		lines  int
+		want   []string
+	}{
+		{"all", AuditFilter{}, 0, []string{"2026-01-01T00:00:01Z", "2026-01-01T00:00:02Z", "2026-01-01T00:00:03Z"}},
+		{"last", AuditFilter{}, 1, []string{"2026-01-01T00:00:03Z"}},
+		{"user", AuditFilter{User: "minikube-user"}, 0, []string{"2026-01-01T00:00:01Z", "2026-01-01T00:00:03Z"}},
+		{"verb", AuditFilter{Verbs: []string{"DELETE", "list"}}, 0, []string{"2026-01-01T00:00:01Z", "2026-01-01T00:00:02Z"}},
+		{"resource", AuditFilter{Resources: []string{"pods"}}, 0, []string{"2026-01-01T00:00:01Z", "2026-01-01T00:00:03Z"}},
+		{"subresource", AuditFilter{Resources: []string{"pods/log"}}, 0, []string{"2026-01-01T00:00:03Z"}},
+		{"group", AuditFilter{Resources: []string{"deployments.apps"}}, 0, []string{"2026-01-01T00:00:02Z"}},
+		{"combined", AuditFilter{User: "minikube-user", Verbs: []string{"get"}}, 0, []string{"2026-01-01T00:00:03Z"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			events, err := FilterAudit(strings.NewReader(auditLog), tc.filter, tc.lines)
+			if err != nil {
+				t.Fatalf("FilterAudit() = %v", err)
+			}
+			got := []string{}
+			for _, e := range events {
+				got = append(got, e.RequestReceivedTimestamp)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("FilterAudit() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuditEventString(t *testing.T) {
+	events, err := FilterAudit(strings.NewReader(auditLog), AuditFilter{Verbs: []string{"delete"}}, 0)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("FilterAudit() = %v, %v", events, err)
+	}
+	want := "2026-01-01T00:00:02Z 403 system:serviceaccount:default:ci delete deployments.apps -n default web (forbid: )"
+	if got := events[0].String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/detect"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+// buildCacheRoot is where build caches are transferred to within the guest VM
+var buildCacheRoot = path.Join(vmpath.GuestPersistentDir, "build-cache")
+
+var validBuildCacheName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// ValidBuildCacheName returns whether name can be used for a build cache directory
+func ValidBuildCacheName(name string) bool {
+	return validBuildCacheName.MatchString(name)
+}
+
+// buildCache imports and exports an image build cache between the host and a node
+type buildCache struct {
+	// from and to are the names of the build caches in the host cache directory
+	from string
+	to   string
+	// guestFrom and guestTo are the build cache directories on the node
+	guestFrom string
+	guestTo   string
+}
+
+// transfer copies the imported build cache to the node, and prepares the directory for the exported one
+func (c *buildCache) transfer(cr command.Runner) error {
+	if c.from == "" && c.to == "" {
+		return nil
+	}
+	if _, err := cr.RunCmd(exec.Command("sudo", "mkdir", "-p", buildCacheRoot)); err != nil {
+		return err
+	}
+	if c.to != "" {
+		c.guestTo = path.Join(buildCacheRoot, "export", c.to)
+		if err := resetGuestDir(cr, c.guestTo); err != nil {
+			return err
+		}
+	}
+	if c.from == "" {
+		return nil
+	}
+
+	src := filepath.Join(detect.BuildCacheDir(), c.from)
+	if _, err := os.Stat(src); err != nil {
+		// the first build of a pipeline has no cache to import yet
+		klog.Warningf("build cache %s not found, building without it", src)
+		return nil
+	}
+	klog.Infof("Importing build cache from: %s", src)
+
+	tmp, err := os.CreateTemp("", "build-cache.*.tar")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	if err := util.TarDir(src, tmp.Name()); err != nil {
+		return errors.Wrapf(err, "archiving build cache %s", src)
+	}
+
+	filename := c.from + ".tar"
+	f, err := assets.NewFileAsset(tmp.Name(), buildCacheRoot, filename, "0644")
+	if err != nil {
+		return errors.Wrapf(err, "creating copyable file asset: %s", filename)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			klog.Warningf("error closing the file %s: %v", f.GetSourcePath(), err)
+		}
+	}()
+	if err := cr.Copy(f); err != nil {
+		return errors.Wrap(err, "transferring build cache")
+	}
+
+	c.guestFrom = path.Join(buildCacheRoot, "import", c.from)
+	if err := resetGuestDir(cr, c.guestFrom); err != nil {
+		return err
+	}
+	dst := path.Join(buildCacheRoot, filename)
+	if _, err := cr.RunCmd(exec.Command("sudo", "tar", "-C", c.guestFrom, "-xf", dst)); err != nil {
+		return err
+	}
+	_, err = cr.RunCmd(exec.Command("sudo", "rm", "-f", dst))
+	return err
+}
+
+// retrieve copies the exported build cache from the node, replacing the one on the host
+func (c *buildCache) retrieve(cr command.Runner) error {
+	if c.guestTo == "" {
+		return nil
+	}
+	filename := c.to + ".tar"
+	src := path.Join(buildCacheRoot, filename)
+	if _, err := cr.RunCmd(exec.Command("sudo", "tar", "-C", c.guestTo, "-cf", src, ".")); err != nil {
+		return err
+	}
+
+	dir := detect.BuildCacheDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, c.to+".*.tar")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	f, err := assets.NewFileAsset(tmp.Name(), buildCacheRoot, filename, "0644")
+	if err != nil {
+		return errors.Wrapf(err, "creating copyable file asset: %s", filename)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			klog.Warningf("error closing the file %s: %v", f.GetSourcePath(), err)
+		}
+	}()
+	if err := cr.CopyFrom(f); err != nil {
+		return errors.Wrap(err, "transferring build cache")
+	}
+
+	dst := filepath.Join(dir, c.to)
+	staging := dst + ".new"
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	if err := util.Untar(tmp.Name(), staging); err != nil {
+		return errors.Wrapf(err, "extracting build cache %s", dst)
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.Rename(staging, dst); err != nil {
+		return err
+	}
+	klog.Infof("Exported build cache to: %s", dst)
+
+	_, err = cr.RunCmd(exec.Command("sudo", "rm", "-rf", src, c.guestTo))
+	return err
+}
+
+// resetGuestDir creates an empty directory on the node
+func resetGuestDir(cr command.Runner, dir string) error {
+	if _, err := cr.RunCmd(exec.Command("sudo", "rm", "-rf", dir)); err != nil {
+		return err
+	}
+	_, err := cr.RunCmd(exec.Command("sudo", "mkdir", "-p", dir))
+	return err
+}
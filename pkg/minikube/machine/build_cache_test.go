@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import "testing"
+
+func TestValidBuildCacheName(t *testing.T) {
+	tests := map[string]bool{
+		"app":       true,
+		"my-app_v1": true,
+		"app.2":     true,
+		"":          false,
+		"-app":      false,
+		"../app":    false,
+		"a/b":       false,
+	}
+	for name, want := range tests {
+		if got := ValidBuildCacheName(name); got != want {
+			t.Errorf("ValidBuildCacheName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -43,7 +43,7 @@ import (
 var buildRoot = path.Join(vmpath.GuestPersistentDir, "build")
 
 // BuildImage builds image to all profiles
-func BuildImage(srcPath string, file string, tag string, push bool, env []string, opt []string, profiles []*config.Profile, allNodes bool, nodeName string, options *run.CommandOptions) error {
+func BuildImage(srcPath string, file string, tag string, push bool, env []string, opt []string, platforms []string, cacheFrom string, cacheTo string, profiles []*config.Profile, allNodes bool, nodeName string, options *run.CommandOptions) error {
 	api, err := NewAPIClient(options)
 	if err != nil {
 		return errors.Wrap(err, "api")
@@ -116,10 +116,16 @@ func BuildImage(srcPath string, file string, tag string, push bool, env []string
 				if err != nil {
 					return err
 				}
-				if remote {
-					err = buildImage(cr, c.KubernetesConfig, srcPath, file, tag, push, env, opt)
-				} else {
-					err = transferAndBuildImage(cr, c.KubernetesConfig, srcPath, file, tag, push, env, opt)
+				cache := buildCache{from: cacheFrom, to: cacheTo}
+				if err = cache.transfer(cr); err == nil {
+					if remote {
+						err = buildImage(cr, c.KubernetesConfig, srcPath, file, tag, push, env, opt, platforms, cache)
+					} else {
+						err = transferAndBuildImage(cr, c.KubernetesConfig, srcPath, file, tag, push, env, opt, platforms, cache)
+					}
+				}
+				if err == nil {
+					err = cache.retrieve(cr)
 				}
 				if err != nil {
 					failed = append(failed, m)
@@ -137,14 +143,14 @@ func BuildImage(srcPath string, file string, tag string, push bool, env []string
 }
 
 // buildImage builds a single image
-func buildImage(cr command.Runner, k8s config.KubernetesConfig, src string, file string, tag string, push bool, env []string, opt []string) error {
+func buildImage(cr command.Runner, k8s config.KubernetesConfig, src string, file string, tag string, push bool, env []string, opt []string, platforms []string, cache buildCache) error {
 	r, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: cr})
 	if err != nil {
 		return errors.Wrap(err, "runtime")
 	}
 	klog.Infof("Building image from url: %s", src)
 
-	err = r.BuildImage(src, file, tag, push, env, opt)
+	err = r.BuildImage(src, file, tag, push, env, opt, platforms, cache.guestFrom, cache.guestTo)
 	if err != nil {
 		return errors.Wrapf(err, "%s build %s", r.Name(), src)
 	}
@@ -154,7 +160,7 @@ func buildImage(cr command.Runner, k8s config.KubernetesConfig, src string, file
 }
 
 // transferAndBuildImage transfers and builds a single image
-func transferAndBuildImage(cr command.Runner, k8s config.KubernetesConfig, src string, file string, tag string, push bool, env []string, opt []string) error {
+func transferAndBuildImage(cr command.Runner, k8s config.KubernetesConfig, src string, file string, tag string, push bool, env []string, opt []string, platforms []string, cache buildCache) error {
 	r, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: cr})
 	if err != nil {
 		return errors.Wrap(err, "runtime")
@@ -201,7 +207,7 @@ func transferAndBuildImage(cr command.Runner, k8s config.KubernetesConfig, src s
 	if file != "" && !path.IsAbs(file) {
 		file = path.Join(context, file)
 	}
-	err = r.BuildImage(context, file, tag, push, env, opt)
+	err = r.BuildImage(context, file, tag, push, env, opt, platforms, cache.guestFrom, cache.guestTo)
 	if err != nil {
 		return errors.Wrapf(err, "%s build %s", r.Name(), dst)
 	}
@@ -461,6 +461,28 @@ func DoSaveImages(images []string, output string, profiles []*config.Profile, ca
 	return nil
 }
 
+// SaveImagesToArchive saves images from profile into a single archive of the given format
+func SaveImagesToArchive(images []string, output string, format string, profile *config.Profile, options *run.CommandOptions) error {
+	tmp, err := os.MkdirTemp("", "image-save")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	tarballs := map[string]string{}
+	for i, img := range images {
+		dst := filepath.Join(tmp, fmt.Sprintf("%d.tar", i))
+		if err := DoSaveImages([]string{img}, dst, []*config.Profile{profile}, "", options); err != nil {
+			return err
+		}
+		if _, err := os.Stat(dst); err != nil {
+			return errors.Wrapf(err, "saving %s", img)
+		}
+		tarballs[img] = dst
+	}
+	return image.WriteArchive(format, tarballs, output)
+}
+
 // transferAndSaveCachedImage transfers and loads a single image from the cache
 func transferAndSaveCachedImage(cr command.Runner, k8s config.KubernetesConfig, imgName string, cacheDir string) error {
 	dst := filepath.Join(cacheDir, imgName)
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/run"
+)
+
+// ImageUser is a pod which references an image
+type ImageUser struct {
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Pod       string `json:"pod" yaml:"pod"`
+	Node      string `json:"node,omitempty" yaml:"node,omitempty"`
+}
+
+// InventoryImage is an image of a cluster, with the nodes which hold it and the pods which use it
+type InventoryImage struct {
+	ID       string      `json:"id" yaml:"id"`
+	RepoTags []string    `json:"repoTags" yaml:"repoTags"`
+	Size     int64       `json:"size" yaml:"size"`
+	Nodes    []string    `json:"nodes" yaml:"nodes"`
+	UsedBy   []ImageUser `json:"usedBy" yaml:"usedBy"`
+	Dangling bool        `json:"dangling" yaml:"dangling"`
+}
+
+// ImageInventory returns the images of all running nodes in profile, joined with the pods known
+// to the API server which reference them
+func ImageInventory(profile *config.Profile, options *run.CommandOptions) ([]InventoryImage, error) {
+	api, err := NewAPIClient(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating api client")
+	}
+	defer api.Close()
+
+	nodes, err := runningImageNodes(api, profile.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kapi.Client(profile.Name)
+	if err != nil {
+		return nil, errors.Wrap(err, "kubernetes client")
+	}
+	pods, err := client.CoreV1().Pods(meta.NamespaceAll).List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pods")
+	}
+	return joinImageInventory(nodes, pods.Items), nil
+}
+
+// joinImageInventory merges the images of the nodes by ID, and records the pods referencing each of them
+func joinImageInventory(nodes []*imageNode, pods []v1.Pod) []InventoryImage {
+	merged := map[string]*cruntime.ListImage{}
+	items := map[string]*InventoryImage{}
+	order := []string{}
+	for _, n := range nodes {
+		for _, img := range n.images {
+			id := imageDigest(img.ID)
+			item, ok := items[id]
+			if !ok {
+				item = &InventoryImage{ID: id, RepoTags: []string{}, Size: imageSizeBytes(img.Size), Nodes: []string{}, UsedBy: []ImageUser{}}
+				items[id] = item
+				merged[id] = &cruntime.ListImage{ID: img.ID}
+				order = append(order, id)
+			}
+			item.Nodes = appendUnique(item.Nodes, n.name)
+			for _, tag := range imageTags(img) {
+				item.RepoTags = appendUnique(item.RepoTags, tag)
+			}
+			merged[id].RepoTags = item.RepoTags
+			for _, d := range img.RepoDigests {
+				merged[id].RepoDigests = appendUnique(merged[id].RepoDigests, d)
+			}
+		}
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		refs := podImageRefs(pod)
+		for _, id := range order {
+			if refs.has(*merged[id]) {
+				items[id].UsedBy = append(items[id].UsedBy, ImageUser{Namespace: pod.Namespace, Pod: pod.Name, Node: pod.Spec.NodeName})
+			}
+		}
+	}
+
+	inventory := []InventoryImage{}
+	for _, id := range order {
+		item := items[id]
+		item.Dangling = len(item.RepoTags) == 0
+		sort.Strings(item.RepoTags)
+		sort.Slice(item.UsedBy, func(i, j int) bool {
+			a, b := item.UsedBy[i], item.UsedBy[j]
+			if a.Namespace != b.Namespace {
+				return a.Namespace < b.Namespace
+			}
+			return a.Pod < b.Pod
+		})
+		inventory = append(inventory, *item)
+	}
+	// tagged images by name first, then dangling images by ID
+	sort.SliceStable(inventory, func(i, j int) bool {
+		a, b := inventory[i], inventory[j]
+		if a.Dangling != b.Dangling {
+			return !a.Dangling
+		}
+		if !a.Dangling && a.RepoTags[0] != b.RepoTags[0] {
+			return a.RepoTags[0] < b.RepoTags[0]
+		}
+		return a.ID < b.ID
+	})
+	return inventory
+}
+
+// appendUnique appends s to list unless it is already in it
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
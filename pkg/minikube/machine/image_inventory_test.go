@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+func TestJoinImageInventory(t *testing.T) {
+	nodes := []*imageNode{
+		{name: "p1", images: []cruntime.ListImage{
+			{ID: "sha256:1111", RepoTags: []string{"docker.io/library/nginx:latest"}, Size: "100"},
+			{ID: "sha256:3333", RepoTags: []string{"<none>:<none>"}, Size: "300"},
+		}},
+		{name: "p1-m02", images: []cruntime.ListImage{
+			{ID: "1111", RepoTags: []string{"docker.io/library/nginx:latest", "example.com/web:v1"}, Size: "100"},
+			{ID: "2222", RepoTags: []string{"docker.io/library/busybox:latest"}, RepoDigests: []string{"docker.io/library/busybox@sha256:aaaa"}, Size: "200"},
+		}},
+	}
+	pods := []v1.Pod{
+		{
+			ObjectMeta: meta.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: "p1", Containers: []v1.Container{{Name: "nginx", Image: "nginx"}}},
+		},
+		{
+			ObjectMeta: meta.ObjectMeta{Name: "job", Namespace: "batch"},
+			Spec:       v1.PodSpec{NodeName: "p1-m02", InitContainers: []v1.Container{{Name: "init", Image: "example.com/web:v1"}}},
+			Status:     v1.PodStatus{InitContainerStatuses: []v1.ContainerStatus{{Name: "init", ImageID: "docker-pullable://busybox@sha256:aaaa"}}},
+		},
+	}
+
+	got := joinImageInventory(nodes, pods)
+	want := []InventoryImage{
+		{
+			ID:       "2222",
+			RepoTags: []string{"docker.io/library/busybox:latest"},
+			Size:     200,
+			Nodes:    []string{"p1-m02"},
+			UsedBy:   []ImageUser{{Namespace: "batch", Pod: "job", Node: "p1-m02"}},
+		},
+		{
+			ID:       "1111",
+			RepoTags: []string{"docker.io/library/nginx:latest", "example.com/web:v1"},
+			Size:     100,
+			Nodes:    []string{"p1", "p1-m02"},
+			UsedBy:   []ImageUser{{Namespace: "batch", Pod: "job", Node: "p1-m02"}, {Namespace: "default", Pod: "web", Node: "p1"}},
+		},
+		{
+			ID:       "3333",
+			RepoTags: []string{},
+			Size:     300,
+			Nodes:    []string{"p1"},
+			UsedBy:   []ImageUser{},
+			Dangling: true,
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("joinImageInventory mismatch (-want +got):\n%s", diff)
+	}
+}
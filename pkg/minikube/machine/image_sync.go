@@ -0,0 +1,351 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/run"
+)
+
+// SyncHost is the source name of images synchronised from the host container daemon
+const SyncHost = "host"
+
+// SyncedImage is an image that was transferred to a node by SyncImages
+type SyncedImage struct {
+	Image string `json:"image" yaml:"image"`
+	ID    string `json:"id" yaml:"id"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+// imageNode is a running node together with its container runtime and images
+type imageNode struct {
+	name   string
+	runner command.Runner
+	cr     cruntime.Manager
+	images []cruntime.ListImage
+}
+
+// hasImage returns whether the node has an image with the given ID
+func (n *imageNode) hasImage(id string) bool {
+	for _, img := range n.images {
+		if imageDigest(img.ID) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// syncSource is an image to synchronise, and the node it is read from (nil for the host)
+type syncSource struct {
+	id   string
+	tags []string
+	node *imageNode
+}
+
+// imageDigest returns the image ID without the algorithm prefix, so IDs from different runtimes compare equal
+func imageDigest(id string) string {
+	return strings.TrimPrefix(id, "sha256:")
+}
+
+// imageTags returns the usable repo tags of an image
+func imageTags(img cruntime.ListImage) []string {
+	tags := []string{}
+	for _, tag := range img.RepoTags {
+		if tag == "" || strings.Contains(tag, "<none>") {
+			continue
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// SyncImages transfers the images missing on the nodes of the profiles in to, from the nodes of
+// profile from or from the host container daemon. Images are compared by ID, and streamed from one
+// runtime to the other without being written to the cache directory. If images is empty, all
+// tagged images of the source profile are synchronised.
+func SyncImages(images []string, from string, fromHost bool, to []string, options *run.CommandOptions) ([]SyncedImage, error) {
+	api, err := NewAPIClient(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating api client")
+	}
+	defer api.Close()
+
+	klog.Infof("Sync images: %q from %q (host: %v) to %q", images, from, fromHost, to)
+
+	dsts := []*imageNode{}
+	for _, pName := range to {
+		nodes, err := runningImageNodes(api, pName)
+		if err != nil {
+			return nil, err
+		}
+		dsts = append(dsts, nodes...)
+	}
+	if len(dsts) == 0 {
+		return nil, fmt.Errorf("no running nodes found in %s", strings.Join(to, ", "))
+	}
+
+	var sources []syncSource
+	var imgClient *client.Client
+	if fromHost {
+		imgClient, err = client.NewClientWithOpts(client.FromEnv)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating docker client")
+		}
+		defer imgClient.Close()
+		sources, err = hostSyncSources(imgClient, images)
+	} else {
+		var nodes []*imageNode
+		nodes, err = runningImageNodes(api, from)
+		if err != nil {
+			return nil, err
+		}
+		sources, err = nodeSyncSources(nodes, images)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	synced := []SyncedImage{}
+	for _, src := range sources {
+		targets := missingOn(src, dsts)
+		if len(targets) == 0 {
+			klog.Infof("%s is up to date on all nodes", src.tags[0])
+			continue
+		}
+		var done []*imageNode
+		if src.node == nil {
+			done, err = syncFromHost(imgClient, src, targets)
+		} else {
+			done, err = syncFromNode(src, targets)
+		}
+		for _, n := range done {
+			from := SyncHost
+			if src.node != nil {
+				from = src.node.name
+			}
+			synced = append(synced, SyncedImage{Image: src.tags[0], ID: src.id, From: from, To: n.name})
+			n.images = append(n.images, cruntime.ListImage{ID: src.id, RepoTags: src.tags})
+		}
+		if err != nil {
+			return synced, errors.Wrapf(err, "syncing %s", src.tags[0])
+		}
+	}
+	return synced, nil
+}
+
+// runningImageNodes returns the running nodes of a profile, with their images
+func runningImageNodes(api libmachine.API, pName string) ([]*imageNode, error) {
+	c, err := config.Load(pName)
+	if err != nil {
+		klog.Errorf("Failed to load profile %q: %v", pName, err)
+		return nil, errors.Wrapf(err, "error loading config for profile :%v", pName)
+	}
+
+	nodes := []*imageNode{}
+	for _, n := range c.Nodes {
+		m := config.MachineName(*c, n)
+
+		status, err := Status(api, m)
+		if err != nil {
+			klog.Warningf("error getting status for %s: %v", m, err)
+			continue
+		}
+
+		if status == state.Running.String() {
+			h, err := api.Load(m)
+			if err != nil {
+				klog.Warningf("Failed to load machine %q: %v", m, err)
+				continue
+			}
+			runner, err := CommandRunner(h)
+			if err != nil {
+				return nil, err
+			}
+			cr, err := cruntime.New(cruntime.Config{Type: c.KubernetesConfig.ContainerRuntime, Runner: runner})
+			if err != nil {
+				return nil, errors.Wrap(err, "error creating container runtime")
+			}
+			list, err := cr.ListImages(cruntime.ListImagesOptions{})
+			if err != nil {
+				return nil, errors.Wrapf(err, "listing images on %s", m)
+			}
+			nodes = append(nodes, &imageNode{name: m, runner: runner, cr: cr, images: list})
+		}
+	}
+	return nodes, nil
+}
+
+// nodeSyncSources returns the tagged images of the nodes which match images, one per image ID
+func nodeSyncSources(nodes []*imageNode, images []string) ([]syncSource, error) {
+	wanted := map[string]imageRefs{}
+	for _, img := range images {
+		refs := imageRefs{}
+		refs.add(img)
+		wanted[img] = refs
+	}
+
+	found := map[string]bool{}
+	seen := map[string]bool{}
+	sources := []syncSource{}
+	for _, n := range nodes {
+		for _, img := range n.images {
+			tags := imageTags(img)
+			id := imageDigest(img.ID)
+			if len(tags) == 0 || seen[id] {
+				continue
+			}
+			matched := len(images) == 0
+			for name, refs := range wanted {
+				if refs.has(img) {
+					found[name] = true
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+			seen[id] = true
+			sources = append(sources, syncSource{id: id, tags: tags, node: n})
+		}
+	}
+	for _, img := range images {
+		if !found[img] {
+			return nil, fmt.Errorf("image %s not found in source profile", img)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].tags[0] < sources[j].tags[0] })
+	return sources, nil
+}
+
+// hostSyncSources returns the images of the host container daemon
+func hostSyncSources(imgClient *client.Client, images []string) ([]syncSource, error) {
+	if len(images) == 0 {
+		return nil, fmt.Errorf("images to sync from the host must be given by name")
+	}
+	ctx := context.Background()
+	imgClient.NegotiateAPIVersion(ctx)
+
+	sources := []syncSource{}
+	for _, name := range images {
+		img, err := imgClient.ImageInspect(ctx, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "inspecting host image %s", name)
+		}
+		sources = append(sources, syncSource{id: imageDigest(img.ID), tags: []string{name}})
+	}
+	return sources, nil
+}
+
+// missingOn returns the nodes which do not have the source image, excluding the source node itself
+func missingOn(src syncSource, nodes []*imageNode) []*imageNode {
+	missing := []*imageNode{}
+	for _, n := range nodes {
+		if n == src.node || n.hasImage(src.id) {
+			continue
+		}
+		missing = append(missing, n)
+	}
+	return missing
+}
+
+// syncFromHost streams an image from the host container daemon into the runtime of each node
+func syncFromHost(imgClient *client.Client, src syncSource, nodes []*imageNode) ([]*imageNode, error) {
+	done := []*imageNode{}
+	for _, n := range nodes {
+		klog.Infof("Syncing %s from the host to %s", src.tags[0], n.name)
+		rc, err := imgClient.ImageSave(context.Background(), src.tags)
+		if err != nil {
+			return done, errors.Wrap(err, "saving host image")
+		}
+		err = n.cr.LoadImageStream(rc)
+		rc.Close()
+		if err != nil {
+			return done, err
+		}
+		done = append(done, n)
+	}
+	return done, nil
+}
+
+// syncFromNode saves an image on the source node, and streams it into the runtime of each node
+func syncFromNode(src syncSource, nodes []*imageNode) ([]*imageNode, error) {
+	filename := "sync-" + src.id + ".tar"
+	staged := path.Join(saveRoot, filename)
+	if _, err := src.node.runner.RunCmd(exec.Command("sudo", "mkdir", "-p", saveRoot)); err != nil {
+		return nil, err
+	}
+	if err := src.node.cr.SaveImage(src.tags[0], staged); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, err := src.node.runner.RunCmd(exec.Command("sudo", "rm", "-f", staged)); err != nil {
+			klog.Warningf("failed to remove %s on %s: %v", staged, src.node.name, err)
+		}
+	}()
+
+	done := []*imageNode{}
+	for _, n := range nodes {
+		klog.Infof("Syncing %s from %s to %s", src.tags[0], src.node.name, n.name)
+		if err := streamImage(src.node.runner, staged, n.cr); err != nil {
+			return done, err
+		}
+		// the archive only names the first tag
+		for _, tag := range src.tags[1:] {
+			if err := n.cr.TagImage(src.tags[0], tag); err != nil {
+				return done, err
+			}
+		}
+		done = append(done, n)
+	}
+	return done, nil
+}
+
+// streamImage copies an image archive from a node straight into a container runtime
+func streamImage(runner command.Runner, archive string, cr cruntime.Manager) error {
+	pr, pw := io.Pipe()
+	copied := make(chan error, 1)
+	go func() {
+		f := assets.NewStreamAsset(pw, path.Dir(archive), path.Base(archive), "0644")
+		err := runner.CopyFrom(f)
+		pw.CloseWithError(err)
+		copied <- err
+	}()
+	err := cr.LoadImageStream(pr)
+	// unblock the copy if the load stopped reading early
+	pr.CloseWithError(io.ErrClosedPipe)
+	if cerr := <-copied; cerr != nil && err == nil {
+		err = errors.Wrap(cerr, "copying image archive")
+	}
+	return err
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+func TestSyncSources(t *testing.T) {
+	src := &imageNode{name: "dev", images: []cruntime.ListImage{
+		{ID: "sha256:aaaa", RepoTags: []string{"example.com/app:v1", "example.com/app:latest"}},
+		{ID: "sha256:bbbb", RepoTags: []string{"docker.io/library/nginx:latest"}},
+		{ID: "sha256:cccc", RepoTags: []string{"<none>:<none>"}},
+	}}
+	// cri-o lists image IDs without the algorithm prefix
+	dst1 := &imageNode{name: "staging", images: []cruntime.ListImage{
+		{ID: "bbbb", RepoTags: []string{"docker.io/library/nginx:latest"}},
+	}}
+	dst2 := &imageNode{name: "test", images: []cruntime.ListImage{
+		{ID: "sha256:aaaa", RepoTags: []string{"example.com/app:v1"}},
+	}}
+
+	all, err := nodeSyncSources([]*imageNode{src}, nil)
+	if err != nil {
+		t.Fatalf("nodeSyncSources: %v", err)
+	}
+	if len(all) != 2 || all[0].id != "bbbb" || all[1].id != "aaaa" {
+		t.Fatalf("unexpected sources: %+v", all)
+	}
+
+	missing := missingOn(all[1], []*imageNode{src, dst1, dst2})
+	if len(missing) != 1 || missing[0] != dst1 {
+		t.Errorf("expected app to be missing on staging only, got %+v", missing)
+	}
+	if missing := missingOn(all[0], []*imageNode{dst1}); len(missing) != 0 {
+		t.Errorf("expected nginx to be up to date, got %+v", missing)
+	}
+
+	named, err := nodeSyncSources([]*imageNode{src}, []string{"nginx"})
+	if err != nil {
+		t.Fatalf("nodeSyncSources: %v", err)
+	}
+	if len(named) != 1 || named[0].id != "bbbb" {
+		t.Errorf("expected only nginx, got %+v", named)
+	}
+
+	if _, err := nodeSyncSources([]*imageNode{src}, []string{"busybox"}); err == nil {
+		t.Errorf("expected error for an image missing in the source profile")
+	}
+}
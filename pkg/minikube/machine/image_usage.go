@@ -0,0 +1,393 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	dockerref "github.com/distribution/reference"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/run"
+	"k8s.io/minikube/pkg/util"
+)
+
+// NodeImageUsage is the image and container storage usage of a single node
+type NodeImageUsage struct {
+	Node          string `json:"node" yaml:"node"`
+	Images        int    `json:"images" yaml:"images"`
+	ImagesSize    int64  `json:"imagesSize" yaml:"imagesSize"`
+	StorageDir    string `json:"storageDir" yaml:"storageDir"`
+	StorageSize   int64  `json:"storageSize" yaml:"storageSize"`
+	DiskSize      int64  `json:"diskSize" yaml:"diskSize"`
+	DiskUsed      int64  `json:"diskUsed" yaml:"diskUsed"`
+	DiskAvailable int64  `json:"diskAvailable" yaml:"diskAvailable"`
+}
+
+// PrunedImage is an image that was (or would be, in dry-run mode) removed from a node
+type PrunedImage struct {
+	Node     string   `json:"node" yaml:"node"`
+	ID       string   `json:"id" yaml:"id"`
+	RepoTags []string `json:"repoTags" yaml:"repoTags"`
+	Size     int64    `json:"size" yaml:"size"`
+}
+
+// runtimeStorageDir returns the directory where the container runtime keeps images and containers
+func runtimeStorageDir(runtime string) string {
+	switch runtime {
+	case "containerd":
+		return "/var/lib/containerd"
+	case "crio", "cri-o":
+		return "/var/lib/containers"
+	default:
+		return "/var/lib/docker"
+	}
+}
+
+// ImageDiskUsage returns the image and container storage usage of all running nodes in profile
+func ImageDiskUsage(profile *config.Profile, options *run.CommandOptions) ([]NodeImageUsage, error) {
+	api, err := NewAPIClient(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating api client")
+	}
+	defer api.Close()
+
+	pName := profile.Name
+
+	c, err := config.Load(pName)
+	if err != nil {
+		klog.Errorf("Failed to load profile %q: %v", pName, err)
+		return nil, errors.Wrapf(err, "error loading config for profile :%v", pName)
+	}
+
+	usages := []NodeImageUsage{}
+	for _, n := range c.Nodes {
+		m := config.MachineName(*c, n)
+
+		status, err := Status(api, m)
+		if err != nil {
+			klog.Warningf("error getting status for %s: %v", m, err)
+			continue
+		}
+
+		if status == state.Running.String() {
+			h, err := api.Load(m)
+			if err != nil {
+				klog.Warningf("Failed to load machine %q: %v", m, err)
+				continue
+			}
+			runner, err := CommandRunner(h)
+			if err != nil {
+				return nil, err
+			}
+			usage, err := nodeImageUsage(runner, c.KubernetesConfig.ContainerRuntime)
+			if err != nil {
+				klog.Warningf("Failed to get image disk usage for %s: %v", m, err)
+				continue
+			}
+			usage.Node = m
+			usages = append(usages, *usage)
+		}
+	}
+	return usages, nil
+}
+
+// nodeImageUsage collects image sizes and filesystem stats from a single node
+func nodeImageUsage(runner command.Runner, runtime string) (*NodeImageUsage, error) {
+	cr, err := cruntime.New(cruntime.Config{Type: runtime, Runner: runner})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating container runtime")
+	}
+	list, err := cr.ListImages(cruntime.ListImagesOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing images")
+	}
+
+	dir := runtimeStorageDir(runtime)
+	usage := &NodeImageUsage{Images: len(list), StorageDir: dir}
+	for _, img := range list {
+		usage.ImagesSize += imageSizeBytes(img.Size)
+	}
+
+	rr, err := runner.RunCmd(exec.Command("sudo", "du", "-sb", dir))
+	if err != nil {
+		return nil, errors.Wrapf(err, "du %s", dir)
+	}
+	if usage.StorageSize, err = parseDiskUsage(rr.Stdout.String()); err != nil {
+		return nil, errors.Wrapf(err, "parsing du %s", dir)
+	}
+
+	rr, err = runner.RunCmd(exec.Command("df", "-B1", "--output=size,used,avail", dir))
+	if err != nil {
+		return nil, errors.Wrapf(err, "df %s", dir)
+	}
+	if usage.DiskSize, usage.DiskUsed, usage.DiskAvailable, err = parseDiskStats(rr.Stdout.String()); err != nil {
+		return nil, errors.Wrapf(err, "parsing df %s", dir)
+	}
+	return usage, nil
+}
+
+// imageSizeBytes parses the size of an image as returned by ListImages
+func imageSizeBytes(size string) int64 {
+	s, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		klog.Warningf("unable to parse image size %q: %v", size, err)
+		return 0
+	}
+	return s
+}
+
+// parseDiskUsage parses the output of the `du -sb` command
+func parseDiskUsage(s string) (int64, error) {
+	// 1234567	/var/lib/docker
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return 0, errors.New("no matching data found")
+	}
+	return strconv.ParseInt(fields[0], 10, 64)
+}
+
+// parseDiskStats parses the output of the `df -B1 --output=size,used,avail` command
+func parseDiskStats(s string) (size int64, used int64, avail int64, err error) {
+	//  1B-blocks        Used       Avail
+	// 41567956992 3884867584 37666312192
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	if len(lines) < 2 {
+		return 0, 0, 0, errors.New("no matching data found")
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) < 3 {
+		return 0, 0, 0, fmt.Errorf("unexpected df output: %q", lines[1])
+	}
+	values := make([]int64, 3)
+	for i := range values {
+		if values[i], err = strconv.ParseInt(fields[i], 10, 64); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	return values[0], values[1], values[2], nil
+}
+
+// PruneImages removes images that are not referenced by any pod from all nodes in profile
+func PruneImages(profile *config.Profile, dryRun bool, options *run.CommandOptions) ([]PrunedImage, error) {
+	api, err := NewAPIClient(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating api client")
+	}
+	defer api.Close()
+
+	pName := profile.Name
+
+	c, err := config.Load(pName)
+	if err != nil {
+		klog.Errorf("Failed to load profile %q: %v", pName, err)
+		return nil, errors.Wrapf(err, "error loading config for profile :%v", pName)
+	}
+
+	client, err := kapi.Client(pName)
+	if err != nil {
+		return nil, errors.Wrap(err, "kubernetes client")
+	}
+	used, err := imagesInUse(client)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing images in use")
+	}
+	// the sandbox image is used by the runtime and not referenced by any pod spec
+	if v, err := util.ParseKubernetesVersion(c.KubernetesConfig.KubernetesVersion); err == nil {
+		used.add(images.Pause(v, c.KubernetesConfig.ImageRepository))
+	}
+
+	pruned := []PrunedImage{}
+	succeeded := []string{}
+	failed := []string{}
+
+	for _, n := range c.Nodes {
+		m := config.MachineName(*c, n)
+
+		status, err := Status(api, m)
+		if err != nil {
+			klog.Warningf("error getting status for %s: %v", m, err)
+			continue
+		}
+
+		if status == state.Running.String() {
+			h, err := api.Load(m)
+			if err != nil {
+				klog.Warningf("Failed to load machine %q: %v", m, err)
+				continue
+			}
+			runner, err := CommandRunner(h)
+			if err != nil {
+				return nil, err
+			}
+			crMgr, err := cruntime.New(cruntime.Config{Type: c.KubernetesConfig.ContainerRuntime, Runner: runner})
+			if err != nil {
+				return nil, errors.Wrap(err, "error creating container runtime")
+			}
+			list, err := crMgr.ListImages(cruntime.ListImagesOptions{})
+			if err != nil {
+				failed = append(failed, m)
+				klog.Warningf("Failed to list images for profile %s %v", pName, err.Error())
+				continue
+			}
+			for _, img := range unusedImages(list, used) {
+				if !dryRun {
+					if err := pruneImage(crMgr, img); err != nil {
+						klog.Warningf("Failed to remove image %s on %s: %v", img.ID, m, err)
+						continue
+					}
+				}
+				pruned = append(pruned, PrunedImage{Node: m, ID: parseImageID(strings.TrimPrefix(img.ID, "sha256:")), RepoTags: img.RepoTags, Size: imageSizeBytes(img.Size)})
+			}
+			succeeded = append(succeeded, m)
+		}
+	}
+
+	klog.Infof("succeeded pruning in: %s", strings.Join(succeeded, " "))
+	klog.Infof("failed pruning in: %s", strings.Join(failed, " "))
+	return pruned, nil
+}
+
+// pruneImage removes all tags of an image, or the image itself when it is dangling
+func pruneImage(crMgr cruntime.Manager, img cruntime.ListImage) error {
+	names := []string{}
+	for _, tag := range img.RepoTags {
+		if name, _ := parseRepoTag(tag); name != "" && name != "<none>" {
+			names = append(names, tag)
+		}
+	}
+	if len(names) == 0 {
+		names = append(names, img.ID)
+	}
+	for _, name := range names {
+		if err := crMgr.RemoveImage(name); err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "no such image") {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// imageRefs is a set of normalized image references, digests and IDs
+type imageRefs map[string]bool
+
+// add records an image reference as it appears in a pod spec or status
+func (r imageRefs) add(ref string) {
+	ref = strings.TrimPrefix(ref, "docker-pullable://")
+	ref = strings.TrimPrefix(ref, "docker://")
+	if ref == "" {
+		return
+	}
+	if i := strings.Index(ref, "@"); i != -1 {
+		r[ref[i+1:]] = true
+		ref = ref[:i]
+	}
+	if strings.HasPrefix(ref, "sha256:") {
+		r[strings.TrimPrefix(ref, "sha256:")] = true
+		return
+	}
+	r[normalizeImageRef(ref)] = true
+}
+
+// has returns whether any of the references of img is in the set
+func (r imageRefs) has(img cruntime.ListImage) bool {
+	if r[strings.TrimPrefix(img.ID, "sha256:")] {
+		return true
+	}
+	for _, tag := range img.RepoTags {
+		if r[normalizeImageRef(tag)] {
+			return true
+		}
+	}
+	for _, d := range img.RepoDigests {
+		if i := strings.Index(d, "@"); i != -1 && r[d[i+1:]] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeImageRef returns the fully qualified name:tag of an image reference
+func normalizeImageRef(ref string) string {
+	named, err := dockerref.ParseNormalizedNamed(ref)
+	if err != nil {
+		return ref
+	}
+	return dockerref.TagNameOnly(named).String()
+}
+
+// imagesInUse returns the images referenced by any pod known to the API server
+func imagesInUse(client kubernetes.Interface) (imageRefs, error) {
+	pods, err := client.CoreV1().Pods(meta.NamespaceAll).List(context.Background(), meta.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	used := imageRefs{}
+	for i := range pods.Items {
+		for ref := range podImageRefs(&pods.Items[i]) {
+			used[ref] = true
+		}
+	}
+	return used, nil
+}
+
+// podImageRefs returns the images referenced by the spec and status of a pod
+func podImageRefs(pod *v1.Pod) imageRefs {
+	refs := imageRefs{}
+	for _, c := range pod.Spec.InitContainers {
+		refs.add(c.Image)
+	}
+	for _, c := range pod.Spec.Containers {
+		refs.add(c.Image)
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		refs.add(c.Image)
+	}
+	for _, s := range pod.Status.InitContainerStatuses {
+		refs.add(s.ImageID)
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		refs.add(s.ImageID)
+	}
+	return refs
+}
+
+// unusedImages returns the images in list which are not referenced
+func unusedImages(list []cruntime.ListImage, used imageRefs) []cruntime.ListImage {
+	unused := []cruntime.ListImage{}
+	for _, img := range list {
+		if !used.has(img) {
+			unused = append(unused, img)
+		}
+	}
+	return unused
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+func TestParseDiskStats(t *testing.T) {
+	out := "     1B-blocks        Used       Avail\n41567956992 3884867584 37666312192\n"
+	size, used, avail, err := parseDiskStats(out)
+	if err != nil {
+		t.Fatalf("parseDiskStats: %v", err)
+	}
+	if size != 41567956992 || used != 3884867584 || avail != 37666312192 {
+		t.Errorf("parseDiskStats = %d, %d, %d", size, used, avail)
+	}
+
+	if _, _, _, err := parseDiskStats("     1B-blocks        Used       Avail\n"); err == nil {
+		t.Errorf("expected error for missing data")
+	}
+}
+
+func TestParseDiskUsage(t *testing.T) {
+	got, err := parseDiskUsage("1234567\t/var/lib/docker\n")
+	if err != nil {
+		t.Fatalf("parseDiskUsage: %v", err)
+	}
+	if got != 1234567 {
+		t.Errorf("parseDiskUsage = %d, want 1234567", got)
+	}
+}
+
+func TestUnusedImages(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Pod{
+		ObjectMeta: meta.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "nginx", Image: "nginx"}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "nginx", ImageID: "docker-pullable://nginx@sha256:aaaa"}},
+		},
+	}, &v1.Pod{
+		ObjectMeta: meta.ObjectMeta{Name: "app", Namespace: "kube-system"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "app", Image: "registry.k8s.io/app:v1"}},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", ImageID: "sha256:bbbb"}},
+		},
+	})
+
+	used, err := imagesInUse(client)
+	if err != nil {
+		t.Fatalf("imagesInUse: %v", err)
+	}
+
+	list := []cruntime.ListImage{
+		{ID: "1111", RepoTags: []string{"docker.io/library/nginx:latest"}},
+		{ID: "2222", RepoTags: []string{"example.com/retagged:v1"}, RepoDigests: []string{"example.com/retagged@sha256:aaaa"}},
+		{ID: "sha256:bbbb", RepoTags: []string{}},
+		{ID: "3333", RepoTags: []string{"docker.io/library/busybox:latest"}},
+		{ID: "4444", RepoTags: []string{"<none>:<none>"}},
+	}
+	unused := unusedImages(list, used)
+	if len(unused) != 2 {
+		t.Fatalf("expected 2 unused images, got %+v", unused)
+	}
+	if unused[0].ID != "3333" || unused[1].ID != "4444" {
+		t.Errorf("unexpected unused images: %+v", unused)
+	}
+}
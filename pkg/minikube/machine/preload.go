@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/sysinit"
+)
+
+const (
+	// preloadRoot is where the preload tarball is created on the node, before it is copied to the host
+	preloadRoot = "/var/tmp"
+	// preloadStorageDriver is the docker storage driver of the preload tarballs, see download.TarballName
+	preloadStorageDriver = "overlay2"
+)
+
+// preloadDirs returns the directories under /var to capture in a preload tarball, the same as hack/preload-images
+func preloadDirs(runner command.Runner, k8sVersion, containerRuntime string) ([]string, error) {
+	binaries := "./lib/minikube/binaries/" + k8sVersion
+	if _, err := runner.RunCmd(exec.Command("sudo", "test", "-d", path.Join("/var/lib/minikube/binaries", k8sVersion))); err != nil {
+		return nil, fmt.Errorf("the Kubernetes %s binaries are not installed on the node", k8sVersion)
+	}
+	dirs := []string{binaries}
+
+	switch containerRuntime {
+	case constants.Docker:
+		rr, err := runner.RunCmd(exec.Command("docker", "info", "--format", "{{.Driver}}"))
+		if err != nil {
+			return nil, errors.Wrap(err, "docker storage driver")
+		}
+		if d := strings.TrimSpace(rr.Stdout.String()); d != preloadStorageDriver {
+			return nil, fmt.Errorf("docker storage driver %s is not supported, preloads require %s", d, preloadStorageDriver)
+		}
+		dirs = append(dirs, "./lib/docker/"+preloadStorageDriver, "./lib/docker/image")
+	case constants.Containerd:
+		dirs = append(dirs, "./lib/containerd")
+	case constants.CRIO, "cri-o":
+		dirs = append(dirs, "./lib/containers")
+	default:
+		return nil, fmt.Errorf("unsupported container runtime: %s", containerRuntime)
+	}
+	return dirs, nil
+}
+
+// preloadServices returns the services writing to the image store of a container runtime, in the order they are
+// stopped: the kubelet first, so that it does not start the runtime again
+func preloadServices(containerRuntime string) []string {
+	switch containerRuntime {
+	case constants.Docker:
+		// docker is socket activated
+		return []string{"kubelet", "docker.socket", "docker"}
+	case constants.Containerd:
+		return []string{"kubelet", "containerd"}
+	default:
+		return []string{"kubelet", "crio"}
+	}
+}
+
+// stopPreloadServices stops the active services writing to the image store, so that it is captured consistently,
+// and returns a function starting them again
+func stopPreloadServices(runner command.Runner, containerRuntime string) (func(), error) {
+	sm := sysinit.New(runner)
+	stopped := []string{}
+	restart := func() {
+		for i := len(stopped) - 1; i >= 0; i-- {
+			if err := sm.Start(stopped[i]); err != nil {
+				klog.Warningf("error starting %s: %v", stopped[i], err)
+			}
+		}
+	}
+	for _, svc := range preloadServices(containerRuntime) {
+		if !sm.Active(svc) {
+			continue
+		}
+		klog.Infof("stopping %s to capture the image store", svc)
+		if err := sm.Stop(svc); err != nil {
+			restart()
+			return nil, errors.Wrapf(err, "stopping %s", svc)
+		}
+		stopped = append(stopped, svc)
+	}
+	return restart, nil
+}
+
+// CreatePreload captures the image store and Kubernetes binaries of a running node into a preload tarball,
+// which is then preferred over the official preload for the same Kubernetes version, container runtime and image
+// repository. The kubelet and the container runtime of the node are stopped while the image store is captured.
+func CreatePreload(runner command.Runner, cc config.ClusterConfig) (string, error) {
+	k8sVersion := cc.KubernetesConfig.KubernetesVersion
+	cRuntime := cc.KubernetesConfig.ContainerRuntime
+
+	if _, err := runner.RunCmd(exec.Command("which", "lz4")); err != nil {
+		return "", cruntime.NewErrISOFeature("lz4")
+	}
+	dirs, err := preloadDirs(runner, k8sVersion, cRuntime)
+	if err != nil {
+		return "", err
+	}
+
+	filename := download.TarballName(k8sVersion, cRuntime)
+	src := path.Join(preloadRoot, filename)
+	restart, err := stopPreloadServices(runner, cRuntime)
+	if err != nil {
+		return "", err
+	}
+	args := []string{"tar", "--xattrs", "--xattrs-include", "security.capability", "-I", "lz4", "-C", "/var", "-cf", src}
+	t := time.Now()
+	rr, err := runner.RunCmd(exec.Command("sudo", append(args, dirs...)...))
+	restart()
+	if err != nil {
+		return "", errors.Wrapf(err, "creating tarball: %s", rr.Output())
+	}
+	klog.Infof("duration metric: took %s to create the preload tarball", time.Since(t))
+	defer func() {
+		if _, err := runner.RunCmd(exec.Command("sudo", "rm", "-f", src)); err != nil {
+			klog.Warningf("error removing %s: %v", src, err)
+		}
+	}()
+	if _, err := runner.RunCmd(exec.Command("sudo", "chmod", "0644", src)); err != nil {
+		return "", err
+	}
+
+	dst := download.LocalTarballPath(k8sVersion, cRuntime)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), filename+".*")
+	if err != nil {
+		return "", errors.Wrap(err, "tempfile")
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	f, err := assets.NewFileAsset(tmp.Name(), preloadRoot, filename, "0644")
+	if err != nil {
+		return "", errors.Wrapf(err, "creating copyable file asset: %s", filename)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			klog.Warningf("error closing the file %s: %v", f.GetSourcePath(), err)
+		}
+	}()
+	t = time.Now()
+	if err := runner.CopyFrom(f); err != nil {
+		return "", errors.Wrap(err, "transferring preload tarball")
+	}
+	klog.Infof("duration metric: took %s to copy the preload tarball", time.Since(t))
+
+	// only replace an existing preload once the new one is complete
+	if err := download.SaveLocalPreloadRepository(k8sVersion, cRuntime, cc.KubernetesConfig.ImageRepository); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return "", errors.Wrap(err, "rename")
+	}
+	klog.Infof("Saved preload to: %s", dst)
+	return dst, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/command"
+)
+
+func TestPreloadDirs(t *testing.T) {
+	tests := []struct {
+		runtime string
+		driver  string
+		want    []string
+		wantErr bool
+	}{
+		{runtime: "docker", driver: "overlay2", want: []string{"./lib/minikube/binaries/v1.33.0", "./lib/docker/overlay2", "./lib/docker/image"}},
+		{runtime: "docker", driver: "btrfs", wantErr: true},
+		{runtime: "containerd", want: []string{"./lib/minikube/binaries/v1.33.0", "./lib/containerd"}},
+		{runtime: "crio", want: []string{"./lib/minikube/binaries/v1.33.0", "./lib/containers"}},
+		{runtime: "rkt", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.runtime+tc.driver, func(t *testing.T) {
+			runner := command.NewFakeCommandRunner()
+			runner.SetCommandToOutput(map[string]string{
+				"sudo test -d /var/lib/minikube/binaries/v1.33.0": "",
+				"docker info --format {{.Driver}}":                tc.driver + "\n",
+			})
+			got, err := preloadDirs(runner, "v1.33.0", tc.runtime)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("preloadDirs() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("preloadDirs() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// a node without the binaries of the version cannot be captured
+	if _, err := preloadDirs(command.NewFakeCommandRunner(), "v1.34.0", "containerd"); err == nil {
+		t.Errorf("expected an error for missing binaries")
+	}
+}
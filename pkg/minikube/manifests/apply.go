@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+const (
+	// ApplySetName is the name of the Secret in the default namespace tracking the applied objects, to prune them
+	ApplySetName = "minikube-manifests"
+	// applySetIDLabel and applySetKindsAnnotation are set by kubectl on the applyset parent
+	applySetIDLabel         = "applyset.kubernetes.io/id"
+	applySetKindsAnnotation = "applyset.kubernetes.io/contains-group-kinds"
+	applySetPartOfLabel     = "applyset.kubernetes.io/part-of"
+)
+
+// minApplySetVersion is the first Kubernetes version whose kubectl can prune an applyset
+var minApplySetVersion = semver.MustParse("1.27.0")
+
+// guestDir is the directory of the rendered manifests in the control-plane node
+var guestDir = path.Join(vmpath.GuestPersistentDir, "manifests")
+
+// Dir returns the directory of the manifests of a profile
+func Dir(profile string) string {
+	return filepath.Join(localpath.Profile(profile), "manifests")
+}
+
+// Render returns the manifests of a profile by path relative to its directory, each Helm chart (a directory
+// with a Chart.yaml) being rendered into a single manifest with the Helm of the host
+func Render(profile, kubernetesVersion string) (map[string][]byte, error) {
+	dir := Dir(profile)
+	rendered := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if _, err := os.Stat(filepath.Join(p, "Chart.yaml")); err != nil || p == dir {
+				return nil
+			}
+			data, err := renderChart(p, kubernetesVersion)
+			if err != nil {
+				return err
+			}
+			rendered[filepath.ToSlash(rel)+".yaml"] = data
+			return filepath.SkipDir
+		}
+		if !isManifest(d.Name()) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rendered[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "reading manifests")
+	}
+	return rendered, nil
+}
+
+// renderChart renders a Helm chart with its default values
+func renderChart(dir, kubernetesVersion string) ([]byte, error) {
+	helm, err := exec.LookPath("helm")
+	if err != nil {
+		return nil, fmt.Errorf("helm is required to render the chart %s, but was not found in PATH", dir)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	c := exec.CommandContext(ctx, helm, "template", filepath.Base(dir), dir, "--namespace", "default", "--kube-version", kubernetesVersion)
+	var stderr strings.Builder
+	c.Stderr = &stderr
+	data, err := c.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "rendering the chart %s: %s", dir, stderr.String())
+	}
+	return data, nil
+}
+
+// Apply applies the manifests of a profile using the kubectl of the primary control-plane node runner, and prunes
+// the objects of the manifests removed since the previous apply
+func Apply(cc config.ClusterConfig, runner command.Runner) error {
+	version := cc.KubernetesConfig.KubernetesVersion
+	rendered, err := Render(cc.Name, version)
+	if err != nil {
+		return err
+	}
+	v, err := util.ParseKubernetesVersion(version)
+	if err != nil {
+		return errors.Wrap(err, "parsing Kubernetes version")
+	}
+	prune := v.GTE(minApplySetVersion)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	kubectl := []string{"sudo", "KUBECTL_APPLYSET=true", kapi.KubectlBinaryPath(version), fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig"))}
+	run := func(args ...string) (*command.RunResult, error) {
+		args = append(append([]string{}, kubectl...), args...)
+		rr, err := runner.RunCmd(exec.CommandContext(ctx, args[0], args[1:]...))
+		if err != nil {
+			return rr, errors.Wrapf(err, "cmd: %s output: %s", rr.Command(), rr.Output())
+		}
+		return rr, nil
+	}
+
+	if _, err := runner.RunCmd(exec.Command("sudo", "rm", "-rf", guestDir)); err != nil {
+		return errors.Wrap(err, "removing the previous manifests")
+	}
+	if len(rendered) == 0 {
+		if !prune {
+			return nil
+		}
+		return deleteApplySet(run)
+	}
+
+	names := []string{}
+	dirs := map[string]bool{guestDir: true}
+	for name := range rendered {
+		names = append(names, name)
+		dirs[path.Dir(path.Join(guestDir, name))] = true
+	}
+	sort.Strings(names)
+	mkdir := []string{"sudo", "mkdir", "-p"}
+	for d := range dirs {
+		mkdir = append(mkdir, d)
+	}
+	if _, err := runner.RunCmd(exec.Command(mkdir[0], mkdir[1:]...)); err != nil {
+		return errors.Wrap(err, "creating the manifests directories")
+	}
+	for _, name := range names {
+		if err := runner.Copy(assets.NewMemoryAssetTarget(rendered[name], path.Join(guestDir, name), "0640")); err != nil {
+			return errors.Wrapf(err, "copying manifest %s", name)
+		}
+	}
+
+	args := []string{"apply", "--namespace=default", "--recursive", "-f", guestDir}
+	if prune {
+		args = append(args, "--prune", "--applyset="+ApplySetName)
+	} else {
+		klog.Infof("not pruning the manifests, as kubectl %s has no applysets", version)
+	}
+	if _, err := run(args...); err != nil {
+		return err
+	}
+	klog.Infof("applied manifests %v", names)
+	return nil
+}
+
+// deleteApplySet deletes the objects of the applyset and its parent Secret, as kubectl cannot prune all the objects
+// of an applyset by applying no manifest
+func deleteApplySet(run func(args ...string) (*command.RunResult, error)) error {
+	rr, err := run("get", "secret", ApplySetName, "--namespace=default", "--ignore-not-found", "-o", "json")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(rr.Stdout.String()) == "" {
+		return nil
+	}
+	var parent struct {
+		Metadata struct {
+			Labels      map[string]string `json:"labels"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+	}
+	if err := json.Unmarshal(rr.Stdout.Bytes(), &parent); err != nil {
+		return errors.Wrap(err, "parsing the applyset")
+	}
+	if id, kinds := parent.Metadata.Labels[applySetIDLabel], parent.Metadata.Annotations[applySetKindsAnnotation]; id != "" && kinds != "" {
+		klog.Infof("deleting the %s of the removed manifests", kinds)
+		if _, err := run("delete", kinds, "--all-namespaces", "--ignore-not-found", "-l", applySetPartOfLabel+"="+id); err != nil {
+			return err
+		}
+	}
+	_, err = run("delete", "secret", ApplySetName, "--namespace=default", "--ignore-not-found")
+	return err
+}
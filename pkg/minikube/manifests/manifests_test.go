@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifests
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func pod(name string) string {
+	return "apiVersion: v1\nkind: Pod\nmetadata:\n  name: " + name + "\n"
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStaticPods(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	writeFiles(t, StaticPodsDir("p"), map[string]string{
+		"primary.yaml":        pod("primary"),
+		"README.md":           "not a manifest",
+		"all/agent.yaml":      pod("agent"),
+		"all/shared.yml":      pod("shared"),
+		"p-m02/shared.yml":    pod("shared-m02"),
+		"p-m02/worker.json":   `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "worker"}}`,
+		"p-m03/ignored.yaml":  pod("ignored"),
+		"p/primary-only.yaml": pod("primary-only"),
+	})
+	cc := config.ClusterConfig{Name: "p", Nodes: []config.Node{{Name: "", ControlPlane: true}, {Name: "m02"}, {Name: "m03"}}}
+
+	tests := []struct {
+		node config.Node
+		want map[string]string
+	}{
+		{cc.Nodes[0], map[string]string{"primary.yaml": pod("primary"), "primary-only.yaml": pod("primary-only"), "agent.yaml": pod("agent"), "shared.yml": pod("shared")}},
+		{cc.Nodes[1], map[string]string{"agent.yaml": pod("agent"), "shared.yml": pod("shared-m02"), "worker.json": `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "worker"}}`}},
+	}
+	for _, tc := range tests {
+		t.Run(config.MachineName(cc, tc.node), func(t *testing.T) {
+			pods, err := StaticPods(cc, tc.node)
+			if err != nil {
+				t.Fatalf("StaticPods: %v", err)
+			}
+			got := map[string]string{}
+			for name, data := range pods {
+				got[name] = string(data)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("StaticPods mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateStaticPods(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	if err := ValidateStaticPods("p"); err != nil {
+		t.Errorf("ValidateStaticPods without static pods: %v", err)
+	}
+
+	writeFiles(t, StaticPodsDir("p"), map[string]string{"all/agent.yaml": pod("agent")})
+	if err := ValidateStaticPods("p"); err != nil {
+		t.Errorf("ValidateStaticPods: %v", err)
+	}
+
+	writeFiles(t, StaticPodsDir("p"), map[string]string{"p-m02/deploy.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: d\n"})
+	err := ValidateStaticPods("p")
+	if err == nil || !strings.Contains(err.Error(), "deploy.yaml is not the manifest of a named Pod") {
+		t.Errorf("ValidateStaticPods with a Deployment = %v, want an error", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	rendered, err := Render("p", "v1.34.0")
+	if err != nil || len(rendered) != 0 {
+		t.Fatalf("Render without manifests = %v, %v; want nothing", rendered, err)
+	}
+
+	writeFiles(t, Dir("p"), map[string]string{
+		"app.yaml":          "kind: ConfigMap",
+		"notes.txt":         "not a manifest",
+		"monitoring/a.yml":  "kind: Service",
+		"monitoring/b.json": `{"kind": "Namespace"}`,
+	})
+	rendered, err = Render("p", "v1.34.0")
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	names := []string{}
+	for name := range rendered {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := []string{"app.yaml", "monitoring/a.yml", "monitoring/b.json"}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("Render mismatch (-want +got):\n%s", diff)
+	}
+	if string(rendered["monitoring/a.yml"]) != "kind: Service" {
+		t.Errorf("Render changed a manifest: %q", rendered["monitoring/a.yml"])
+	}
+}
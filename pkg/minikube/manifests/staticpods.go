@@ -0,0 +1,186 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifests installs the user static pods and applies the user manifests of a profile.
+//
+// The static-pods directory of a profile holds Pod manifests that the kubelets run as static pods:
+// the files at its root run on the primary control-plane node, the files of its "all" subdirectory on
+// every node, and the files of a subdirectory named after a node on that node only.
+//
+// The manifests directory of a profile holds manifests and Helm charts applied once the cluster is
+// healthy, on every start. The objects of removed manifests are pruned.
+package manifests
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+const (
+	// AllNodes is the subdirectory of the static pods running on every node
+	AllNodes = "all"
+	// staticPodPrefix prefixes the user static pods in the kubelet manifests directory, to tell them from the control plane ones
+	staticPodPrefix = "user-"
+)
+
+// StaticPodsDir returns the directory of the static pods of a profile
+func StaticPodsDir(profile string) string {
+	return filepath.Join(localpath.Profile(profile), "static-pods")
+}
+
+// isManifest returns whether a file name is one of a manifest
+func isManifest(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// readStaticPods reads the static pods of a directory by file name, if it exists
+func readStaticPods(dir string, pods map[string][]byte) error {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "reading static pods")
+	}
+	for _, e := range entries {
+		if e.IsDir() || !isManifest(e.Name()) {
+			continue
+		}
+		p := filepath.Join(dir, e.Name())
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return errors.Wrap(err, "reading static pod")
+		}
+		var pod struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name string `yaml:"name"`
+			} `yaml:"metadata"`
+		}
+		if err := yaml.Unmarshal(data, &pod); err != nil {
+			return errors.Wrapf(err, "parsing %s", p)
+		}
+		if pod.Kind != "Pod" || pod.Metadata.Name == "" {
+			return fmt.Errorf("%s is not the manifest of a named Pod", p)
+		}
+		pods[e.Name()] = data
+	}
+	return nil
+}
+
+// StaticPods returns the static pods of a node by file name, the ones of the node subdirectory overriding the others
+func StaticPods(cc config.ClusterConfig, n config.Node) (map[string][]byte, error) {
+	dir := StaticPodsDir(cc.Name)
+	pods := map[string][]byte{}
+	if config.IsPrimaryControlPlane(cc, n) {
+		if err := readStaticPods(dir, pods); err != nil {
+			return nil, err
+		}
+	}
+	for _, sub := range []string{AllNodes, config.MachineName(cc, n)} {
+		if err := readStaticPods(filepath.Join(dir, sub), pods); err != nil {
+			return nil, err
+		}
+	}
+	return pods, nil
+}
+
+// ValidateStaticPods returns an error if a static pod of a profile is not a Pod manifest
+func ValidateStaticPods(profile string) error {
+	dir := StaticPodsDir(profile)
+	if err := readStaticPods(dir, map[string][]byte{}); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "reading static pods")
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			if err := readStaticPods(filepath.Join(dir, e.Name()), map[string][]byte{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// InstallStaticPods installs the static pods of a node into the kubelet manifests directory,
+// and removes the ones deleted from the profile since the last start
+func InstallStaticPods(cc config.ClusterConfig, n config.Node, r command.Runner) error {
+	pods, err := StaticPods(cc, n)
+	if err != nil {
+		return err
+	}
+
+	rr, err := r.RunCmd(exec.Command("sudo", "find", vmpath.GuestManifestsDir, "-maxdepth", "1", "-name", staticPodPrefix+"*"))
+	if err != nil {
+		return errors.Wrap(err, "listing static pods")
+	}
+	stale := []string{}
+	for _, p := range strings.Fields(rr.Stdout.String()) {
+		if _, ok := pods[strings.TrimPrefix(path.Base(p), staticPodPrefix)]; !ok {
+			stale = append(stale, p)
+		}
+	}
+	if len(stale) > 0 {
+		klog.Infof("removing static pods %v", stale)
+		if _, err := r.RunCmd(exec.Command("sudo", append([]string{"rm", "-f"}, stale...)...)); err != nil {
+			return errors.Wrap(err, "removing static pods")
+		}
+	}
+
+	if len(pods) == 0 {
+		return nil
+	}
+	if _, err := r.RunCmd(exec.Command("sudo", "mkdir", "-p", vmpath.GuestManifestsDir)); err != nil {
+		return errors.Wrap(err, "creating the kubelet manifests directory")
+	}
+	names := []string{}
+	for name := range pods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := assets.NewMemoryAssetTarget(pods[name], path.Join(vmpath.GuestManifestsDir, staticPodPrefix+name), "0600")
+		if err := r.Copy(f); err != nil {
+			return errors.Wrapf(err, "copying static pod %s", name)
+		}
+	}
+	klog.Infof("installed static pods %v on %s", names, config.MachineName(cc, n))
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mirror resolves the URLs of the artifacts minikube downloads against a single artifact mirror.
+//
+// The layout of a mirror, relative to its base URL, is:
+//
+//	iso/minikube-<iso version>-<arch>.iso
+//	kicbase/kicbase-<kicbase version>-<arch>.tar
+//	preload/<preload version>/<kubernetes version>/<preload tarball>
+//	release/<kubernetes version>/bin/<os>/<arch>/<binary>
+//	minikube/releases/<minikube version>/<driver or licenses.tar.gz>
+//	minikube/releases-v2.json
+//	minikube/releases-beta-v2.json
+//	gvisor/<arch>/runsc
+//	gvisor/<arch>/containerd-shim-runsc-v1
+//
+// Every artifact has its SHA-256 checksum next to it, in <artifact>.sha256,
+// except Kubernetes binaries older than v1.17, which have <binary>.sha1 as on dl.k8s.io.
+package mirror
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Env is the environment variable the mirror is passed in to the components running in the cluster
+const Env = "MINIKUBE_ARTIFACT_MIRROR"
+
+var base = ""
+
+// Validate returns an error if a base URL cannot be used as an artifact mirror
+func Validate(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %q", baseURL)
+	}
+	switch u.Scheme {
+	case "http", "https", "file":
+		return nil
+	default:
+		return fmt.Errorf("unsupported artifact mirror scheme %q, must be one of: http, https, file", u.Scheme)
+	}
+}
+
+// Set sets the base URL of the artifact mirror, an empty one disables the mirror
+func Set(baseURL string) error {
+	if baseURL == "" {
+		base = ""
+		return nil
+	}
+	if err := Validate(baseURL); err != nil {
+		return err
+	}
+	base = strings.TrimSuffix(baseURL, "/")
+	return nil
+}
+
+// Enabled returns true if an artifact mirror is set
+func Enabled() bool {
+	return base != ""
+}
+
+// Base returns the base URL of the artifact mirror, empty if none is set
+func Base() string {
+	return base
+}
+
+// URL returns the URL of an artifact on the mirror, see the package documentation for the layout
+func URL(elem ...string) string {
+	return base + "/" + path.Join(elem...)
+}
+
+// ChecksumURL returns the URL of the checksum of an artifact on the mirror
+func ChecksumURL(artifactURL string) string {
+	return artifactURL + ".sha256"
+}
+
+// WithChecksum returns the URL of an artifact, with the go-getter parameter to verify it against its checksum on the mirror
+func WithChecksum(artifactURL string) string {
+	return fmt.Sprintf("%s?checksum=file:%s", artifactURL, ChecksumURL(artifactURL))
+}
+
+// LocalPath returns the path of a file:// URL on the local filesystem, and false for any other URL
+func LocalPath(artifactURL string) (string, bool) {
+	u, err := url.Parse(artifactURL)
+	if err != nil || u.Scheme != "file" {
+		return "", false
+	}
+	p := u.Path
+	if u.Host != "" && u.Host != "localhost" {
+		// file://server/share/... is a UNC path on Windows
+		p = "//" + u.Host + p
+	}
+	if runtime.GOOS == "windows" && len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		// file:///C:/mirror is C:/mirror
+		p = p[1:]
+	}
+	return filepath.FromSlash(p), true
+}
+
+// Open opens an artifact for reading, file:// URLs are read from the local filesystem
+func Open(artifactURL string) (io.ReadCloser, error) {
+	if p, ok := LocalPath(artifactURL); ok {
+		return os.Open(p)
+	}
+	req, err := http.NewRequest("GET", artifactURL, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "creating request for %s", artifactURL)
+	}
+	req.Header.Set("User-Agent", "minikube")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting %s", artifactURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("getting %s: status code %d", artifactURL, resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// Head returns an error if an artifact does not exist, without downloading it
+func Head(artifactURL string) error {
+	if p, ok := LocalPath(artifactURL); ok {
+		_, err := os.Stat(p)
+		return err
+	}
+	resp, err := http.Head(artifactURL)
+	if err != nil {
+		return errors.Wrapf(err, "getting %s", artifactURL)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("getting %s: status code %d", artifactURL, resp.StatusCode)
+	}
+	return nil
+}
+
+// Checksum returns the hex-encoded SHA-256 checksum of an artifact, as published on the mirror
+func Checksum(artifactURL string) (string, error) {
+	u := ChecksumURL(artifactURL)
+	r, err := Open(u)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, 4096))
+	if err != nil {
+		return "", errors.Wrapf(err, "reading %s", u)
+	}
+	return parseChecksum(string(data))
+}
+
+// parseChecksum parses the content of a checksum file, either a bare checksum or the output of sha256sum
+func parseChecksum(data string) (string, error) {
+	fields := strings.Fields(data)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file")
+	}
+	sum := strings.ToLower(fields[0])
+	if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("invalid SHA-256 checksum: %q", fields[0])
+	}
+	return sum, nil
+}
+
+// Verify returns an error if a downloaded file does not match the checksum of its artifact on the mirror
+func Verify(file string, artifactURL string) error {
+	want, err := Checksum(artifactURL)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return errors.Wrapf(err, "hashing %s", file)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", artifactURL, got, want)
+	}
+	return nil
+}
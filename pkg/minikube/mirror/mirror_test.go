@@ -0,0 +1,138 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mirror
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	defer func() { base = "" }()
+
+	if err := Set("ftp://mirror.example.com"); err == nil {
+		t.Errorf("expected an unsupported scheme to be rejected")
+	}
+	if Enabled() {
+		t.Errorf("expected the mirror to stay disabled after an invalid URL")
+	}
+	if err := Set("https://artifactory.example.com/minikube/"); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if got, want := URL("iso", "minikube-v1.0.0-amd64.iso"), "https://artifactory.example.com/minikube/iso/minikube-v1.0.0-amd64.iso"; got != want {
+		t.Errorf("URL() = %q, want %q", got, want)
+	}
+	if got, want := WithChecksum("https://m/a"), "https://m/a?checksum=file:https://m/a.sha256"; got != want {
+		t.Errorf("WithChecksum() = %q, want %q", got, want)
+	}
+	if err := Set(""); err != nil || Enabled() {
+		t.Errorf("expected an empty URL to disable the mirror")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	content := []byte("runsc")
+	sum := sha256.Sum256(content)
+	checksums := map[string]string{
+		"/gvisor/amd64/runsc.sha256":   hex.EncodeToString(sum[:]) + "  runsc\n",
+		"/gvisor/amd64/wrong.sha256":   hex.EncodeToString(make([]byte, sha256.Size)),
+		"/gvisor/amd64/invalid.sha256": "not a checksum",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, ok := checksums[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(c))
+	}))
+	defer srv.Close()
+
+	file := filepath.Join(t.TempDir(), "runsc")
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		artifact string
+		wantErr  bool
+	}{
+		{artifact: "runsc"},
+		{artifact: "wrong", wantErr: true},
+		{artifact: "invalid", wantErr: true},
+		{artifact: "missing", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.artifact, func(t *testing.T) {
+			err := Verify(file, srv.URL+"/gvisor/amd64/"+tc.artifact)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Verify() = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFileMirror(t *testing.T) {
+	defer func() { base = "" }()
+
+	content := []byte("runsc")
+	sum := sha256.Sum256(content)
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "gvisor", "amd64"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gvisor", "amd64", "runsc"), content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gvisor", "amd64", "runsc.sha256"), []byte(hex.EncodeToString(sum[:])+"  runsc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set("file://" + filepath.ToSlash(dir)); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	artifact := URL("gvisor", "amd64", "runsc")
+	if err := Head(artifact); err != nil {
+		t.Errorf("Head(%s) = %v", artifact, err)
+	}
+	if err := Head(URL("gvisor", "amd64", "missing")); err == nil {
+		t.Errorf("expected a missing artifact to be reported")
+	}
+
+	r, err := Open(artifact)
+	if err != nil {
+		t.Fatalf("Open(%s) = %v", artifact, err)
+	}
+	file := filepath.Join(t.TempDir(), "runsc")
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(file, artifact); err != nil {
+		t.Errorf("Verify() = %v", err)
+	}
+}
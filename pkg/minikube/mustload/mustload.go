@@ -189,7 +189,7 @@ func Healthy(name string, options *run.CommandOptions) ClusterController {
 
 		machineName := config.MachineName(*ctrl.Config, *ctrl.CP.Node)
 
-		as, err := kverify.APIServerStatus(ctrl.CP.Runner, ctrl.CP.Hostname, ctrl.CP.Port)
+		as, err := kverify.APIServerStatus(ctrl.CP.Runner, ctrl.Config.Name, ctrl.CP.Hostname, ctrl.CP.Port)
 		if err != nil {
 			if last {
 				out.Styled(style.Shrug, `Unable to get control-plane node {{.name}} apiserver status: {{.error}}`, out.V{"name": machineName, "error": err})
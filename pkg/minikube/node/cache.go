@@ -41,6 +41,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/image"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/reason"
@@ -61,13 +62,18 @@ func beginCacheKubernetesImages(g *errgroup.Group, imageRepository string, k8sVe
 	}
 
 	// TODO: remove imageRepository check once #7695 is fixed
-	if imageRepository == "" && download.PreloadExists(k8sVersion, cRuntime, driverName) {
+	// user-built preloads are captured from a cluster, so they are only used with the image repository they were built with
+	download.SetImageRepository(imageRepository)
+	if (imageRepository == "" || download.LocalPreloadExists(k8sVersion, cRuntime)) && download.PreloadExists(k8sVersion, cRuntime, driverName) {
 		klog.Info("Caching tarball of preloaded images")
 		err := download.Preload(k8sVersion, cRuntime, driverName)
 		if err == nil {
 			klog.Infof("Finished verifying existence of preloaded tar for %s on %s", k8sVersion, cRuntime)
 			return // don't cache individual images if preload is successful.
 		}
+		if errors.Is(err, download.ErrSignature) {
+			exit.Error(reason.InetDownloadSignature, "Failed to verify the preloaded images", err)
+		}
 		klog.Warningf("Error downloading preloaded artifacts will continue without preload: %v", err)
 	}
 
@@ -141,18 +147,35 @@ func beginDownloadKicBaseImage(g *errgroup.Group, cc *config.ClusterConfig, down
 			cc.KicBaseImage = baseImg
 		}
 		var finalImg string
+		// the image expected from where it was downloaded: the base image, or the one of the artifact mirror
+		wantImg := baseImg
 		// If we end up using a fallback image, notify the user
 		defer func() {
 			if finalImg != "" {
 				cc.KicBaseImage = finalImg
-				if image.Tag(finalImg) != image.Tag(baseImg) {
+				if image.Tag(finalImg) != image.Tag(wantImg) {
 					out.WarningT(fmt.Sprintf("minikube was unable to download %s, but successfully downloaded %s as a fallback image", image.Tag(baseImg), finalImg))
 				}
 			}
 		}()
-		// first we try to download the kicbase image (and fall back images) from docker registry
 		var err error
+		// the artifact mirror takes precedence over the docker registries
+		if mirror.Enabled() && (downloadOnly || driver.IsDocker(cc.Driver) && !download.ImageExistsInDaemon(baseImg)) {
+			if finalImg, err = kicbaseTarballToCache(cc, downloadOnly); err == nil {
+				wantImg = finalImg
+				return nil
+			}
+			if errors.Is(err, download.ErrSignature) {
+				return err
+			}
+			klog.Warningf("failed to get kicbase from the artifact mirror, trying the docker registries: %v", err)
+		}
+		// first we try to download the kicbase image (and fall back images) from docker registry
 		for _, img := range append([]string{baseImg}, kic.FallbackImages...) {
+			if err = download.VerifyImagePinned(img); err != nil {
+				klog.Infof("skipping %s: %v", img, err)
+				continue
+			}
 
 			if driver.IsDocker(cc.Driver) && download.ImageExistsInDaemon(img) && !downloadOnly {
 				klog.Infof("%s exists in daemon, skipping load", img)
@@ -200,24 +223,34 @@ func beginDownloadKicBaseImage(g *errgroup.Group, cc *config.ClusterConfig, down
 		}
 		out.Ln("")
 
-		kicbaseVersion := strings.Split(kic.Version, "-")[0]
-		_, err = download.GHKicbaseTarballToCache(kicbaseVersion)
-		if err != nil {
-			klog.Infof("failed to download kicbase from github")
-			return fmt.Errorf("failed to download kic base image or any fallback image")
-		}
+		finalImg, err = kicbaseTarballToCache(cc, downloadOnly)
+		return err
+	})
+}
 
-		klog.Infof("successfully downloaded kicbase as fall back image from github")
-		if !downloadOnly && driver.IsDocker(cc.Driver) {
-			if finalImg, err = download.CacheToDaemon(fmt.Sprintf("kicbase/stable:%s", kicbaseVersion)); err == nil {
-				klog.Infof("successfully loaded and using kicbase from tarball on github")
-			} else {
-				return fmt.Errorf("failed to load kic base image into docker: %v", err)
-			}
+// kicbaseTarballToCache downloads the kicbase tarball from the minikube release page or the artifact mirror,
+// and returns the image it loaded into docker, if any
+func kicbaseTarballToCache(cc *config.ClusterConfig, downloadOnly bool) (string, error) {
+	kicbaseVersion := strings.Split(kic.Version, "-")[0]
+	src, err := download.GHKicbaseTarballToCache(kicbaseVersion)
+	if err != nil {
+		klog.Infof("failed to download kicbase tarball: %v", err)
+		if errors.Is(err, download.ErrSignature) {
+			return "", err
 		}
-		return nil
+		return "", fmt.Errorf("failed to download kic base image or any fallback image")
+	}
 
-	})
+	klog.Infof("successfully downloaded kicbase tarball from %s", src)
+	if downloadOnly || !driver.IsDocker(cc.Driver) {
+		return "", nil
+	}
+	img, err := download.CacheToDaemon(fmt.Sprintf("kicbase/stable:%s", kicbaseVersion))
+	if err != nil {
+		return "", fmt.Errorf("failed to load kic base image into docker: %v", err)
+	}
+	klog.Infof("successfully loaded and using kicbase from tarball")
+	return img, nil
 }
 
 // waitDownloadKicBaseImage blocks until the base image for KIC is downloaded.
@@ -232,6 +265,9 @@ func waitDownloadKicBaseImage(g *errgroup.Group) {
 	https://help.github.com/en/packages/using-github-packages-with-your-projects-ecosystem/configuring-docker-for-use-with-github-packages#authenticating-to-github-packages
 `)
 			}
+			if errors.Is(err, download.ErrSignature) {
+				exit.Error(reason.InetDownloadSignature, "Failed to verify the base image", err)
+			}
 			if errors.Is(err, image.ErrGithubNeedsLogin) || errors.Is(err, image.ErrNeedsLogin) {
 				exit.Message(reason.Usage, `Please either authenticate to the registry or use --base-image flag to use a different registry.`)
 			} else {
@@ -45,6 +45,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/kubeadm"
+	"k8s.io/minikube/pkg/minikube/bundle"
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/cni"
 	"k8s.io/minikube/pkg/minikube/command"
@@ -54,16 +55,20 @@ import (
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/hostcache"
+	"k8s.io/minikube/pkg/minikube/imagepolicy"
 	"k8s.io/minikube/pkg/minikube/kubeconfig"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/logs"
 	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/manifests"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/proxy"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/registry"
+	"k8s.io/minikube/pkg/minikube/registrycache"
 	"k8s.io/minikube/pkg/minikube/run"
 	"k8s.io/minikube/pkg/minikube/style"
 	"k8s.io/minikube/pkg/minikube/vmpath"
@@ -101,7 +106,7 @@ func Start(starter Starter, options *run.CommandOptions) (*kubeconfig.Settings,
 	}
 	if stopk8s {
 		nv := semver.Version{Major: 0, Minor: 0, Patch: 0}
-		cr := configureRuntimes(starter.Runner, *starter.Cfg, nv)
+		cr := configureRuntimes(starter.Runner, *starter.Cfg, nv, registryCacheAddress(starter))
 
 		showNoK8sVersionInfo(cr)
 
@@ -118,7 +123,7 @@ func Start(starter Starter, options *run.CommandOptions) (*kubeconfig.Settings,
 	}
 
 	// configure the runtime (docker, containerd, crio)
-	cr := configureRuntimes(starter.Runner, *starter.Cfg, sv)
+	cr := configureRuntimes(starter.Runner, *starter.Cfg, sv, registryCacheAddress(starter))
 
 	// check if installed runtime is compatible with current minikube code
 	if err = cruntime.CheckCompatibility(cr); err != nil {
@@ -127,6 +132,11 @@ func Start(starter Starter, options *run.CommandOptions) (*kubeconfig.Settings,
 
 	showVersionInfo(starter.Node.KubernetesVersion, cr)
 
+	// load the CNI and addon images the preload does not contain, as they cannot be pulled (intentionally non-fatal)
+	if options.Offline {
+		loadOfflineImages(starter)
+	}
+
 	// add "host.minikube.internal" dns alias (intentionally non-fatal)
 	hostIP, err := cluster.HostIP(starter.Host, starter.Cfg.Name)
 	if err != nil {
@@ -143,6 +153,10 @@ func Start(starter Starter, options *run.CommandOptions) (*kubeconfig.Settings,
 		if err != nil {
 			return nil, err
 		}
+		// enforce the image policy on admission (intentionally non-fatal)
+		if err := imagepolicy.ApplyAdmission(*starter.Cfg, starter.Runner); err != nil {
+			out.WarningT("Unable to enforce the image policy on admission: {{.error}}", out.V{"error": err})
+		}
 		// configure CoreDNS concurrently from primary control-plane node only and only on first node start
 		if !starter.PreExists {
 			wg.Add(1)
@@ -193,6 +207,11 @@ func Start(starter Starter, options *run.CommandOptions) (*kubeconfig.Settings,
 		}
 	}
 
+	// install the user static pods of the node (intentionally non-fatal)
+	if err := manifests.InstallStaticPods(*starter.Cfg, *starter.Node, starter.Runner); err != nil {
+		out.WarningT("Unable to install the static pods: {{.error}}", out.V{"error": err})
+	}
+
 	go configureMounts(&wg, *starter.Cfg)
 
 	wg.Add(1)
@@ -404,12 +423,57 @@ func Provision(cc *config.ClusterConfig, n *config.Node, delOnFail bool, options
 	if driver.IsKIC(cc.Driver) {
 		waitDownloadKicBaseImage(&kicGroup)
 	}
+	hostcache.MarkUsed(cc)
 
 	return startMachine(cc, n, delOnFail, options)
 }
 
+// registryCacheAddress starts the registry cache shared by all profiles if the cluster uses it,
+// and returns the address at which the node reaches it (intentionally non-fatal)
+func registryCacheAddress(starter Starter) string {
+	cc := starter.Cfg
+	if !cc.RegistryCache {
+		return ""
+	}
+	if cc.KubernetesConfig.ContainerRuntime == constants.Docker && len(cc.RegistryMirror) > 0 {
+		out.WarningT("Not using the registry cache, as registry mirrors are already set with --registry-mirror")
+		return ""
+	}
+	var hostIP net.IP
+	if !driver.IsKIC(cc.Driver) {
+		ip, err := cluster.HostIP(starter.Host, cc.Name)
+		if err != nil {
+			out.WarningT("Unable to use the registry cache: {{.error}}", out.V{"error": err})
+			return ""
+		}
+		hostIP = ip
+	}
+	addr, err := registrycache.Ensure(cc, hostIP)
+	if err != nil {
+		out.WarningT("Unable to use the registry cache: {{.error}}", out.V{"error": err})
+		return ""
+	}
+	klog.Infof("Using registry cache at %s", addr)
+	return addr
+}
+
+// loadOfflineImages loads the cached CNI and addon images of the cluster into the node (intentionally non-fatal)
+func loadOfflineImages(starter Starter) {
+	imgs, err := bundle.CachedImages(starter.Cfg, bundle.EnabledAddons(starter.Cfg, viper.GetStringSlice(config.AddonListFlag)))
+	if err != nil {
+		out.WarningT("Unable to list the cached images: {{.error}}", out.V{"error": err})
+		return
+	}
+	if len(imgs) == 0 {
+		return
+	}
+	if err := machine.LoadCachedImages(starter.Cfg, starter.Runner, imgs, detect.ImageCacheDir(), false); err != nil {
+		out.WarningT("Unable to load the cached images: {{.error}}", out.V{"error": err})
+	}
+}
+
 // ConfigureRuntimes does what needs to happen to get a runtime going.
-func configureRuntimes(runner cruntime.CommandRunner, cc config.ClusterConfig, kv semver.Version) cruntime.Manager {
+func configureRuntimes(runner cruntime.CommandRunner, cc config.ClusterConfig, kv semver.Version, registryCache string) cruntime.Manager {
 	co := cruntime.Config{
 		Type:              cc.KubernetesConfig.ContainerRuntime,
 		Socket:            cc.KubernetesConfig.CRISocket,
@@ -418,6 +482,8 @@ func configureRuntimes(runner cruntime.CommandRunner, cc config.ClusterConfig, k
 		ImageRepository:   cc.KubernetesConfig.ImageRepository,
 		KubernetesVersion: kv,
 		InsecureRegistry:  cc.InsecureRegistry,
+		RegistryCache:     registryCache,
+		ImagePolicy:       imagepolicy.RuntimePolicy(cc),
 	}
 	if cc.GPUs != "" {
 		co.GPUs = cc.GPUs
@@ -645,7 +711,7 @@ func setupKubeconfig(h host.Host, cc config.ClusterConfig, n config.Node, cluste
 		ClusterServerAddress: addr,
 		ClientCertificate:    localpath.ClientCert(cc.Name),
 		ClientKey:            localpath.ClientKey(cc.Name),
-		CertificateAuthority: localpath.CACert(),
+		CertificateAuthority: localpath.ProfileCACert(cc.Name),
 		KeepContext:          cc.KeepContext,
 		EmbedCerts:           cc.EmbedCerts,
 	}
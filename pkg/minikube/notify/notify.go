@@ -32,6 +32,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/detect"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/run"
 	"k8s.io/minikube/pkg/minikube/style"
@@ -46,12 +47,28 @@ var (
 
 // MaybePrintUpdateTextFromGithub prints update text if needed, from github
 func MaybePrintUpdateTextFromGithub(options *run.CommandOptions) {
-	maybePrintUpdateText(GithubMinikubeReleasesURL, GithubMinikubeBetaReleasesURL, lastUpdateCheckFilePath, options)
+	latest, beta := releasesURLs(GithubMinikubeReleasesURL, GithubMinikubeBetaReleasesURL)
+	maybePrintUpdateText(latest, beta, lastUpdateCheckFilePath, options)
 }
 
 // MaybePrintUpdateTextFromAliyunMirror prints update text if needed, from Aliyun mirror
 func MaybePrintUpdateTextFromAliyunMirror(options *run.CommandOptions) {
-	maybePrintUpdateText(GithubMinikubeReleasesAliyunURL, GithubMinikubeBetaReleasesAliyunURL, lastUpdateCheckFilePath, options)
+	latest, beta := releasesURLs(GithubMinikubeReleasesAliyunURL, GithubMinikubeBetaReleasesAliyunURL)
+	maybePrintUpdateText(latest, beta, lastUpdateCheckFilePath, options)
+}
+
+// ReleasesURL returns the URL of the minikube releases JSON file, on the artifact mirror if one is set
+func ReleasesURL() string {
+	latest, _ := releasesURLs(GithubMinikubeReleasesURL, GithubMinikubeBetaReleasesURL)
+	return latest
+}
+
+// releasesURLs returns the URLs of the releases and beta releases JSON files, replaced by the artifact mirror if one is set
+func releasesURLs(latest, beta string) (string, string) {
+	if mirror.Enabled() {
+		return mirror.URL("minikube", "releases-v2.json"), mirror.URL("minikube", "releases-beta-v2.json")
+	}
+	return latest, beta
 }
 
 func maybePrintUpdateText(latestReleasesURL string, betaReleasesURL string, lastUpdatePath string, options *run.CommandOptions) {
@@ -120,7 +137,7 @@ func shouldCheckURLVersion(filePath string, options *run.CommandOptions) bool {
 	if !viper.GetBool(config.WantUpdateNotification) {
 		return false
 	}
-	if options.NonInteractive {
+	if options.NonInteractive || options.Offline {
 		return false
 	}
 	if out.JSON {
@@ -167,6 +184,14 @@ func (r *Releases) UnmarshalJSON(p []byte) error {
 }
 
 func getJSON(url string, target *Releases) error {
+	if _, ok := mirror.LocalPath(url); ok {
+		r, err := mirror.Open(url)
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		return json.NewDecoder(r).Decode(target)
+	}
 	client := &http.Client{}
 
 	req, err := http.NewRequest("GET", url, nil)
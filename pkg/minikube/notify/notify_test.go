@@ -30,6 +30,7 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/spf13/viper"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/run"
 	"k8s.io/minikube/pkg/minikube/tests"
@@ -237,6 +238,20 @@ func TestMaybePrintUpdateText(t *testing.T) {
 	}
 }
 
+func TestReleasesURLs(t *testing.T) {
+	if got := ReleasesURL(); got != GithubMinikubeReleasesURL {
+		t.Errorf("ReleasesURL() = %s, want %s", got, GithubMinikubeReleasesURL)
+	}
+	if err := mirror.Set("https://artifactory.example.com/minikube"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = mirror.Set("") }()
+	latest, beta := releasesURLs(GithubMinikubeReleasesAliyunURL, GithubMinikubeBetaReleasesAliyunURL)
+	if latest != "https://artifactory.example.com/minikube/minikube/releases-v2.json" || beta != "https://artifactory.example.com/minikube/minikube/releases-beta-v2.json" {
+		t.Errorf("releasesURLs() = %s, %s, want the artifact mirror", latest, beta)
+	}
+}
+
 func TestDownloadURL(t *testing.T) {
 	const urlBase = "https://github.com/kubernetes/minikube/releases/download/"
 	type args struct {
@@ -836,6 +836,10 @@ var localNetworkIssues = []match{
 
 // internetIssues are internet related problems.
 var internetIssues = []match{
+	{
+		Kind:   InetDownloadSignature,
+		Regexp: re(`signature verification failed`),
+	},
 	{
 		Kind: Kind{
 			ID:       "INET_GCR_UNAVAILABLE",
@@ -270,6 +270,8 @@ var (
 	HostCurrentUser = Kind{ID: "HOST_CURRENT_USER", ExitCode: ExHostConfig}
 	// minikube failed to delete cached images from host
 	HostDelCache = Kind{ID: "HOST_DEL_CACHE", ExitCode: ExHostError}
+	// minikube failed to inspect or garbage collect the artifacts cached on the host
+	HostCacheGC = Kind{ID: "HOST_CACHE_GC", ExitCode: ExHostError}
 	// minikube failed to kill a mount process
 	HostKillMountProc = Kind{ID: "HOST_KILL_MOUNT_PROC", ExitCode: ExHostError}
 	// minikube failed to update host Kubernetes resources config
@@ -282,10 +284,16 @@ var (
 	HostMountPid = Kind{ID: "HOST_MOUNT_PID", ExitCode: ExHostError}
 	// minikube was passed a path to a host directory that does not exist
 	HostPathMissing = Kind{ID: "HOST_PATH_MISSING", ExitCode: ExHostNotFound}
+	// minikube failed to manage the registry cache on the host
+	HostRegistryCache = Kind{ID: "HOST_REGISTRY_CACHE", ExitCode: ExHostError}
+	// minikube failed to install a bundle into the local caches
+	HostBundleInstall = Kind{ID: "HOST_BUNDLE_INSTALL", ExitCode: ExHostError}
 	// minikube failed to access info for a directory path
 	HostPathStat = Kind{ID: "HOST_PATH_STAT", ExitCode: ExHostError}
 	// minikube failed to purge minikube config directories
 	HostPurge = Kind{ID: "HOST_PURGE", ExitCode: ExHostError}
+	// minikube failed to issue, read or remove certificates on the host
+	HostCerts = Kind{ID: "HOST_CERTS", ExitCode: ExHostError}
 	// minikube failed to persist profile config
 	HostSaveProfile = Kind{ID: "HOST_SAVE_PROFILE", ExitCode: ExHostConfig}
 	// Host doesn't support 9p
@@ -335,6 +343,10 @@ var (
 	GuestCacheLoad = Kind{ID: "GUEST_CACHE_LOAD", ExitCode: ExGuestError}
 	// minikube failed to setup certificates
 	GuestCert = Kind{ID: "GUEST_CERT", ExitCode: ExGuestError}
+	// minikube failed to rotate the key encrypting the secrets of the cluster
+	GuestSecretsEncryption = Kind{ID: "GUEST_SECRETS_ENCRYPTION", ExitCode: ExGuestError}
+	// minikube failed to bind or unbind the RBAC roles of a client user
+	GuestRBAC = Kind{ID: "GUEST_RBAC", ExitCode: ExGuestError}
 	// minikube failed to access the control plane
 	GuestCpConfig = Kind{ID: "GUEST_CP_CONFIG", ExitCode: ExGuestConfig}
 	// minikube failed to properly delete a resource, such as a profile
@@ -355,6 +367,12 @@ var (
 	GuestImagePush = Kind{ID: "GUEST_IMAGE_PUSH", ExitCode: ExGuestError}
 	// minikube failed to tag an image
 	GuestImageTag = Kind{ID: "GUEST_IMAGE_TAG", ExitCode: ExGuestError}
+	// minikube failed to apply the image policy
+	GuestImagePolicy = Kind{ID: "GUEST_IMAGE_POLICY", ExitCode: ExGuestError}
+	// minikube failed to generate the software bill of materials
+	GuestSBOM = Kind{ID: "GUEST_SBOM", ExitCode: ExGuestError}
+	// minikube failed to create a preload tarball from a node
+	GuestPreloadCreate = Kind{ID: "GUEST_PRELOAD_CREATE", ExitCode: ExGuestError}
 	// minikube failed to load host
 	GuestLoadHost = Kind{ID: "GUEST_LOAD_HOST", ExitCode: ExGuestError}
 	// minkube failed to create a mount
@@ -440,6 +458,20 @@ var (
 	InetVersionUnavailable = Kind{ID: "INET_VERSION_UNAVAILABLE", ExitCode: ExInternetUnavailable}
 	// minikube received invalid empty data for latest release/version info from the server
 	InetVersionEmpty = Kind{ID: "INET_VERSION_EMPTY", ExitCode: ExInternetConfig}
+	// minikube failed to download the artifacts of a bundle
+	InetBundleCreate = Kind{ID: "INET_BUNDLE_CREATE", ExitCode: ExInternetError}
+	// minikube was started offline but artifacts it needs are not cached
+	InetOfflineMissing = Kind{
+		ID:       "INET_OFFLINE_MISSING",
+		ExitCode: ExInternetNotFound,
+		Advice:   translate.T("Create a bundle with 'minikube bundle create' on a machine with internet access, and install it with 'minikube bundle install'"),
+	}
+	// minikube downloaded an artifact whose detached signature does not verify against the signing key
+	InetDownloadSignature = Kind{
+		ID:       "INET_DOWNLOAD_SIGNATURE",
+		ExitCode: ExInternetConflict,
+		Advice:   translate.T("The artifact was not signed with the configured signing key, or was tampered with. Make sure the download source publishes a <artifact>.minisig signature made with the key set by --download-signing-key"),
+	}
 
 	// minikube failed to enable the current container runtime
 	RuntimeEnable = Kind{ID: "RUNTIME_ENABLE", ExitCode: ExRuntimeError}
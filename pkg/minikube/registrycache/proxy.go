@@ -0,0 +1,309 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrycache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// Serve runs the registry cache process on port of each of the addresses, until it fails. The cache is not
+// authenticated, so it only listens on the addresses the nodes reach the host at, never on all interfaces.
+func Serve(addresses []string, port int) error {
+	if len(addresses) == 0 {
+		return fmt.Errorf("no address to listen on")
+	}
+	p := newProxy(processDataDir(), Upstream)
+	errs := make(chan error, len(addresses))
+	for _, a := range addresses {
+		addr := net.JoinHostPort(a, strconv.Itoa(port))
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return errors.Wrapf(err, "listening on %s", addr)
+		}
+		klog.Infof("Serving registry cache of %s from %s on %s", Upstream, p.dir, addr)
+		srv := &http.Server{
+			Handler:           p,
+			ReadHeaderTimeout: 30 * time.Second,
+		}
+		go func() { errs <- srv.Serve(l) }()
+	}
+	return <-errs
+}
+
+// proxy is a pull-through cache of a registry, keeping manifests and blobs on disk.
+// Manifests by tag are always resolved upstream, falling back to the cache when it is unreachable.
+type proxy struct {
+	dir      string
+	upstream string
+	nameOpts []name.Option
+	options  []remote.Option
+}
+
+func newProxy(dir string, upstream string, nameOpts ...name.Option) *proxy {
+	return &proxy{
+		dir:      dir,
+		upstream: upstream,
+		nameOpts: nameOpts,
+		options:  []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)},
+	}
+}
+
+func (p *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "the registry cache is read-only", http.StatusMethodNotAllowed)
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	if path == "" || r.URL.Path == "/v2" {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "{}")
+		return
+	}
+	if i := strings.LastIndex(path, "/manifests/"); i > 0 {
+		p.serveManifest(w, r, path[:i], path[i+len("/manifests/"):])
+		return
+	}
+	if i := strings.LastIndex(path, "/blobs/"); i > 0 {
+		p.serveBlob(w, r, path[:i], path[i+len("/blobs/"):])
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// manifestPath is where a manifest is cached, with its media type alongside
+func (p *proxy) manifestPath(h v1.Hash) string {
+	return filepath.Join(p.dir, "manifests", h.Algorithm, h.Hex)
+}
+
+// tagPath is where the digest of a tag is cached
+func (p *proxy) tagPath(repo string, tag string) string {
+	return filepath.Join(p.dir, "tags", filepath.FromSlash(repo), tag)
+}
+
+// blobPath is where a blob is cached
+func (p *proxy) blobPath(h v1.Hash) string {
+	return filepath.Join(p.dir, "blobs", h.Algorithm, h.Hex)
+}
+
+func (p *proxy) serveManifest(w http.ResponseWriter, r *http.Request, repo string, ref string) {
+	repository, err := name.NewRepository(p.upstream+"/"+repo, p.nameOpts...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var data []byte
+	var mediaType string
+	var digest v1.Hash
+	if h, err := v1.NewHash(ref); err == nil {
+		data, mediaType, err = p.cachedManifest(h)
+		if err != nil {
+			data, mediaType, digest, err = p.fetchManifest(repository.Digest(ref), "")
+			if err != nil {
+				upstreamError(w, err)
+				return
+			}
+		}
+		digest = h
+	} else {
+		data, mediaType, digest, err = p.fetchManifest(repository.Tag(ref), p.tagPath(repo, ref))
+		if err != nil {
+			// serve the last known digest of the tag when upstream is unreachable
+			cached, rerr := os.ReadFile(p.tagPath(repo, ref))
+			if rerr != nil {
+				upstreamError(w, err)
+				return
+			}
+			klog.Warningf("serving cached %s:%s: %v", repo, ref, err)
+			if digest, err = v1.NewHash(string(cached)); err == nil {
+				data, mediaType, err = p.cachedManifest(digest)
+			}
+			if err != nil {
+				upstreamError(w, err)
+				return
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Docker-Content-Digest", digest.String())
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	if r.Method == http.MethodGet {
+		if _, err := w.Write(data); err != nil {
+			klog.Warningf("writing manifest %s: %v", digest, err)
+		}
+	}
+}
+
+// cachedManifest returns a manifest and its media type from the cache
+func (p *proxy) cachedManifest(h v1.Hash) ([]byte, string, error) {
+	data, err := os.ReadFile(p.manifestPath(h))
+	if err != nil {
+		return nil, "", err
+	}
+	mediaType, err := os.ReadFile(p.manifestPath(h) + ".type")
+	if err != nil {
+		return nil, "", err
+	}
+	return data, string(mediaType), nil
+}
+
+// fetchManifest gets a manifest from upstream and caches it, recording its digest at tagFile if set
+func (p *proxy) fetchManifest(ref name.Reference, tagFile string) ([]byte, string, v1.Hash, error) {
+	desc, err := remote.Get(ref, p.options...)
+	if err != nil {
+		return nil, "", v1.Hash{}, err
+	}
+	mp := p.manifestPath(desc.Digest)
+	if err := writeFileAtomic(mp, desc.Manifest); err != nil {
+		klog.Warningf("caching manifest %s: %v", desc.Digest, err)
+	} else if err := writeFileAtomic(mp+".type", []byte(desc.MediaType)); err != nil {
+		klog.Warningf("caching manifest %s: %v", desc.Digest, err)
+	}
+	if tagFile != "" {
+		if err := writeFileAtomic(tagFile, []byte(desc.Digest.String())); err != nil {
+			klog.Warningf("caching tag %s: %v", ref, err)
+		}
+	}
+	return desc.Manifest, string(desc.MediaType), desc.Digest, nil
+}
+
+func (p *proxy) serveBlob(w http.ResponseWriter, r *http.Request, repo string, digest string) {
+	h, err := v1.NewHash(digest)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Docker-Content-Digest", h.String())
+	w.Header().Set("Content-Type", "application/octet-stream")
+
+	bp := p.blobPath(h)
+	if f, err := os.Open(bp); err == nil {
+		defer f.Close()
+		// the modification time is used to prune the least recently used blobs
+		now := time.Now()
+		if err := os.Chtimes(bp, now, now); err != nil {
+			klog.Warningf("touching %s: %v", bp, err)
+		}
+		http.ServeContent(w, r, "", time.Time{}, f)
+		return
+	}
+
+	repository, err := name.NewRepository(p.upstream+"/"+repo, p.nameOpts...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	layer, err := remote.Layer(repository.Digest(h.String()), p.options...)
+	if err != nil {
+		upstreamError(w, err)
+		return
+	}
+	size, err := layer.Size()
+	if err != nil {
+		upstreamError(w, err)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	if r.Method == http.MethodHead {
+		return
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		upstreamError(w, err)
+		return
+	}
+	defer rc.Close()
+	if err := p.streamBlob(w, rc, h, bp); err != nil {
+		klog.Warningf("caching blob %s: %v", h, err)
+	}
+}
+
+// streamBlob writes a blob to the client while storing it in the cache, keeping it only if its digest matches
+func (p *proxy) streamBlob(w io.Writer, rc io.Reader, h v1.Hash, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		_, cerr := io.Copy(w, rc)
+		return errors.Wrapf(err, "creating blob dir (copy: %v)", cerr)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), h.Hex+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, tmp, hasher), rc); err != nil {
+		return err
+	}
+	if h.Algorithm != "sha256" || hex.EncodeToString(hasher.Sum(nil)) != h.Hex {
+		return fmt.Errorf("digest mismatch for %s", h)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dst)
+}
+
+// upstreamError replies with the status of a failed upstream request
+func upstreamError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode != 0 {
+		status = terr.StatusCode
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// writeFileAtomic writes data to a temporary file which is then renamed to path
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,685 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package registrycache runs a pull-through cache of Docker Hub on the host, shared by all profiles.
+// The kic drivers run it as a registry container attached to the network of each profile,
+// the other drivers run it as a minikube process listening on the host.
+package registrycache
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/process"
+)
+
+const (
+	// ContainerName is the name of the registry cache container run for the kic drivers
+	ContainerName = "minikube-registry-cache"
+	// Image is the registry image run in proxy mode for the kic drivers, bumped with `make update-registry-version`
+	Image = "docker.io/library/registry:3.0.0@sha256:cd92709b4191c5779cd7215ccd695db6c54652e7a62843197e367427efb84d0e"
+	// ContainerPort is the port the registry cache container listens on
+	ContainerPort = 5000
+	// ProcessPort is the port on the host the registry cache process listens on
+	ProcessPort = 5050
+	// Upstream is the registry the cache pulls through from
+	Upstream = "registry-1.docker.io"
+	// DefaultMaxSize is the size cap of the cache unless configured otherwise
+	DefaultMaxSize = "20GB"
+
+	stateFile  = "state.json"
+	logFile    = "registry.log"
+	accessFile = "access.json"
+)
+
+// servedBlob matches the blobs served in the logs of the registry container, with their timestamp
+var servedBlob = regexp.MustCompile(`(?m)^(\S+) .*/v2/\S+/blobs/sha256:([a-f0-9]{64})`)
+
+// State is the persisted state of the registry cache frontends that were started
+type State struct {
+	// OCIBinary is set once the cache container was started for a kic driver
+	OCIBinary string `json:"ociBinary,omitempty"`
+	// LogsSince is when the logs of the cache container were last read for the access index
+	LogsSince string `json:"logsSince,omitempty"`
+	// PID is set once the cache process was started for the other drivers
+	PID  int `json:"pid,omitempty"`
+	Port int `json:"port,omitempty"`
+	// Listen are the addresses the cache process listens on
+	Listen []string `json:"listen,omitempty"`
+}
+
+// Status is the status of the registry cache
+type Status struct {
+	Dir       string `json:"dir" yaml:"dir"`
+	Size      int64  `json:"size" yaml:"size"`
+	MaxSize   int64  `json:"maxSize" yaml:"maxSize"`
+	Blobs     int    `json:"blobs" yaml:"blobs"`
+	Container string `json:"container,omitempty" yaml:"container,omitempty"`
+	Process   string `json:"process,omitempty" yaml:"process,omitempty"`
+	Port      int    `json:"port,omitempty" yaml:"port,omitempty"`
+}
+
+// Dir returns the directory the registry cache stores its data in
+func Dir() string {
+	return localpath.MakeMiniPath("cache", "registry")
+}
+
+// containerDataDir is the storage of the registry container, in the distribution layout
+func containerDataDir() string {
+	return filepath.Join(Dir(), "registry")
+}
+
+// inContainerDir returns whether a path is in the storage of the registry container
+func inContainerDir(p string) bool {
+	return strings.HasPrefix(p, containerDataDir()+string(filepath.Separator))
+}
+
+// processDataDir is the storage of the registry cache process
+func processDataDir() string {
+	return filepath.Join(Dir(), "proxy")
+}
+
+func loadState() (*State, error) {
+	s := &State{}
+	data, err := os.ReadFile(filepath.Join(Dir(), stateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, errors.Wrap(err, "parsing registry cache state")
+	}
+	return s, nil
+}
+
+func (s *State) save() error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(Dir(), stateFile), data, 0644)
+}
+
+// MaxSize returns the configured size cap of the registry cache in bytes
+func MaxSize() (int64, error) {
+	s := viper.GetString(config.RegistryCacheMaxSize)
+	if s == "" {
+		s = DefaultMaxSize
+	}
+	size, err := units.FromHumanSize(s)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s %q", config.RegistryCacheMaxSize, s)
+	}
+	return size, nil
+}
+
+// Ensure starts the registry cache for the driver of cc if it is not running yet, after pruning it
+// to its size cap, and returns the address (host:port) at which the nodes of cc reach it.
+// hostIP is the address of the host as seen from the nodes, used when the cache runs as a process.
+func Ensure(cc *config.ClusterConfig, hostIP net.IP) (string, error) {
+	if maxSize, err := MaxSize(); err != nil {
+		klog.Warningf("not pruning the registry cache: %v", err)
+	} else if _, _, err := Prune(maxSize); err != nil {
+		klog.Warningf("failed to prune the registry cache: %v", err)
+	}
+
+	if driver.IsKIC(cc.Driver) {
+		network := cc.Network
+		if network == "" {
+			network = cc.Name
+		}
+		return ensureContainer(cc.Driver, network)
+	}
+
+	if hostIP == nil {
+		return "", fmt.Errorf("unknown host address")
+	}
+	port, err := ensureProcess(hostIP)
+	if err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(hostIP.String(), strconv.Itoa(port)), nil
+}
+
+// containerUser returns the user the registry cache container runs as, so that the blobs it writes belong to the
+// user of the host, who prunes them. It is empty when root in the container is already the user of the host, with
+// a rootless daemon, or when the daemon runs in a VM sharing the files as the user of the host.
+func containerUser(ociBin string) string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+	if info, err := oci.CachedDaemonInfo(ociBin); err == nil && info.Rootless {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid())
+}
+
+// recreateContainer removes a registry cache container run with another image or user, and gives the files it
+// wrote to the user of the new container
+func recreateContainer(ociBin string, user string) (bool, error) {
+	out, err := oci.PrefixCmd(exec.Command(ociBin, "container", "inspect", "-f", "{{.Config.Image}}|{{.Config.User}}", ContainerName)).Output()
+	if err != nil {
+		return false, errors.Wrapf(err, "inspecting %s", ContainerName)
+	}
+	if strings.TrimSpace(string(out)) == Image+"|"+user {
+		return false, nil
+	}
+	klog.Infof("Recreating registry cache container %s, run as %q", ContainerName, strings.TrimSpace(string(out)))
+	if out, err := oci.PrefixCmd(exec.Command(ociBin, "rm", "-f", ContainerName)).CombinedOutput(); err != nil {
+		return false, errors.Wrapf(err, "removing registry cache container: %s", out)
+	}
+	if user == "" {
+		return true, nil
+	}
+	if out, err := oci.PrefixCmd(exec.Command(ociBin, "run", "--rm",
+		"--entrypoint", "chown",
+		"-v", fmt.Sprintf("%s:/var/lib/registry", containerDataDir()),
+		Image, "-R", user, "/var/lib/registry")).CombinedOutput(); err != nil {
+		return false, errors.Wrapf(err, "changing the owner of the registry cache: %s", out)
+	}
+	return true, nil
+}
+
+// ensureContainer runs the registry cache container and connects it to the network of a profile
+func ensureContainer(ociBin string, network string) (string, error) {
+	exists, err := oci.ContainerExists(ociBin, ContainerName)
+	if err != nil {
+		return "", err
+	}
+	user := containerUser(ociBin)
+	if exists {
+		recreated, err := recreateContainer(ociBin, user)
+		if err != nil {
+			return "", err
+		}
+		exists = !recreated
+	}
+	if !exists {
+		if err := os.MkdirAll(containerDataDir(), 0755); err != nil {
+			return "", err
+		}
+		klog.Infof("Starting registry cache container %s", ContainerName)
+		args := []string{"run", "-d",
+			"--name", ContainerName,
+			"--restart", "unless-stopped",
+			"--label", fmt.Sprintf("%s=true", oci.CreatedByLabelKey),
+			"-e", "REGISTRY_PROXY_REMOTEURL=https://" + Upstream,
+			"-v", fmt.Sprintf("%s:/var/lib/registry", containerDataDir()),
+		}
+		if user != "" {
+			args = append(args, "--user", user)
+		}
+		cmd := oci.PrefixCmd(exec.Command(ociBin, append(args, Image)...))
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return "", errors.Wrapf(err, "running registry cache container: %s", out)
+		}
+	} else if running, err := oci.ContainerRunning(ociBin, ContainerName); err == nil && !running {
+		klog.Infof("Restarting registry cache container %s", ContainerName)
+		if out, err := oci.PrefixCmd(exec.Command(ociBin, "start", ContainerName)).CombinedOutput(); err != nil {
+			return "", errors.Wrapf(err, "starting registry cache container: %s", out)
+		}
+	}
+
+	st, err := loadState()
+	if err != nil {
+		return "", err
+	}
+	st.OCIBinary = ociBin
+	if err := st.save(); err != nil {
+		return "", err
+	}
+
+	ip, err := oci.ContainerNetworkIP(ociBin, ContainerName, network)
+	if err != nil {
+		return "", err
+	}
+	if ip == "" {
+		if err := oci.ConnectNetwork(ociBin, network, ContainerName); err != nil {
+			return "", err
+		}
+		if ip, err = oci.ContainerNetworkIP(ociBin, ContainerName, network); err != nil {
+			return "", err
+		}
+	}
+	if ip == "" {
+		return "", fmt.Errorf("registry cache container has no address on network %s", network)
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(ContainerPort)), nil
+}
+
+// DisconnectNetworks disconnects the registry cache container from the networks with a label, so that the
+// networks of a deleted profile can be removed
+func DisconnectNetworks(ociBin string, label string) error {
+	networks, err := oci.NetworkNamesByLabel(ociBin, label)
+	if err != nil {
+		return errors.Wrap(err, "listing networks")
+	}
+	for _, n := range networks {
+		ip, err := oci.ContainerNetworkIP(ociBin, ContainerName, n)
+		if err != nil || ip == "" {
+			continue
+		}
+		klog.Infof("Disconnecting registry cache container %s from network %s", ContainerName, n)
+		if err := oci.DisconnectNetwork(ociBin, n, ContainerName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processRunning returns whether the registry cache process of the state is alive
+func processRunning(st *State) bool {
+	if st.PID == 0 {
+		return false
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return false
+	}
+	running, err := process.Exists(st.PID, filepath.Base(exe))
+	if err != nil {
+		klog.Warningf("checking registry cache process %d: %v", st.PID, err)
+	}
+	return running
+}
+
+// listenAddresses returns the addresses the cache process must listen on to also serve the nodes reaching the
+// host at hostIP, and whether they differ from the current ones
+func listenAddresses(current []string, hostIP net.IP) ([]string, bool) {
+	addrs := append([]string{}, current...)
+	changed := false
+	for _, a := range []string{"127.0.0.1", hostIP.String()} {
+		if !slices.Contains(addrs, a) {
+			addrs = append(addrs, a)
+			changed = true
+		}
+	}
+	return addrs, changed
+}
+
+// ensureProcess starts the registry cache process in the background, listening on hostIP, and returns its port.
+// The process is restarted when it does not listen on hostIP yet.
+func ensureProcess(hostIP net.IP) (int, error) {
+	st, err := loadState()
+	if err != nil {
+		return 0, err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	listen, changed := listenAddresses(st.Listen, hostIP)
+	if processRunning(st) {
+		if !changed {
+			return st.Port, nil
+		}
+		klog.Infof("Restarting registry cache process to listen on %v", listen)
+		if err := process.Terminate(st.PID, filepath.Base(exe)); err != nil && err != os.ErrProcessDone {
+			return 0, errors.Wrap(err, "stopping registry cache process")
+		}
+		for start := time.Now(); processRunning(st); time.Sleep(250 * time.Millisecond) {
+			if time.Since(start) > 10*time.Second {
+				return 0, fmt.Errorf("registry cache process %d did not stop", st.PID)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return 0, err
+	}
+	log, err := os.OpenFile(filepath.Join(Dir(), logFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer log.Close()
+
+	klog.Infof("Starting registry cache process on port %d of %v", ProcessPort, listen)
+	cmd := exec.Command(exe, "cache", "registry", "serve", "--port", strconv.Itoa(ProcessPort), "--listen-address", strings.Join(listen, ","))
+	cmd.Stdout = log
+	cmd.Stderr = log
+	detach(cmd)
+	if err := cmd.Start(); err != nil {
+		return 0, errors.Wrap(err, "starting registry cache process")
+	}
+	st.PID = cmd.Process.Pid
+	st.Port = ProcessPort
+	st.Listen = listen
+	if err := st.save(); err != nil {
+		return 0, err
+	}
+	if err := cmd.Process.Release(); err != nil {
+		klog.Warningf("releasing registry cache process: %v", err)
+	}
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(ProcessPort))
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(250 * time.Millisecond) {
+		if conn, err := net.DialTimeout("tcp", addr, time.Second); err == nil {
+			conn.Close()
+			return ProcessPort, nil
+		}
+	}
+	return 0, fmt.Errorf("registry cache process did not start listening on %s, see %s", addr, filepath.Join(Dir(), logFile))
+}
+
+// GetStatus returns the status of the registry cache
+func GetStatus() (*Status, error) {
+	st, err := loadState()
+	if err != nil {
+		return nil, err
+	}
+	blobs, size, err := cachedBlobs(Dir(), nil)
+	if err != nil {
+		return nil, err
+	}
+	maxSize, err := MaxSize()
+	if err != nil {
+		return nil, err
+	}
+	s := &Status{Dir: Dir(), Size: size, MaxSize: maxSize, Blobs: len(blobs)}
+	if st.OCIBinary != "" {
+		cs, err := oci.ContainerStatus(st.OCIBinary, ContainerName)
+		if err != nil {
+			klog.Warningf("getting status of %s: %v", ContainerName, err)
+		}
+		s.Container = cs.String()
+	}
+	if st.PID != 0 {
+		s.Process = "Stopped"
+		if processRunning(st) {
+			s.Process = "Running"
+			s.Port = st.Port
+		}
+	}
+	return s, nil
+}
+
+// blob is a cached layer or manifest blob
+type blob struct {
+	path     string
+	size     int64
+	lastUsed time.Time
+}
+
+// blobHex returns the digest of a blob file, in the layout of the registry container or of the process
+func blobHex(p string) string {
+	if filepath.Base(p) == "data" {
+		return filepath.Base(filepath.Dir(p))
+	}
+	return filepath.Base(p)
+}
+
+// loadAccessIndex returns when the blobs served by the registry container were last used, by digest
+func loadAccessIndex() (map[string]time.Time, error) {
+	index := map[string]time.Time{}
+	data, err := os.ReadFile(filepath.Join(Dir(), accessFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, errors.Wrap(err, "parsing registry cache access index")
+	}
+	return index, nil
+}
+
+func saveAccessIndex(index map[string]time.Time) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(Dir(), accessFile), data, 0644)
+}
+
+// recordServedBlobs adds the blobs served in timestamped logs of the registry container to the access index
+func recordServedBlobs(index map[string]time.Time, logs string) {
+	for _, m := range servedBlob.FindAllStringSubmatch(logs, -1) {
+		t, err := time.Parse(time.RFC3339Nano, m[1])
+		if err != nil {
+			continue
+		}
+		if t.After(index[m[2]]) {
+			index[m[2]] = t
+		}
+	}
+}
+
+// updateAccessIndex records the blobs served by the registry container since the last update. Unlike the cache
+// process, which touches the blobs it serves, the container does not change the files it reads.
+func updateAccessIndex(index map[string]time.Time) error {
+	st, err := loadState()
+	if err != nil {
+		return err
+	}
+	if st.OCIBinary == "" {
+		return nil
+	}
+	if exists, err := oci.ContainerExists(st.OCIBinary, ContainerName); err != nil || !exists {
+		return err
+	}
+	since := time.Now().UTC().Format(time.RFC3339)
+	args := []string{"logs", "--timestamps"}
+	if st.LogsSince != "" {
+		args = append(args, "--since", st.LogsSince)
+	}
+	logs, err := oci.PrefixCmd(exec.Command(st.OCIBinary, append(args, ContainerName)...)).CombinedOutput()
+	if err != nil {
+		return errors.Wrapf(err, "reading logs of %s", ContainerName)
+	}
+	recordServedBlobs(index, string(logs))
+	st.LogsSince = since
+	return st.save()
+}
+
+// cachedBlobs returns the blobs stored under dir, last used when modified or when recorded in the access index,
+// and the total size of all files in it
+func cachedBlobs(dir string, index map[string]time.Time) ([]blob, int64, error) {
+	blobs := []blob{}
+	var total int64
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		total += info.Size()
+		// both the registry container and the process keep content addressed data below a "blobs" directory
+		if strings.Contains(filepath.ToSlash(p), "/blobs/") {
+			lastUsed := info.ModTime()
+			if t := index[blobHex(p)]; t.After(lastUsed) {
+				lastUsed = t
+			}
+			blobs = append(blobs, blob{path: p, size: info.Size(), lastUsed: lastUsed})
+		}
+		return nil
+	})
+	return blobs, total, err
+}
+
+// Prune removes the least recently used blobs until the registry cache fits in maxSize bytes,
+// and returns the number of removed blobs and the bytes freed
+func Prune(maxSize int64) (int, int64, error) {
+	index, err := loadAccessIndex()
+	if err != nil {
+		klog.Warningf("resetting the registry cache access index: %v", err)
+		index = map[string]time.Time{}
+	}
+	if err := updateAccessIndex(index); err != nil {
+		klog.Warningf("failed to update the registry cache access index: %v", err)
+	}
+	blobs, total, err := cachedBlobs(Dir(), index)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() {
+		// forget the blobs which are gone
+		kept := map[string]time.Time{}
+		for _, b := range blobs {
+			if t, ok := index[blobHex(b.path)]; ok {
+				if _, err := os.Stat(b.path); err == nil {
+					kept[blobHex(b.path)] = t
+				}
+			}
+		}
+		if err := saveAccessIndex(kept); err != nil {
+			klog.Warningf("failed to save the registry cache access index: %v", err)
+		}
+	}()
+	if total <= maxSize {
+		return 0, 0, nil
+	}
+	sort.Slice(blobs, func(i, j int) bool { return blobs[i].lastUsed.Before(blobs[j].lastUsed) })
+
+	victims := []blob{}
+	var size int64
+	for _, b := range blobs {
+		if total-size <= maxSize {
+			break
+		}
+		victims = append(victims, b)
+		size += b.size
+	}
+
+	// the registry container keeps the descriptors of the blobs it serves in memory
+	ociBin := ""
+	if slices.ContainsFunc(victims, func(b blob) bool { return inContainerDir(b.path) }) {
+		if ociBin, err = stopContainer(); err != nil {
+			return 0, 0, err
+		}
+	}
+	removed := 0
+	var freed int64
+	gone := map[string]bool{}
+	for _, b := range victims {
+		p := b.path
+		inContainer := inContainerDir(p)
+		if inContainer {
+			// the registry container keeps each blob in a directory of its own
+			p = filepath.Dir(p)
+		}
+		if err := os.RemoveAll(p); err != nil {
+			klog.Warningf("failed to remove %s: %v", p, err)
+			continue
+		}
+		if inContainer {
+			gone[blobHex(b.path)] = true
+		}
+		removed++
+		freed += b.size
+	}
+	if err := removeLinks(containerDataDir(), gone); err != nil {
+		klog.Warningf("failed to remove the links to the pruned blobs: %v", err)
+	}
+	if ociBin != "" {
+		klog.Infof("Restarting registry cache container %s", ContainerName)
+		if out, err := oci.PrefixCmd(exec.Command(ociBin, "start", ContainerName)).CombinedOutput(); err != nil {
+			return removed, freed, errors.Wrapf(err, "starting registry cache container: %s", out)
+		}
+	}
+	klog.Infof("Pruned %d blobs (%d bytes) from the registry cache", removed, freed)
+	return removed, freed, nil
+}
+
+// stopContainer stops the registry cache container if it runs, so that no blob is removed from under it, and
+// returns the binary to start it again with
+func stopContainer() (string, error) {
+	st, err := loadState()
+	if err != nil || st.OCIBinary == "" {
+		return "", err
+	}
+	if running, err := oci.ContainerRunning(st.OCIBinary, ContainerName); err != nil || !running {
+		return "", nil
+	}
+	klog.Infof("Stopping registry cache container %s", ContainerName)
+	if out, err := oci.PrefixCmd(exec.Command(st.OCIBinary, "stop", ContainerName)).CombinedOutput(); err != nil {
+		return "", errors.Wrapf(err, "stopping registry cache container: %s", out)
+	}
+	return st.OCIBinary, nil
+}
+
+// removeLinks removes the links of the repositories of the registry container to removed blobs, so that the registry
+// pulls them through again instead of serving blobs it no longer has
+func removeLinks(dir string, removed map[string]bool) error {
+	if len(removed) == 0 {
+		return nil
+	}
+	links := []string{}
+	err := filepath.Walk(filepath.Join(dir, "docker", "registry", "v2", "repositories"), func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || info.Name() != "link" {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if removed[strings.TrimPrefix(strings.TrimSpace(string(data)), "sha256:")] {
+			links = append(links, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, l := range links {
+		d := filepath.Dir(l)
+		// the current link of a tag is to its manifest, which the tag goes away with
+		if filepath.Base(d) == "current" {
+			d = filepath.Dir(d)
+		}
+		if err := os.RemoveAll(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
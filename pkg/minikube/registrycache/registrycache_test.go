@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrycache
+
+import (
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+func TestProxy(t *testing.T) {
+	upstream := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	upstreamHost := strings.TrimPrefix(upstream.URL, "http://")
+
+	img, err := random.Image(1024, 2)
+	if err != nil {
+		t.Fatalf("random image: %v", err)
+	}
+	ref, err := name.ParseReference(upstreamHost+"/library/busybox:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatalf("pushing to upstream: %v", err)
+	}
+	want, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	cache := httptest.NewServer(newProxy(dir, upstreamHost, name.Insecure))
+	defer cache.Close()
+	cached, err := name.ParseReference(strings.TrimPrefix(cache.URL, "http://")+"/library/busybox:latest", name.Insecure)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pull := func() {
+		t.Helper()
+		got, err := remote.Image(cached)
+		if err != nil {
+			t.Fatalf("pulling through cache: %v", err)
+		}
+		digest, err := got.Digest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if digest != want {
+			t.Errorf("digest = %s, want %s", digest, want)
+		}
+		if _, err := got.ConfigFile(); err != nil {
+			t.Fatalf("fetching config: %v", err)
+		}
+		layers, err := got.Layers()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, l := range layers {
+			rc, err := l.Compressed()
+			if err != nil {
+				t.Fatalf("fetching layer: %v", err)
+			}
+			rc.Close()
+		}
+	}
+
+	pull()
+	blobs, _, err := cachedBlobs(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// two layers and the config
+	if len(blobs) != 3 {
+		t.Errorf("cached %d blobs, want 3", len(blobs))
+	}
+
+	// the tag and its content are served from the cache once upstream is gone
+	upstream.Close()
+	pull()
+}
+
+func TestPrune(t *testing.T) {
+	t.Setenv("MINIKUBE_HOME", t.TempDir())
+	blobDir := filepath.Join(processDataDir(), "blobs", "sha256")
+	if err := os.MkdirAll(blobDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for i, n := range []string{"old", "mid", "new"} {
+		p := filepath.Join(blobDir, n)
+		if err := os.WriteFile(p, make([]byte, 100), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	removed, freed, err := Prune(300)
+	if err != nil || removed != 0 || freed != 0 {
+		t.Errorf("Prune(300) = %d, %d, %v; want nothing pruned", removed, freed, err)
+	}
+
+	removed, freed, err = Prune(150)
+	if err != nil {
+		t.Fatalf("Prune(150): %v", err)
+	}
+	if removed != 2 || freed != 200 {
+		t.Errorf("Prune(150) = %d, %d; want 2, 200", removed, freed)
+	}
+	if _, err := os.Stat(filepath.Join(blobDir, "new")); err != nil {
+		t.Errorf("most recently used blob was pruned: %v", err)
+	}
+}
+
+func TestPruneAccessIndex(t *testing.T) {
+	t.Setenv("MINIKUBE_HOME", t.TempDir())
+	now := time.Now().Truncate(time.Second)
+	// blobs written by the registry container, oldest first, much larger than the state of the cache
+	digests := []string{strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)}
+	for i, d := range digests {
+		dir := filepath.Join(containerDataDir(), "docker", "registry", "v2", "blobs", "sha256", d[:2], d)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		p := filepath.Join(dir, "data")
+		if err := os.WriteFile(p, make([]byte, 1000), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mtime := now.Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(p, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the oldest blob was pulled again since
+	index := map[string]time.Time{}
+	recordServedBlobs(index, now.Format(time.RFC3339Nano)+` time="..." level=info msg="response completed" http.request.method=GET http.request.uri="/v2/library/busybox/blobs/sha256:`+digests[0]+`"`+"\n"+
+		now.Format(time.RFC3339Nano)+` 172.18.0.2 - - "GET /v2/library/busybox/manifests/latest HTTP/1.1" 200 527`+"\n")
+	if len(index) != 1 || !index[digests[0]].Equal(now) {
+		t.Fatalf("recorded %v, want %s served at %s", index, digests[0], now)
+	}
+	if err := saveAccessIndex(index); err != nil {
+		t.Fatal(err)
+	}
+
+	removed, _, err := Prune(1500)
+	if err != nil {
+		t.Fatalf("Prune(1500): %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Prune(1500) removed %d blobs, want 2", removed)
+	}
+	blobs, _, err := cachedBlobs(Dir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs) != 1 || blobHex(blobs[0].path) != digests[0] {
+		t.Errorf("kept %v, want the most recently served blob %s", blobs, digests[0])
+	}
+	for _, d := range digests[1:] {
+		if _, err := os.Stat(filepath.Join(containerDataDir(), "docker", "registry", "v2", "blobs", "sha256", d[:2], d)); !os.IsNotExist(err) {
+			t.Errorf("the directory of the pruned blob %s was kept: %v", d, err)
+		}
+	}
+}
+
+func TestRemoveLinks(t *testing.T) {
+	dir := t.TempDir()
+	layer, manifest, kept := strings.Repeat("a", 64), strings.Repeat("b", 64), strings.Repeat("c", 64)
+	repo := filepath.Join(dir, "docker", "registry", "v2", "repositories", "library", "busybox")
+	links := map[string]string{
+		filepath.Join("_layers", "sha256", layer, "link"):                                  layer,
+		filepath.Join("_layers", "sha256", kept, "link"):                                   kept,
+		filepath.Join("_manifests", "revisions", "sha256", manifest, "link"):               manifest,
+		filepath.Join("_manifests", "tags", "latest", "current", "link"):                   manifest,
+		filepath.Join("_manifests", "tags", "latest", "index", "sha256", manifest, "link"): manifest,
+		filepath.Join("_manifests", "tags", "stable", "current", "link"):                   kept,
+		filepath.Join("_manifests", "tags", "stable", "index", "sha256", kept, "link"):     kept,
+	}
+	for p, d := range links {
+		p = filepath.Join(repo, p)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte("sha256:"+d), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := removeLinks(dir, map[string]bool{layer: true, manifest: true}); err != nil {
+		t.Fatalf("removeLinks: %v", err)
+	}
+	for p, want := range map[string]bool{
+		filepath.Join("_layers", "sha256", layer):                    false,
+		filepath.Join("_layers", "sha256", kept):                     true,
+		filepath.Join("_manifests", "revisions", "sha256", manifest): false,
+		filepath.Join("_manifests", "tags", "latest"):                false,
+		filepath.Join("_manifests", "tags", "stable"):                true,
+	} {
+		_, err := os.Stat(filepath.Join(repo, p))
+		if got := err == nil; got != want {
+			t.Errorf("%s exists = %v, want %v", p, got, want)
+		}
+	}
+}
@@ -26,4 +26,8 @@ type CommandOptions struct {
 	// flag and we should If only download and cache files for later use and
 	// don't install or start anything.
 	DownloadOnly bool
+
+	// Offline is true if the minikube command run with the --offline flag and
+	// must only use cached artifacts, without any network access.
+	Offline bool
 }
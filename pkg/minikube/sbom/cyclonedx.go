@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// cdxBOM is a CycloneDX 1.5 bill of materials, in its JSON serialization
+type cdxBOM struct {
+	BOMFormat    string         `json:"bomFormat"`
+	SpecVersion  string         `json:"specVersion"`
+	SerialNumber string         `json:"serialNumber"`
+	Version      int            `json:"version"`
+	Metadata     cdxMetadata    `json:"metadata"`
+	Components   []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Type       string        `json:"type"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	Hashes     []cdxHash     `json:"hashes,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CycloneDX encodes the bill of materials as a CycloneDX 1.5 JSON document
+func CycloneDX(bom *BOM) ([]byte, error) {
+	doc := cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.New().String(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: bom.Created.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{
+				{Type: TypeApplication, Name: "minikube", Version: bom.Tool},
+			}},
+			Component: cdxComponent{BOMRef: "profile:" + bom.Profile, Type: "platform", Name: bom.Profile},
+		},
+		Components: []cdxComponent{},
+	}
+
+	used := map[string]int{}
+	for _, c := range bom.Components {
+		ref := c.PURL
+		if ref == "" {
+			ref = fmt.Sprintf("%s:%s@%s", c.Type, c.Name, c.Version)
+		}
+		if used[ref]++; used[ref] > 1 {
+			ref = fmt.Sprintf("%s#%d", ref, used[ref])
+		}
+		comp := cdxComponent{
+			BOMRef:     ref,
+			Type:       c.Type,
+			Name:       c.Name,
+			Version:    c.Version,
+			PURL:       c.PURL,
+			Properties: []cdxProperty{{Name: "minikube:role", Value: c.Role}},
+		}
+		if c.Digest != "" {
+			comp.Hashes = []cdxHash{{Alg: "SHA-256", Content: c.Digest}}
+		}
+		if c.ImageID != "" {
+			comp.Properties = append(comp.Properties, cdxProperty{Name: "minikube:image-id", Value: "sha256:" + c.ImageID})
+		}
+		if len(c.Nodes) > 0 {
+			comp.Properties = append(comp.Properties, cdxProperty{Name: "minikube:nodes", Value: strings.Join(c.Nodes, ",")})
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
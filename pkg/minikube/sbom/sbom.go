@@ -0,0 +1,460 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sbom inventories the software running in a profile, and encodes it as an SPDX or CycloneDX
+// software bill of materials.
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"path"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	dockerref "github.com/distribution/reference"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/cni"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/run"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/version"
+)
+
+// Component types, named as in CycloneDX
+const (
+	TypeApplication     = "application"
+	TypeContainer       = "container"
+	TypeOperatingSystem = "operating-system"
+)
+
+// Component roles in the cluster
+const (
+	RoleBase         = "base"
+	RoleRuntime      = "container-runtime"
+	RoleKubernetes   = "kubernetes"
+	RoleControlPlane = "control-plane"
+	RoleCNI          = "cni"
+	RoleAddon        = "addon"
+	RoleOther        = "other"
+)
+
+// Component is a piece of software running in a profile
+type Component struct {
+	Type    string
+	Name    string
+	Version string
+	// Digest is the sha256 of the binary, or of the manifest of an image
+	Digest string
+	// ImageID is the ID of a container image in the runtime
+	ImageID string
+	PURL    string
+	// Role is what the component is used for in the cluster, addons are named after a colon
+	Role string
+	// Nodes are the nodes the component was found on
+	Nodes []string
+	// DownloadLocation is where the component was obtained from, if known
+	DownloadLocation string
+}
+
+// BOM is the software bill of materials of a profile
+type BOM struct {
+	Profile    string
+	Created    time.Time
+	Tool       string
+	Components []Component
+}
+
+// nodeInfo is what was found on a running node
+type nodeInfo struct {
+	name           string
+	osID           string
+	osName         string
+	osVersion      string
+	runtimeName    string
+	runtimeVersion string
+	// binaries maps the Kubernetes release binaries to their sha256
+	binaries map[string]string
+	images   []cruntime.ListImage
+}
+
+// Collect inventories the base image, operating system, container runtime, Kubernetes binaries,
+// CNI and container images of the running nodes of a profile
+func Collect(cc *config.ClusterConfig, options *run.CommandOptions) (*BOM, error) {
+	api, err := machine.NewAPIClient(options)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating api client")
+	}
+	defer api.Close()
+
+	nodes := []nodeInfo{}
+	for _, n := range cc.Nodes {
+		m := config.MachineName(*cc, n)
+
+		status, err := machine.Status(api, m)
+		if err != nil {
+			klog.Warningf("error getting status for %s: %v", m, err)
+			continue
+		}
+		if status != state.Running.String() {
+			klog.Infof("skipping %s, which is %s", m, status)
+			continue
+		}
+		h, err := api.Load(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading machine %s", m)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			return nil, err
+		}
+		info, err := collectNode(runner, cc, n)
+		if err != nil {
+			return nil, errors.Wrapf(err, "collecting %s", m)
+		}
+		info.name = m
+		nodes = append(nodes, *info)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no running nodes found in %s", cc.Name)
+	}
+	return assemble(cc, nodes, time.Now()), nil
+}
+
+// collectNode gathers the operating system, runtime, binaries and images of a node
+func collectNode(runner command.Runner, cc *config.ClusterConfig, n config.Node) (*nodeInfo, error) {
+	info := &nodeInfo{binaries: map[string]string{}}
+
+	rr, err := runner.RunCmd(exec.Command("cat", "/etc/os-release"))
+	if err != nil {
+		klog.Warningf("unable to read os-release: %v", err)
+	} else {
+		osr := parseOSRelease(rr.Stdout.String())
+		info.osID, info.osName, info.osVersion = osr["ID"], osr["NAME"], osr["VERSION_ID"]
+	}
+
+	cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: runner, Socket: cc.KubernetesConfig.CRISocket})
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating container runtime")
+	}
+	info.runtimeName = cr.Name()
+	if info.runtimeVersion, err = cr.Version(); err != nil {
+		klog.Warningf("unable to get %s version: %v", cr.Name(), err)
+	}
+
+	if n.KubernetesVersion != constants.NoKubernetesVersion {
+		for _, b := range constants.KubernetesReleaseBinaries {
+			p := path.Join(vmpath.GuestPersistentDir, "binaries", n.KubernetesVersion, b)
+			rr, err := runner.RunCmd(exec.Command("sudo", "sha256sum", p))
+			if err != nil {
+				klog.Warningf("unable to checksum %s: %v", p, err)
+				continue
+			}
+			if fields := strings.Fields(rr.Stdout.String()); len(fields) > 0 {
+				info.binaries[b] = fields[0]
+			}
+		}
+	}
+
+	if info.images, err = cr.ListImages(cruntime.ListImagesOptions{}); err != nil {
+		return nil, errors.Wrap(err, "listing images")
+	}
+	return info, nil
+}
+
+// parseOSRelease parses the KEY=value lines of os-release(5)
+func parseOSRelease(s string) map[string]string {
+	m := map[string]string{}
+	for _, line := range strings.Split(s, "\n") {
+		k, v, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok || strings.HasPrefix(k, "#") {
+			continue
+		}
+		m[k] = strings.Trim(v, `"'`)
+	}
+	return m
+}
+
+// isoVersionRE matches the version in the name of a minikube ISO
+var isoVersionRE = regexp.MustCompile(`v\d+\.\d+\.\d+(-[0-9A-Za-z.]+)?`)
+
+// baseComponent returns the ISO or kicbase image the nodes of cc were created from
+func baseComponent(cc *config.ClusterConfig) *Component {
+	switch {
+	case driver.IsKIC(cc.Driver) && cc.KicBaseImage != "":
+		c := &Component{Type: TypeContainer, Role: RoleBase}
+		c.Name, c.Version, c.Digest = splitImage(cc.KicBaseImage)
+		c.PURL = imagePURL(c.Name, c.Version, c.Digest)
+		return c
+	case driver.IsVM(cc.Driver) && cc.MinikubeISO != "":
+		v := isoVersionRE.FindString(path.Base(cc.MinikubeISO))
+		if v == "" {
+			v = version.GetISOVersion()
+		}
+		return &Component{
+			Type:             TypeOperatingSystem,
+			Name:             "minikube-iso",
+			Version:          v,
+			Role:             RoleBase,
+			PURL:             fmt.Sprintf("pkg:generic/minikube-iso@%s?download_url=%s", v, url.QueryEscape(cc.MinikubeISO)),
+			DownloadLocation: cc.MinikubeISO,
+		}
+	}
+	return nil
+}
+
+// splitImage returns the name, tag and digest of an image reference
+func splitImage(image string) (name string, tag string, digest string) {
+	named, err := dockerref.ParseNormalizedNamed(image)
+	if err != nil {
+		return image, "", ""
+	}
+	name = named.Name()
+	if t, ok := named.(dockerref.Tagged); ok {
+		tag = t.Tag()
+	}
+	if d, ok := named.(dockerref.Digested); ok {
+		digest = d.Digest().Encoded()
+	}
+	return name, tag, digest
+}
+
+// normalizeImage returns the fully qualified name:tag of an image reference, without digest
+func normalizeImage(image string) string {
+	name, tag, _ := splitImage(image)
+	if tag == "" {
+		tag = "latest"
+	}
+	return name + ":" + tag
+}
+
+// imagePURL returns the package URL of an image: an oci purl when its digest is known, a docker purl otherwise
+func imagePURL(name string, tag string, digest string) string {
+	last := path.Base(name)
+	if digest != "" {
+		q := url.Values{}
+		q.Set("repository_url", name)
+		if tag != "" {
+			q.Set("tag", tag)
+		}
+		return fmt.Sprintf("pkg:oci/%s@sha256%%3A%s?%s", last, digest, q.Encode())
+	}
+	domain, repo := name, ""
+	if i := strings.Index(name, "/"); i != -1 {
+		domain, repo = name[:i], name[i+1:]
+	}
+	p := "pkg:docker/" + repo
+	if tag != "" {
+		p += "@" + tag
+	}
+	if domain != "docker.io" {
+		p += "?repository_url=" + url.QueryEscape(domain)
+	}
+	return p
+}
+
+// imageRoles maps the normalized images minikube deploys to their role
+func imageRoles(cc *config.ClusterConfig) map[string]string {
+	roles := map[string]string{}
+	repo := cc.KubernetesConfig.ImageRepository
+	if imgs, err := images.Kubeadm(repo, cc.KubernetesConfig.KubernetesVersion); err == nil {
+		for _, img := range imgs {
+			roles[normalizeImage(img)] = RoleControlPlane
+		}
+	}
+	for _, img := range []string{images.KindNet(repo), images.CalicoDaemonSet(repo), images.CalicoDeployment(repo), images.CalicoBin(repo)} {
+		roles[normalizeImage(img)] = RoleCNI
+	}
+
+	names := []string{}
+	for name, enabled := range cc.Addons {
+		if enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		addon, ok := assets.Addons[name]
+		if !ok {
+			continue
+		}
+		for _, img := range assets.AddonImages(addon, cc) {
+			if _, ok := roles[normalizeImage(img)]; !ok {
+				roles[normalizeImage(img)] = RoleAddon + ":" + name
+			}
+		}
+	}
+	return roles
+}
+
+// assemble builds the bill of materials from what was found on the nodes
+func assemble(cc *config.ClusterConfig, nodes []nodeInfo, now time.Time) *BOM {
+	bom := &BOM{Profile: cc.Name, Created: now.UTC(), Tool: version.GetVersion()}
+	byKey := map[string]*Component{}
+	order := []string{}
+	add := func(c Component, node string) {
+		key := strings.Join([]string{c.Type, c.Name, c.Version, c.Digest, c.ImageID}, "|")
+		existing, ok := byKey[key]
+		if !ok {
+			existing = &c
+			byKey[key] = existing
+			order = append(order, key)
+		}
+		if node != "" {
+			for _, n := range existing.Nodes {
+				if n == node {
+					return
+				}
+			}
+			existing.Nodes = append(existing.Nodes, node)
+		}
+	}
+
+	if base := baseComponent(cc); base != nil {
+		add(*base, "")
+	}
+
+	roles := imageRoles(cc)
+	arch := runtime.GOARCH
+	for _, n := range nodes {
+		if n.osName != "" {
+			add(Component{
+				Type:    TypeOperatingSystem,
+				Name:    n.osName,
+				Version: n.osVersion,
+				Role:    RoleBase,
+				PURL:    fmt.Sprintf("pkg:generic/%s@%s", url.PathEscape(strings.ToLower(n.osID)), url.PathEscape(n.osVersion)),
+			}, n.name)
+		}
+		if n.runtimeName != "" {
+			add(Component{
+				Type:    TypeApplication,
+				Name:    strings.ToLower(n.runtimeName),
+				Version: n.runtimeVersion,
+				Role:    RoleRuntime,
+				PURL:    fmt.Sprintf("pkg:generic/%s@%s", strings.ToLower(n.runtimeName), url.PathEscape(n.runtimeVersion)),
+			}, n.name)
+		}
+
+		bins := []string{}
+		for b := range n.binaries {
+			bins = append(bins, b)
+		}
+		sort.Strings(bins)
+		kv := cc.KubernetesConfig.KubernetesVersion
+		for _, b := range bins {
+			dl := fmt.Sprintf("https://dl.k8s.io/release/%s/bin/linux/%s/%s", kv, arch, b)
+			add(Component{
+				Type:             TypeApplication,
+				Name:             b,
+				Version:          kv,
+				Digest:           n.binaries[b],
+				Role:             RoleKubernetes,
+				PURL:             fmt.Sprintf("pkg:generic/%s@%s?download_url=%s", b, kv, url.QueryEscape(dl)),
+				DownloadLocation: dl,
+			}, n.name)
+		}
+
+		for _, img := range n.images {
+			id := strings.TrimPrefix(img.ID, "sha256:")
+			tags := []string{}
+			for _, t := range img.RepoTags {
+				if t != "" && !strings.Contains(t, "<none>") {
+					tags = append(tags, t)
+				}
+			}
+			if len(tags) == 0 {
+				add(Component{Type: TypeContainer, Name: "sha256:" + id, ImageID: id, Role: RoleOther}, n.name)
+				continue
+			}
+			for _, t := range tags {
+				name, tag, _ := splitImage(t)
+				digest := repoDigest(name, img.RepoDigests)
+				role, ok := roles[normalizeImage(t)]
+				if !ok {
+					role = RoleOther
+				}
+				add(Component{
+					Type:    TypeContainer,
+					Name:    name,
+					Version: tag,
+					Digest:  digest,
+					ImageID: id,
+					PURL:    imagePURL(name, tag, digest),
+					Role:    role,
+				}, n.name)
+			}
+		}
+	}
+
+	if c := cniComponent(cc); c != nil {
+		add(*c, "")
+	}
+
+	for _, key := range order {
+		bom.Components = append(bom.Components, *byKey[key])
+	}
+	return bom
+}
+
+// repoDigest returns the manifest digest of the image from the repository name
+func repoDigest(name string, digests []string) string {
+	for _, d := range digests {
+		n, dg, ok := strings.Cut(d, "@")
+		if !ok {
+			continue
+		}
+		if nn, err := dockerref.ParseNormalizedNamed(n); err == nil && nn.Name() == name {
+			return strings.TrimPrefix(dg, "sha256:")
+		}
+	}
+	return ""
+}
+
+// cniComponent returns the CNI configured for the cluster
+func cniComponent(cc *config.ClusterConfig) *Component {
+	if cc.KubernetesConfig.KubernetesVersion == constants.NoKubernetesVersion {
+		return nil
+	}
+	m, err := cni.New(cc)
+	if err != nil {
+		klog.Warningf("unable to get the CNI: %v", err)
+		return nil
+	}
+	name := m.String()
+	if _, ok := m.(cni.Disabled); ok {
+		return nil
+	}
+	return &Component{
+		Type: TypeApplication,
+		Name: name,
+		Role: RoleCNI,
+		PURL: "pkg:generic/" + url.PathEscape(strings.ToLower(strings.ReplaceAll(name, " ", "-"))),
+	}
+}
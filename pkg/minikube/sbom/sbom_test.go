@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+)
+
+const (
+	kicDigest    = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	pauseDigest  = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	kubeletHash  = "cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"
+	metricsImage = "registry.k8s.io/metrics-server/metrics-server:v0.8.0"
+)
+
+func testBOM() *BOM {
+	cc := &config.ClusterConfig{
+		Name:         "p1",
+		Driver:       "docker",
+		KicBaseImage: "gcr.io/k8s-minikube/kicbase:v0.0.47@sha256:" + kicDigest,
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.33.0",
+			ContainerRuntime:  "containerd",
+			CNI:               "bridge",
+		},
+		Addons: map[string]bool{"metrics-server": true, "dashboard": false},
+	}
+	node := func(name string) nodeInfo {
+		return nodeInfo{
+			name:           name,
+			osID:           "ubuntu",
+			osName:         "Ubuntu",
+			osVersion:      "22.04",
+			runtimeName:    "containerd",
+			runtimeVersion: "1.7.27",
+			binaries:       map[string]string{"kubelet": kubeletHash},
+			images: []cruntime.ListImage{
+				{ID: "sha256:1111", RepoTags: []string{"registry.k8s.io/pause:3.10"}, RepoDigests: []string{"registry.k8s.io/pause@sha256:" + pauseDigest}},
+				{ID: "sha256:2222", RepoTags: []string{metricsImage}},
+				{ID: "sha256:3333", RepoTags: []string{"docker.io/library/app:dev"}},
+			},
+		}
+	}
+	return assemble(cc, []nodeInfo{node("p1"), node("p1-m02")}, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+}
+
+func findComponent(t *testing.T, bom *BOM, name string) Component {
+	t.Helper()
+	for _, c := range bom.Components {
+		if c.Name == name {
+			return c
+		}
+	}
+	t.Fatalf("component %s not found in %+v", name, bom.Components)
+	return Component{}
+}
+
+func TestAssemble(t *testing.T) {
+	bom := testBOM()
+
+	base := findComponent(t, bom, "gcr.io/k8s-minikube/kicbase")
+	if base.Role != RoleBase || base.Version != "v0.0.47" || base.Digest != kicDigest {
+		t.Errorf("unexpected base component %+v", base)
+	}
+
+	kubelet := findComponent(t, bom, "kubelet")
+	if kubelet.Digest != kubeletHash || kubelet.Version != "v1.33.0" || strings.Join(kubelet.Nodes, ",") != "p1,p1-m02" {
+		t.Errorf("unexpected kubelet component %+v", kubelet)
+	}
+
+	pause := findComponent(t, bom, "registry.k8s.io/pause")
+	if pause.Role != RoleControlPlane || pause.Digest != pauseDigest || !strings.HasPrefix(pause.PURL, "pkg:oci/pause@sha256%3A"+pauseDigest) {
+		t.Errorf("unexpected pause component %+v", pause)
+	}
+
+	if ms := findComponent(t, bom, "registry.k8s.io/metrics-server/metrics-server"); ms.Role != "addon:metrics-server" {
+		t.Errorf("unexpected metrics-server role %q", ms.Role)
+	}
+	if app := findComponent(t, bom, "docker.io/library/app"); app.Role != RoleOther || app.PURL != "pkg:docker/library/app@dev" {
+		t.Errorf("unexpected app component %+v", app)
+	}
+	if c := findComponent(t, bom, "bridge CNI"); c.Role != RoleCNI {
+		t.Errorf("unexpected CNI component %+v", c)
+	}
+
+	// components found on both nodes are listed once
+	seen := map[string]bool{}
+	for _, c := range bom.Components {
+		key := c.Name + "@" + c.Version
+		if seen[key] {
+			t.Errorf("duplicate component %s", key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestSPDX(t *testing.T) {
+	data, err := SPDX(testBOM())
+	if err != nil {
+		t.Fatalf("SPDX: %v", err)
+	}
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid SPDX JSON: %v", err)
+	}
+	if doc.SPDXVersion != "SPDX-2.3" || doc.CreationInfo.Created != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected document header %+v", doc)
+	}
+	if len(doc.Packages) == 0 || len(doc.Packages) != len(doc.Relationships) {
+		t.Fatalf("expected one DESCRIBES relationship per package, got %d packages and %d relationships", len(doc.Packages), len(doc.Relationships))
+	}
+	ids := map[string]bool{}
+	for _, p := range doc.Packages {
+		if spdxIDRE.MatchString(strings.TrimPrefix(p.SPDXID, "SPDXRef-")) {
+			t.Errorf("invalid SPDX ID %q", p.SPDXID)
+		}
+		if ids[p.SPDXID] {
+			t.Errorf("duplicate SPDX ID %q", p.SPDXID)
+		}
+		ids[p.SPDXID] = true
+	}
+}
+
+func TestCycloneDX(t *testing.T) {
+	data, err := CycloneDX(testBOM())
+	if err != nil {
+		t.Fatalf("CycloneDX: %v", err)
+	}
+	var doc cdxBOM
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid CycloneDX JSON: %v", err)
+	}
+	if doc.BOMFormat != "CycloneDX" || doc.SpecVersion != "1.5" || !strings.HasPrefix(doc.SerialNumber, "urn:uuid:") {
+		t.Errorf("unexpected document header %+v", doc)
+	}
+	refs := map[string]bool{}
+	hashed := 0
+	for _, c := range doc.Components {
+		if refs[c.BOMRef] {
+			t.Errorf("duplicate bom-ref %q", c.BOMRef)
+		}
+		refs[c.BOMRef] = true
+		if len(c.Hashes) > 0 {
+			hashed++
+		}
+	}
+	// kicbase, kubelet and pause
+	if hashed != 3 {
+		t.Errorf("expected 3 components with hashes, got %d", hashed)
+	}
+}
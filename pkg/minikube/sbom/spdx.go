@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// spdxDocument is an SPDX 2.3 document, in its JSON serialization
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	Checksums             []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	Comment               string            `json:"comment,omitempty"`
+}
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// spdxIDRE matches the characters which are not allowed in SPDX identifiers
+var spdxIDRE = regexp.MustCompile(`[^A-Za-z0-9.-]+`)
+
+// spdxPurpose maps component types to SPDX package purposes
+var spdxPurpose = map[string]string{
+	TypeApplication:     "APPLICATION",
+	TypeContainer:       "CONTAINER",
+	TypeOperatingSystem: "OPERATING-SYSTEM",
+}
+
+// SPDX encodes the bill of materials as an SPDX 2.3 JSON document
+func SPDX(bom *BOM) ([]byte, error) {
+	doc := spdxDocument{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              "minikube-" + bom.Profile,
+		DocumentNamespace: fmt.Sprintf("https://minikube.sigs.k8s.io/spdx/%s-%s", bom.Profile, uuid.New().String()),
+		CreationInfo: spdxCreationInfo{
+			Created:  bom.Created.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: minikube-" + bom.Tool},
+		},
+		Packages:      []spdxPackage{},
+		Relationships: []spdxRelationship{},
+	}
+
+	used := map[string]int{}
+	for _, c := range bom.Components {
+		id := "SPDXRef-Package-" + strings.Trim(spdxIDRE.ReplaceAllString(c.Name+"-"+c.Version, "-"), "-")
+		if used[id]++; used[id] > 1 {
+			id = fmt.Sprintf("%s-%d", id, used[id])
+		}
+		pkg := spdxPackage{
+			Name:                  c.Name,
+			SPDXID:                id,
+			VersionInfo:           c.Version,
+			DownloadLocation:      "NOASSERTION",
+			PrimaryPackagePurpose: spdxPurpose[c.Type],
+			Comment:               componentComment(c),
+		}
+		if c.DownloadLocation != "" {
+			pkg.DownloadLocation = c.DownloadLocation
+		}
+		if c.Digest != "" {
+			pkg.Checksums = []spdxChecksum{{Algorithm: "SHA256", ChecksumValue: c.Digest}}
+		}
+		if c.PURL != "" {
+			pkg.ExternalRefs = []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: c.PURL}}
+		}
+		doc.Packages = append(doc.Packages, pkg)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: id})
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// componentComment describes the role of a component in the cluster, where SPDX has no field for it
+func componentComment(c Component) string {
+	parts := []string{"role: " + c.Role}
+	if c.ImageID != "" {
+		parts = append(parts, "image id: sha256:"+c.ImageID)
+	}
+	if len(c.Nodes) > 0 {
+		parts = append(parts, "nodes: "+strings.Join(c.Nodes, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package trust installs the minikube CA into the trust stores of a Linux host: the system trust store, used by
+// most programs, and the NSS databases of Firefox and Chromium, which do not use it.
+package trust
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// Nickname is the name of the minikube CA in the trust store directory and in the NSS databases
+const Nickname = "minikubeCA"
+
+// Name returns the name of the CA of a profile in the trust stores: the minikube CA shared by the profiles for an
+// empty profile, else the CA the profile was started with, with --ca-cert
+func Name(profile string) string {
+	if profile == "" {
+		return Nickname
+	}
+	return Nickname + "-" + profile
+}
+
+// certFile returns the file of a CA in the system trust store directory
+func certFile(name string) string {
+	return name + ".crt"
+}
+
+// SystemStore is a directory of CA certificates trusted by the host, with the command updating the trust store
+type SystemStore struct {
+	Dir    string
+	Update []string
+}
+
+// systemStores are the trust store directories of the common Linux distributions
+var systemStores = []SystemStore{
+	{Dir: "/usr/local/share/ca-certificates", Update: []string{"update-ca-certificates"}},           // Debian, Ubuntu, Alpine
+	{Dir: "/etc/pki/ca-trust/source/anchors", Update: []string{"update-ca-trust", "extract"}},       // Fedora, RHEL
+	{Dir: "/etc/pki/trust/anchors", Update: []string{"update-ca-certificates"}},                     // openSUSE
+	{Dir: "/etc/ca-certificates/trust-source/anchors", Update: []string{"trust", "extract-compat"}}, // Arch
+}
+
+// nssDatabases are the patterns of the NSS databases in a home directory: the shared one of Chromium, and the ones
+// of the Firefox profiles
+var nssDatabases = []string{
+	".pki/nssdb",
+	"snap/chromium/current/.pki/nssdb",
+	".mozilla/firefox/*",
+	"snap/firefox/common/.mozilla/firefox/*",
+}
+
+// runCommand runs a command, returning its output in the error
+var runCommand = func(args ...string) error {
+	klog.Infof("running %v", args)
+	if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "%s: %s", strings.Join(args, " "), out)
+	}
+	return nil
+}
+
+// sudo prefixes a command with sudo unless minikube runs as root
+func sudo(args ...string) []string {
+	if os.Geteuid() == 0 {
+		return args
+	}
+	return append([]string{"sudo"}, args...)
+}
+
+// FindSystemStore returns the trust store directory of the host, or dir if not empty
+func FindSystemStore(dir string) (SystemStore, error) {
+	return findSystemStore("/", dir)
+}
+
+// findSystemStore returns the trust store directory of the host with a root directory, or dir if not empty. The
+// store of a directory unknown to minikube is not updated.
+func findSystemStore(root, dir string) (SystemStore, error) {
+	if dir != "" {
+		for _, s := range systemStores {
+			if filepath.Clean(dir) == s.Dir {
+				return s, nil
+			}
+		}
+		return SystemStore{Dir: filepath.Clean(dir)}, nil
+	}
+	for _, s := range systemStores {
+		if st, err := os.Stat(filepath.Join(root, s.Dir)); err == nil && st.IsDir() {
+			return s, nil
+		}
+	}
+	return SystemStore{}, fmt.Errorf("no known trust store directory, pass one with --trust-store-dir")
+}
+
+// Install copies a CA certificate into the trust store directory with a name, then updates the trust store
+func (s SystemStore) Install(name, caCert string) error {
+	if err := runCommand(sudo("install", "-m", "0644", caCert, filepath.Join(s.Dir, certFile(name)))...); err != nil {
+		return err
+	}
+	if len(s.Update) == 0 {
+		return nil
+	}
+	return runCommand(sudo(s.Update...)...)
+}
+
+// Remove removes a CA from the trust store directory, then updates the trust store. It returns whether the CA was
+// installed.
+func (s SystemStore) Remove(name string) (bool, error) {
+	if _, err := os.Stat(filepath.Join(s.Dir, certFile(name))); os.IsNotExist(err) {
+		return false, nil
+	}
+	if err := runCommand(sudo("rm", "-f", filepath.Join(s.Dir, certFile(name)))...); err != nil {
+		return true, err
+	}
+	if len(s.Update) == 0 {
+		return true, nil
+	}
+	return true, runCommand(sudo(s.Update...)...)
+}
+
+// NSSDatabases returns the NSS databases of a home directory
+func NSSDatabases(home string) []string {
+	dbs := []string{}
+	for _, p := range nssDatabases {
+		matches, err := filepath.Glob(filepath.Join(home, p))
+		if err != nil {
+			klog.Warningf("invalid pattern %s: %v", p, err)
+			continue
+		}
+		for _, m := range matches {
+			// only the SQL format is supported by the recent versions of NSS
+			if _, err := os.Stat(filepath.Join(m, "cert9.db")); err == nil {
+				dbs = append(dbs, m)
+			}
+		}
+	}
+	return dbs
+}
+
+// HasCertutil returns whether the certutil command managing NSS databases is installed
+func HasCertutil() bool {
+	_, err := exec.LookPath("certutil")
+	return err == nil
+}
+
+// AddToNSS adds a CA certificate to an NSS database with a name, as trusted for TLS servers, replacing the previous
+// CA of that name
+func AddToNSS(db, name, caCert string) error {
+	if _, err := RemoveFromNSS(db, name); err != nil {
+		return err
+	}
+	return runCommand("certutil", "-A", "-d", "sql:"+db, "-n", name, "-t", "C,,", "-i", caCert)
+}
+
+// RemoveFromNSS removes a CA from an NSS database, returning whether it was there
+func RemoveFromNSS(db, name string) (bool, error) {
+	if err := runCommand("certutil", "-L", "-d", "sql:"+db, "-n", name); err != nil {
+		klog.Infof("%s is not in %s: %v", name, db, err)
+		return false, nil
+	}
+	return true, runCommand("certutil", "-D", "-d", "sql:"+db, "-n", name)
+}
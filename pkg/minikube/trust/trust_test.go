@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trust
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindSystemStore(t *testing.T) {
+	root := t.TempDir()
+	if _, err := findSystemStore(root, ""); err == nil {
+		t.Errorf("findSystemStore without trust store = nil, want an error")
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "/etc/pki/ca-trust/source/anchors"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	s, err := findSystemStore(root, "")
+	if err != nil {
+		t.Fatalf("findSystemStore: %v", err)
+	}
+	if s.Dir != "/etc/pki/ca-trust/source/anchors" || !reflect.DeepEqual(s.Update, []string{"update-ca-trust", "extract"}) {
+		t.Errorf("findSystemStore = %+v, want the Fedora store", s)
+	}
+
+	// a known directory is updated, another one is not
+	if s, _ := findSystemStore(root, "/usr/local/share/ca-certificates/"); !reflect.DeepEqual(s.Update, []string{"update-ca-certificates"}) {
+		t.Errorf("findSystemStore of the Debian directory = %+v, want it updated", s)
+	}
+	if s, _ := findSystemStore(root, "/opt/certs"); s.Dir != "/opt/certs" || s.Update != nil {
+		t.Errorf("findSystemStore of another directory = %+v, want it not updated", s)
+	}
+}
+
+func TestSystemStore(t *testing.T) {
+	ran := []string{}
+	orig := runCommand
+	defer func() { runCommand = orig }()
+	runCommand = func(args ...string) error {
+		ran = append(ran, strings.Join(args, " "))
+		return nil
+	}
+
+	dir := t.TempDir()
+	s := SystemStore{Dir: dir, Update: []string{"update-ca-certificates"}}
+	if removed, err := s.Remove(Nickname); err != nil || removed {
+		t.Errorf("Remove of an untrusted CA = %v, %v, want false", removed, err)
+	}
+	if err := s.Install(Nickname, "/home/user/.minikube/ca.crt"); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	if err := s.Install(Name("corp"), "/home/user/.minikube/profiles/corp/ca.crt"); err != nil {
+		t.Fatalf("Install of a profile CA: %v", err)
+	}
+	// the commands are stubbed, so install the CA like them
+	if err := os.WriteFile(filepath.Join(dir, "minikubeCA.crt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := s.Remove(Nickname); err != nil || !removed {
+		t.Errorf("Remove of a trusted CA = %v, %v, want true", removed, err)
+	}
+	want := []string{
+		"install -m 0644 /home/user/.minikube/ca.crt " + filepath.Join(dir, "minikubeCA.crt"),
+		"update-ca-certificates",
+		"install -m 0644 /home/user/.minikube/profiles/corp/ca.crt " + filepath.Join(dir, "minikubeCA-corp.crt"),
+		"update-ca-certificates",
+		"rm -f " + filepath.Join(dir, "minikubeCA.crt"),
+		"update-ca-certificates",
+	}
+	for i := range ran {
+		ran[i] = strings.TrimPrefix(ran[i], "sudo ")
+	}
+	if !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %q, want %q", ran, want)
+	}
+}
+
+func TestNSSDatabases(t *testing.T) {
+	home := t.TempDir()
+	for _, db := range []string{".pki/nssdb", ".mozilla/firefox/abc.default-release", ".mozilla/firefox/old.default"} {
+		if err := os.MkdirAll(filepath.Join(home, db), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the legacy databases without cert9.db are not supported
+	for _, db := range []string{".pki/nssdb", ".mozilla/firefox/abc.default-release"} {
+		if err := os.WriteFile(filepath.Join(home, db, "cert9.db"), nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := NSSDatabases(home)
+	want := []string{filepath.Join(home, ".pki/nssdb"), filepath.Join(home, ".mozilla/firefox/abc.default-release")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NSSDatabases = %v, want %v", got, want)
+	}
+}
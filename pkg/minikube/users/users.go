@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package users issues client certificates signed by the cluster CA, to authenticate as other users than the
+// minikube-user admin.
+//
+// Kubernetes does not check the revocation of client certificates, so a user is revoked by deleting the RBAC
+// bindings minikube created for it. The permissions of its groups last until its certificate expires or the CA is
+// rotated.
+package users
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+// UserLabel labels the RBAC bindings of a user, which are deleted when it is revoked
+const UserLabel = "minikube.sigs.k8s.io/user"
+
+// ErrNotFound is returned for a user without a certificate in the profile
+var ErrNotFound = errors.New("no such user")
+
+// nameRe matches the user names, which are also file names and label values
+var nameRe = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?$`)
+
+// User is a client user of a cluster
+type User struct {
+	Name    string
+	Groups  []string
+	Expires time.Time
+	Context string
+}
+
+// ValidateName returns an error if a name cannot be the one of a user
+func ValidateName(name string) error {
+	if !nameRe.MatchString(name) {
+		return fmt.Errorf("invalid user name %q: use up to 63 letters, digits, '.', '_' or '-', starting and ending with a letter or digit", name)
+	}
+	if name == "minikube-user" {
+		return fmt.Errorf("%q is the admin user of the cluster", name)
+	}
+	return nil
+}
+
+// Dir returns the directory of the users of a profile
+func Dir(profile string) string {
+	return filepath.Join(localpath.Profile(profile), "users")
+}
+
+// CertPath returns the path of the client certificate of a user
+func CertPath(profile, name string) string {
+	return filepath.Join(Dir(profile), name+".crt")
+}
+
+// KeyPath returns the path of the client key of a user
+func KeyPath(profile, name string) string {
+	return filepath.Join(Dir(profile), name+".key")
+}
+
+// ContextName returns the name of the kubeconfig context and user of a user
+func ContextName(profile, name string) string {
+	return name + "@" + profile
+}
+
+// Add issues the client certificate of a user in groups, signed by the cluster CA, replacing any previous one
+func Add(profile, name string, groups []string, ttl time.Duration) (*User, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+	if err := util.GenerateClientCert(CertPath(profile, name), KeyPath(profile, name), name, groups, localpath.ProfileCACert(profile), localpath.ProfileCAKey(profile), ttl); err != nil {
+		return nil, errors.Wrapf(err, "generating the certificate of %s", name)
+	}
+	return Get(profile, name)
+}
+
+// Get returns a user of a profile
+func Get(profile, name string) (*User, error) {
+	data, err := os.ReadFile(CertPath(profile, name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading certificate")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("unable to decode the certificate of %s", name)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing the certificate of %s", name)
+	}
+	groups := append([]string{}, cert.Subject.Organization...)
+	sort.Strings(groups)
+	return &User{Name: cert.Subject.CommonName, Groups: groups, Expires: cert.NotAfter, Context: ContextName(profile, name)}, nil
+}
+
+// List returns the users of a profile, sorted by name
+func List(profile string) ([]User, error) {
+	entries, err := os.ReadDir(Dir(profile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "reading users")
+	}
+	users := []User{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".crt")
+		if !ok || e.IsDir() {
+			continue
+		}
+		u, err := Get(profile, name)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, nil
+}
+
+// Remove deletes the client certificate and key of a user
+func Remove(profile, name string) error {
+	if err := os.Remove(CertPath(profile, name)); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return errors.Wrap(err, "removing certificate")
+	}
+	if err := os.Remove(KeyPath(profile, name)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "removing key")
+	}
+	return nil
+}
+
+// bindingName returns the name of the RBAC binding of a user
+func bindingName(name string) string {
+	return "minikube:user:" + name
+}
+
+// kubectl runs kubectl in the control plane with a timeout
+func kubectl(cc config.ClusterConfig, runner command.Runner, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	args = append([]string{"sudo", kapi.KubectlBinaryPath(cc.KubernetesConfig.KubernetesVersion), fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig"))}, args...)
+	if rr, err := runner.RunCmd(exec.CommandContext(ctx, args[0], args[1:]...)); err != nil {
+		return errors.Wrapf(err, "cmd: %s output: %s", rr.Command(), rr.Output())
+	}
+	return nil
+}
+
+// Bind binds a user to a cluster role, in a namespace or cluster wide if the namespace is empty, replacing its
+// previous bindings, using the kubectl of the control plane node runner
+func Bind(cc config.ClusterConfig, runner command.Runner, name, clusterRole, namespace string) error {
+	kind, scope := "clusterrolebinding", []string{}
+	if namespace != "" {
+		kind, scope = "rolebinding", []string{"--namespace", namespace}
+	}
+	if err := Unbind(cc, runner, name); err != nil {
+		return err
+	}
+	create := append([]string{"create", kind, bindingName(name), "--clusterrole=" + clusterRole, "--user=" + name}, scope...)
+	if err := kubectl(cc, runner, create...); err != nil {
+		return err
+	}
+	label := append([]string{"label", kind, bindingName(name), UserLabel + "=" + name}, scope...)
+	return kubectl(cc, runner, label...)
+}
+
+// Unbind deletes the RBAC bindings labeled with a user, using the kubectl of the control plane node runner
+func Unbind(cc config.ClusterConfig, runner command.Runner, name string) error {
+	klog.Infof("deleting the RBAC bindings of %s", name)
+	return kubectl(cc, runner, "delete", "clusterrolebindings,rolebindings", "--all-namespaces", "--ignore-not-found", "-l", UserLabel+"="+name)
+}
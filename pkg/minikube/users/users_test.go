@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package users
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/util"
+)
+
+func TestValidateName(t *testing.T) {
+	for _, name := range []string{"alice", "bob.smith", "ci_bot-2", "A"} {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) = %v, want valid", name, err)
+		}
+	}
+	for _, name := range []string{"", "-alice", "alice-", "system:admin", "a/b", "minikube-user", strings.Repeat("a", 64)} {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) = nil, want an error", name)
+		}
+	}
+}
+
+func TestUsers(t *testing.T) {
+	t.Setenv(localpath.MinikubeHome, t.TempDir())
+	if err := util.GenerateCACert(localpath.CACert(), filepath.Join(localpath.MiniPath(), "ca.key"), "minikubeCA"); err != nil {
+		t.Fatalf("GenerateCACert: %v", err)
+	}
+
+	if users, err := List("p"); err != nil || len(users) != 0 {
+		t.Fatalf("List without users = %v, %v; want none", users, err)
+	}
+
+	bob, err := Add("p", "bob", nil, time.Hour)
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if time.Until(bob.Expires) > time.Hour {
+		t.Errorf("bob expires at %s, want within an hour", bob.Expires)
+	}
+	if _, err := Add("p", "alice", []string{"qa", "dev"}, time.Hour); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	users, err := List("p")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	got := []User{}
+	for _, u := range users {
+		got = append(got, User{Name: u.Name, Groups: u.Groups, Context: u.Context})
+	}
+	want := []User{
+		{Name: "alice", Groups: []string{"dev", "qa"}, Context: "alice@p"},
+		{Name: "bob", Groups: []string{}, Context: "bob@p"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("List mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := Remove("p", "bob"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := Remove("p", "bob"); err != ErrNotFound {
+		t.Errorf("Remove of a removed user = %v, want %v", err, ErrNotFound)
+	}
+	if _, err := Get("p", "bob"); err != ErrNotFound {
+		t.Errorf("Get of a removed user = %v, want %v", err, ErrNotFound)
+	}
+}
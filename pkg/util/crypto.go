@@ -23,10 +23,12 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"fmt"
 	"math/big"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -66,29 +68,9 @@ func GenerateCACert(certPath, keyPath string, name string) error {
 // GenerateSignedCert generates a signed certificate and key
 func GenerateSignedCert(certPath, keyPath, cn string, ips []net.IP, alternateDNS []string, signerCertPath, signerKeyPath string, expiration time.Duration) error {
 	klog.Infof("Generating cert %s with IP's: %s", certPath, ips)
-	signerCertBytes, err := os.ReadFile(signerCertPath)
-	if err != nil {
-		return errors.Wrap(err, "Error reading file: signerCertPath")
-	}
-	decodedSignerCert, _ := pem.Decode(signerCertBytes)
-	if decodedSignerCert == nil {
-		return errors.New("Unable to decode certificate")
-	}
-	signerCert, err := x509.ParseCertificate(decodedSignerCert.Bytes)
-	if err != nil {
-		return errors.Wrap(err, "Error parsing certificate: decodedSignerCert.Bytes")
-	}
-	signerKeyBytes, err := os.ReadFile(signerKeyPath)
-	if err != nil {
-		return errors.Wrap(err, "Error reading file: signerKeyPath")
-	}
-	decodedSignerKey, _ := pem.Decode(signerKeyBytes)
-	if decodedSignerKey == nil {
-		return errors.New("Unable to decode key")
-	}
-	signerKey, err := x509.ParsePKCS1PrivateKey(decodedSignerKey.Bytes)
+	signerCert, signerKey, err := loadSigner(signerCertPath, signerKeyPath)
 	if err != nil {
-		return errors.Wrap(err, "Error parsing private key: decodedSignerKey.Bytes")
+		return err
 	}
 
 	template := x509.Certificate{
@@ -116,6 +98,200 @@ func GenerateSignedCert(certPath, keyPath, cn string, ips []net.IP, alternateDNS
 	return writeCertsAndKeys(&template, certPath, priv, keyPath, signerCert, signerKey)
 }
 
+// GenerateClientCert generates a client certificate and key for a user and its groups, with a random serial number
+func GenerateClientCert(certPath, keyPath, user string, groups []string, signerCertPath, signerKeyPath string, expiration time.Duration) error {
+	klog.Infof("Generating client cert %s for %s in %v", certPath, user, groups)
+	signerCert, signerKey, err := loadSigner(signerCertPath, signerKeyPath)
+	if err != nil {
+		return err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return errors.Wrap(err, "Error generating serial number")
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   user,
+			Organization: groups,
+		},
+		NotBefore: time.Now().Add(time.Hour * -24),
+		NotAfter:  time.Now().Add(expiration),
+
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return errors.Wrap(err, "Error generating RSA key")
+	}
+
+	return writeCertsAndKeys(&template, certPath, priv, keyPath, signerCert, signerKey)
+}
+
+// GenerateServingCert generates a serving certificate and key for DNS names and IPs, with a random serial number,
+// and returns them PEM encoded
+func GenerateServingCert(hosts []string, signerCertPath, signerKeyPath string, expiration time.Duration) ([]byte, []byte, error) {
+	klog.Infof("Generating serving cert for %v", hosts)
+	if len(hosts) == 0 {
+		return nil, nil, errors.New("no host to generate a serving cert for")
+	}
+	signerCert, signerKey, err := loadSigner(signerCertPath, signerKeyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error generating serial number")
+	}
+
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName: hosts[0],
+		},
+		NotBefore: time.Now().Add(time.Hour * -1),
+		NotAfter:  time.Now().Add(expiration),
+
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error generating RSA key")
+	}
+	return encodeCertAndKey(&template, priv, signerCert, signerKey)
+}
+
+// loadSigner loads the certificate and RSA key of a CA
+func loadSigner(signerCertPath, signerKeyPath string) (*x509.Certificate, *rsa.PrivateKey, error) {
+	signerCertBytes, err := os.ReadFile(signerCertPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error reading file: signerCertPath")
+	}
+	decodedSignerCert, _ := pem.Decode(signerCertBytes)
+	if decodedSignerCert == nil {
+		return nil, nil, errors.New("Unable to decode certificate")
+	}
+	signerCert, err := x509.ParseCertificate(decodedSignerCert.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error parsing certificate: decodedSignerCert.Bytes")
+	}
+	signerKeyBytes, err := os.ReadFile(signerKeyPath)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error reading file: signerKeyPath")
+	}
+	decodedSignerKey, _ := pem.Decode(signerKeyBytes)
+	if decodedSignerKey == nil {
+		return nil, nil, errors.New("Unable to decode key")
+	}
+	signerKey, err := parseRSAPrivateKey(decodedSignerKey.Bytes)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "Error parsing private key: decodedSignerKey.Bytes")
+	}
+	return signerCert, signerKey, nil
+}
+
+// parseRSAPrivateKey parses a PKCS #1 or PKCS #8 RSA private key, as supplied CAs may use either
+func parseRSAPrivateKey(der []byte) (*rsa.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported %T private key, only RSA keys are supported", key)
+	}
+	return rsaKey, nil
+}
+
+// ValidateCACert checks that a CA certificate and key can sign the server and client certificates of a cluster,
+// and returns the CA certificate. The certificate file may be followed by the chain of the CA, whose path length
+// constraints must allow it.
+func ValidateCACert(certPath, keyPath string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading certificate")
+	}
+	chain := []*x509.Certificate{}
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing certificate %d of %s", len(chain)+1, certPath)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no PEM certificate in %s", certPath)
+	}
+
+	ca := chain[0]
+	if !ca.BasicConstraintsValid || !ca.IsCA {
+		return nil, fmt.Errorf("%q is not a CA: its basic constraints do not allow it to sign certificates", ca.Subject.CommonName)
+	}
+	if ca.KeyUsage != 0 && ca.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return nil, fmt.Errorf("the key usage of %q does not include certificate signing", ca.Subject.CommonName)
+	}
+	// the extended key usages of a CA restrict the ones of the certificates it signs
+	if len(ca.ExtKeyUsage) > 0 && !slices.Contains(ca.ExtKeyUsage, x509.ExtKeyUsageAny) {
+		for _, usage := range []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth} {
+			if !slices.Contains(ca.ExtKeyUsage, usage) {
+				return nil, fmt.Errorf("the extended key usage of %q does not allow server and client authentication", ca.Subject.CommonName)
+			}
+		}
+	}
+	now := time.Now()
+	if now.Before(ca.NotBefore) || now.After(ca.NotAfter) {
+		return nil, fmt.Errorf("%q is only valid from %s to %s", ca.Subject.CommonName, ca.NotBefore, ca.NotAfter)
+	}
+
+	// chain[i] is followed by the i CAs before it in the chain, then by the certificates they sign
+	for i := 1; i < len(chain); i++ {
+		parent := chain[i]
+		if err := chain[i-1].CheckSignatureFrom(parent); err != nil {
+			return nil, errors.Wrapf(err, "%q is not signed by the next certificate of the chain, %q", chain[i-1].Subject.CommonName, parent.Subject.CommonName)
+		}
+		if (parent.MaxPathLen > 0 || parent.MaxPathLenZero) && i > parent.MaxPathLen {
+			return nil, fmt.Errorf("the path length of %q allows %d intermediate CAs below it, not %d", parent.Subject.CommonName, parent.MaxPathLen, i)
+		}
+	}
+
+	keyData, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading key")
+	}
+	block, _ := pem.Decode(keyData)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM key in %s", keyPath)
+	}
+	key, err := parseRSAPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing %s", keyPath)
+	}
+	if !key.PublicKey.Equal(ca.PublicKey) {
+		return nil, fmt.Errorf("the key %s does not match the certificate %q", keyPath, ca.Subject.CommonName)
+	}
+	return ca, nil
+}
+
 func loadOrGeneratePrivateKey(keyPath string) (*rsa.PrivateKey, error) {
 	keyBytes, err := os.ReadFile(keyPath)
 	if err == nil {
@@ -134,27 +310,36 @@ func loadOrGeneratePrivateKey(keyPath string) (*rsa.PrivateKey, error) {
 	return priv, nil
 }
 
-func writeCertsAndKeys(template *x509.Certificate, certPath string, signeeKey *rsa.PrivateKey, keyPath string, parent *x509.Certificate, signingKey *rsa.PrivateKey) error {
+// encodeCertAndKey signs a certificate template and returns the PEM encoded certificate and key
+func encodeCertAndKey(template *x509.Certificate, signeeKey *rsa.PrivateKey, parent *x509.Certificate, signingKey *rsa.PrivateKey) ([]byte, []byte, error) {
 	derBytes, err := x509.CreateCertificate(rand.Reader, template, parent, &signeeKey.PublicKey, signingKey)
 	if err != nil {
-		return errors.Wrap(err, "Error creating certificate")
+		return nil, nil, errors.Wrap(err, "Error creating certificate")
 	}
 
 	certBuffer := bytes.Buffer{}
 	if err := pem.Encode(&certBuffer, &pem.Block{Type: "CERTIFICATE", Bytes: derBytes}); err != nil {
-		return errors.Wrap(err, "Error encoding certificate")
+		return nil, nil, errors.Wrap(err, "Error encoding certificate")
 	}
 
 	keyBuffer := bytes.Buffer{}
 	if err := pem.Encode(&keyBuffer, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(signeeKey)}); err != nil {
-		return errors.Wrap(err, "Error encoding key")
+		return nil, nil, errors.Wrap(err, "Error encoding key")
+	}
+	return certBuffer.Bytes(), keyBuffer.Bytes(), nil
+}
+
+func writeCertsAndKeys(template *x509.Certificate, certPath string, signeeKey *rsa.PrivateKey, keyPath string, parent *x509.Certificate, signingKey *rsa.PrivateKey) error {
+	certData, keyData, err := encodeCertAndKey(template, signeeKey, parent, signingKey)
+	if err != nil {
+		return err
 	}
 
 	if err := os.MkdirAll(filepath.Dir(certPath), os.FileMode(0755)); err != nil {
 		return errors.Wrap(err, "Error creating certificate directory")
 	}
 	klog.Infof("Writing cert to %s ...", certPath)
-	if err := lock.WriteFile(certPath, certBuffer.Bytes(), os.FileMode(0644)); err != nil {
+	if err := lock.WriteFile(certPath, certData, os.FileMode(0644)); err != nil {
 		return errors.Wrap(err, "Error writing certificate to cert path")
 	}
 
@@ -162,7 +347,7 @@ func writeCertsAndKeys(template *x509.Certificate, certPath string, signeeKey *r
 		return errors.Wrap(err, "Error creating key directory")
 	}
 	klog.Infof("Writing key to %s ...", keyPath)
-	if err := lock.WriteFile(keyPath, keyBuffer.Bytes(), os.FileMode(0600)); err != nil {
+	if err := lock.WriteFile(keyPath, keyData, os.FileMode(0600)); err != nil {
 		return errors.Wrap(err, "Error writing key file")
 	}
 
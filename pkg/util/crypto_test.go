@@ -17,12 +17,19 @@ limitations under the License.
 package util
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"math/big"
 	"net"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"testing"
+	"time"
 
 	"k8s.io/minikube/pkg/minikube/constants"
 )
@@ -133,3 +140,200 @@ func TestGenerateSignedCert(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateClientCert(t *testing.T) {
+	tmpDir := t.TempDir()
+	signerCertPath := filepath.Join(tmpDir, "ca.crt")
+	signerKeyPath := filepath.Join(tmpDir, "ca.key")
+	if err := GenerateCACert(signerCertPath, signerKeyPath, constants.APIServerName); err != nil {
+		t.Fatalf("Error generating signer cert")
+	}
+
+	certPath := filepath.Join(tmpDir, "alice.crt")
+	keyPath := filepath.Join(tmpDir, "alice.key")
+	groups := []string{"dev", "qa"}
+	if err := GenerateClientCert(certPath, keyPath, "alice", groups, signerCertPath, signerKeyPath, time.Hour); err != nil {
+		t.Fatalf("GenerateClientCert() error = %v", err)
+	}
+
+	certBytes, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatalf("Error reading cert data: %v", err)
+	}
+	data, _ := pem.Decode(certBytes)
+	c, err := x509.ParseCertificate(data.Bytes)
+	if err != nil {
+		t.Fatalf("Error parsing certificate: %v", err)
+	}
+	// the groups are a DER set, which is not ordered
+	got := append([]string{}, c.Subject.Organization...)
+	sort.Strings(got)
+	if c.Subject.CommonName != "alice" || !reflect.DeepEqual(got, groups) {
+		t.Errorf("subject = %v, want alice in %v", c.Subject, groups)
+	}
+	if !reflect.DeepEqual(c.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}) {
+		t.Errorf("ext key usage = %v, want client auth only", c.ExtKeyUsage)
+	}
+	if time.Until(c.NotAfter) > time.Hour {
+		t.Errorf("expires at %s, want within an hour", c.NotAfter)
+	}
+}
+
+// newTestCert creates a certificate from a template, signed by parent or self-signed if parent is nil
+func newTestCert(t *testing.T, tmpl *x509.Certificate, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	return cert, key
+}
+
+func TestValidateCACert(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name string, certs []*x509.Certificate, key *rsa.PrivateKey, pkcs8 bool) (string, string) {
+		certPath := filepath.Join(tmpDir, name+".crt")
+		keyPath := filepath.Join(tmpDir, name+".key")
+		var data []byte
+		for _, c := range certs {
+			data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+		}
+		if err := os.WriteFile(certPath, data, 0644); err != nil {
+			t.Fatal(err)
+		}
+		block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+		if pkcs8 {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+		}
+		if err := os.WriteFile(keyPath, pem.EncodeToMemory(block), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return certPath, keyPath
+	}
+	ca := func(cn string, usage x509.KeyUsage, extUsage []x509.ExtKeyUsage, maxPathLen int) *x509.Certificate {
+		return &x509.Certificate{
+			Subject:               pkix.Name{CommonName: cn},
+			KeyUsage:              usage,
+			ExtKeyUsage:           extUsage,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+			MaxPathLen:            maxPathLen,
+			MaxPathLenZero:        maxPathLen == 0,
+		}
+	}
+
+	root, rootKey := newTestCert(t, ca("root", x509.KeyUsageCertSign, nil, 1), nil, nil)
+	strictRoot, strictRootKey := newTestCert(t, ca("strict root", x509.KeyUsageCertSign, nil, 0), nil, nil)
+	intermediate, intermediateKey := newTestCert(t, ca("intermediate", x509.KeyUsageCertSign|x509.KeyUsageDigitalSignature, nil, 0), root, rootKey)
+	tooDeep, tooDeepKey := newTestCert(t, ca("too deep", x509.KeyUsageCertSign, nil, 0), strictRoot, strictRootKey)
+	noCertSign, noCertSignKey := newTestCert(t, ca("no cert sign", x509.KeyUsageDigitalSignature, nil, -1), root, rootKey)
+	serverOnly, serverOnlyKey := newTestCert(t, ca("server only", x509.KeyUsageCertSign, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, -1), root, rootKey)
+	leaf, leafKey := newTestCert(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf"}, KeyUsage: x509.KeyUsageDigitalSignature}, intermediate, intermediateKey)
+
+	minikubeCert, minikubeKey := filepath.Join(tmpDir, "minikube.crt"), filepath.Join(tmpDir, "minikube.key")
+	if err := GenerateCACert(minikubeCert, minikubeKey, "minikubeCA"); err != nil {
+		t.Fatalf("GenerateCACert() error = %v", err)
+	}
+
+	chainCert, chainKey := write("intermediate", []*x509.Certificate{intermediate, root}, intermediateKey, true)
+	tooDeepCert, tooDeepKeyPath := write("too-deep", []*x509.Certificate{tooDeep, strictRoot}, tooDeepKey, false)
+	noCertSignCert, noCertSignKeyPath := write("no-cert-sign", []*x509.Certificate{noCertSign}, noCertSignKey, false)
+	serverOnlyCert, serverOnlyKeyPath := write("server-only", []*x509.Certificate{serverOnly}, serverOnlyKey, false)
+	leafCert, leafKeyPath := write("leaf", []*x509.Certificate{leaf}, leafKey, false)
+	wrongKeyCert, wrongKeyPath := write("wrong-key", []*x509.Certificate{intermediate}, rootKey, false)
+
+	tests := []struct {
+		name     string
+		certPath string
+		keyPath  string
+		wantErr  bool
+	}{
+		{name: "minikube CA", certPath: minikubeCert, keyPath: minikubeKey},
+		{name: "intermediate with chain and PKCS #8 key", certPath: chainCert, keyPath: chainKey},
+		{name: "path length exceeded", certPath: tooDeepCert, keyPath: tooDeepKeyPath, wantErr: true},
+		{name: "no certificate signing", certPath: noCertSignCert, keyPath: noCertSignKeyPath, wantErr: true},
+		{name: "server authentication only", certPath: serverOnlyCert, keyPath: serverOnlyKeyPath, wantErr: true},
+		{name: "not a CA", certPath: leafCert, keyPath: leafKeyPath, wantErr: true},
+		{name: "key of another certificate", certPath: wrongKeyCert, keyPath: wrongKeyPath, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ValidateCACert(tc.certPath, tc.keyPath)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("ValidateCACert() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+
+	// the certificates signed with an intermediate verify against the CA file
+	certPath, keyPath := filepath.Join(tmpDir, "apiserver.crt"), filepath.Join(tmpDir, "apiserver.key")
+	if err := GenerateSignedCert(certPath, keyPath, "minikube", nil, []string{"localhost"}, chainCert, chainKey, time.Hour); err != nil {
+		t.Fatalf("GenerateSignedCert() with an intermediate error = %v", err)
+	}
+	data, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	signed, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(intermediate)
+	if _, err := signed.Verify(x509.VerifyOptions{DNSName: "localhost", Roots: roots, Intermediates: intermediates}); err != nil {
+		t.Errorf("certificate signed by the intermediate does not verify: %v", err)
+	}
+}
+
+func TestGenerateServingCert(t *testing.T) {
+	tmpDir := t.TempDir()
+	signerCertPath := filepath.Join(tmpDir, "ca.crt")
+	signerKeyPath := filepath.Join(tmpDir, "ca.key")
+	if err := GenerateCACert(signerCertPath, signerKeyPath, constants.APIServerName); err != nil {
+		t.Fatalf("Error generating signer cert")
+	}
+
+	if _, _, err := GenerateServingCert(nil, signerCertPath, signerKeyPath, time.Hour); err == nil {
+		t.Errorf("GenerateServingCert() without hosts error = nil, want an error")
+	}
+	certPEM, keyPEM, err := GenerateServingCert([]string{"hello.test", "*.hello.test", "10.0.0.1"}, signerCertPath, signerKeyPath, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateServingCert() error = %v", err)
+	}
+	data, _ := pem.Decode(certPEM)
+	c, err := x509.ParseCertificate(data.Bytes)
+	if err != nil {
+		t.Fatalf("Error parsing certificate: %v", err)
+	}
+	if c.Subject.CommonName != "hello.test" || !reflect.DeepEqual(c.DNSNames, []string{"hello.test", "*.hello.test"}) || len(c.IPAddresses) != 1 {
+		t.Errorf("names = %q %v %v, want hello.test, *.hello.test and 10.0.0.1", c.Subject.CommonName, c.DNSNames, c.IPAddresses)
+	}
+	if !reflect.DeepEqual(c.ExtKeyUsage, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}) {
+		t.Errorf("ext key usage = %v, want server auth only", c.ExtKeyUsage)
+	}
+	if block, _ := pem.Decode(keyPEM); block == nil || block.Type != "RSA PRIVATE KEY" {
+		t.Errorf("key is not a PEM RSA private key")
+	}
+}